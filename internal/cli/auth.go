@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"repomedic/internal/config"
+	"repomedic/internal/engine"
+	"repomedic/internal/flags"
+	gh "repomedic/internal/github"
+	"repomedic/internal/rules"
+
+	"github.com/google/go-github/v81/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	authStatusOrg       string
+	authStatusFormat    string
+	authStatusTokenFile string
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Inspect GitHub authentication",
+	Long: `Inspect the GitHub token(s) RepoMedic would use for a scan.
+
+Examples:
+  # Show token source, scopes, identity and rule impact
+  repomedic auth status --org my-org
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the token source, scopes, identity and which rules it degrades",
+	Long: `Show what RepoMedic knows about the GitHub token it would scan with.
+
+Reports:
+  source       where the token came from (explicit, env:GITHUB_TOKEN, gh,
+               file, env:GITHUB_TOKENS, app)
+  type         classic PAT, fine-grained PAT, GitHub App, OAuth, or gh
+  login        the authenticated user (empty for App installation tokens)
+  scopes       X-OAuth-Scopes (classic and OAuth tokens only)
+  sso          whether the token is SSO-authorized for --org
+  rate limits  core, search and GraphQL budgets
+  rule impact  registered rules that would be SKIPPED, ERROR or DEGRADED,
+               based on the permissions each data fetcher declares
+
+Scopes are checked exactly. Repository roles and fine-grained permissions
+cannot be read from a token, so rules needing them are listed as unverified.
+
+Tokens are resolved like scan resolves them: one per --app-installation,
+else --token-file, then GITHUB_TOKENS, then the single token. With a pool
+every token is reported ("Token 2 of 3"), and --format json prints an array
+with one report per token.
+
+Examples:
+  repomedic auth status
+  repomedic auth status --org my-org --format json
+  GITHUB_TOKENS="ghp_a...,ghp_b..." repomedic auth status --org my-org
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := strings.ToLower(strings.TrimSpace(authStatusFormat))
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unsupported --format: %s (must be one of: text, json)", authStatusFormat)
+		}
+		org := ""
+		if authStatusOrg != "" {
+			cfgOrg := config.New()
+			cfgOrg.Targeting.Org = authStatusOrg
+			if err := cfgOrg.Validate(); err != nil {
+				return err
+			}
+			org = cfgOrg.Targeting.Org
+		}
+
+		if err := cfg.Runtime.Network.Validate(); err != nil {
+			return err
+		}
+		if err := cfg.Runtime.App.Validate(); err != nil {
+			return err
+		}
+		if cfg.Runtime.App.Enabled() && authStatusTokenFile != "" {
+			return fmt.Errorf("--%s cannot be combined with --%s", flags.FlagTokenFile, flags.FlagAppInstallation)
+		}
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		creds, source, err := resolvePoolCredentials(ctx, authStatusTokenFile, cfg.Runtime.App)
+		if err != nil {
+			return err
+		}
+		if len(creds) == 0 {
+			return errors.New("no GitHub auth token found (set GITHUB_TOKEN or run 'gh auth login')")
+		}
+
+		statuses := make([]*authStatus, 0, len(creds))
+		for i, c := range creds {
+			status, err := buildAuthStatus(ctx, c.client, c.token, source, org, rules.List())
+			if err != nil {
+				if len(creds) > 1 {
+					return fmt.Errorf("token %d: %w", i+1, err)
+				}
+				return err
+			}
+			if len(creds) > 1 {
+				status.PoolMember, status.PoolSize = i+1, len(creds)
+			}
+			statuses = append(statuses, status)
+		}
+
+		if format == "json" {
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			if len(statuses) == 1 {
+				return enc.Encode(statuses[0])
+			}
+			return enc.Encode(statuses)
+		}
+		for i, status := range statuses {
+			if i > 0 {
+				if _, err := io.WriteString(cmd.OutOrStdout(), "\n"); err != nil {
+					return err
+				}
+			}
+			if err := writeAuthStatusText(cmd.OutOrStdout(), status); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// authStatus is the report printed by "auth status". It never includes the token.
+type authStatus struct {
+	// PoolMember and PoolSize number the token within a token pool (1-based);
+	// both are zero for a single token.
+	PoolMember int                 `json:"pool_member,omitempty"`
+	PoolSize   int                 `json:"pool_size,omitempty"`
+	Source     gh.AuthTokenSource  `json:"source"`
+	Type       gh.TokenKind        `json:"type"`
+	Login      string              `json:"login,omitempty"`
+	Scopes     []string            `json:"scopes"`
+	SSO        *gh.SSOStatus       `json:"sso,omitempty"`
+	RateLimits *github.RateLimits  `json:"rate_limits,omitempty"`
+	RuleImpact []engine.RuleImpact `json:"rule_impact"`
+}
+
+func buildAuthStatus(ctx context.Context, client *gh.Client, token string, source gh.AuthTokenSource, org string, ruleList []rules.Rule) (*authStatus, error) {
+	info, err := client.InspectAuth(ctx, org)
+	if err != nil {
+		return nil, err
+	}
+	kind := gh.DetectTokenKind(token, source)
+	impact, err := engine.PermissionImpact(ctx, ruleList, engine.TokenPermissionCheck(kind, info.Scopes))
+	if err != nil {
+		return nil, err
+	}
+	if impact == nil {
+		impact = []engine.RuleImpact{}
+	}
+	return &authStatus{
+		Source:     source,
+		Type:       kind,
+		Login:      info.Login,
+		Scopes:     info.Scopes,
+		SSO:        info.SSO,
+		RateLimits: info.RateLimits,
+		RuleImpact: impact,
+	}, nil
+}
+
+func writeAuthStatusText(w io.Writer, s *authStatus) error {
+	var b strings.Builder
+	if s.PoolSize > 0 {
+		fmt.Fprintf(&b, "Token %d of %d\n", s.PoolMember, s.PoolSize)
+	}
+	fmt.Fprintf(&b, "Token source:  %s\n", s.Source)
+	fmt.Fprintf(&b, "Token type:    %s\n", s.Type)
+	login := s.Login
+	if login == "" {
+		login = "(none; not a user token)"
+	}
+	fmt.Fprintf(&b, "Login:         %s\n", login)
+	switch {
+	case s.Scopes == nil:
+		b.WriteString("Scopes:        n/a (fine-grained and App tokens use permissions)\n")
+	case len(s.Scopes) == 0:
+		b.WriteString("Scopes:        (none)\n")
+	default:
+		fmt.Fprintf(&b, "Scopes:        %s\n", strings.Join(s.Scopes, ", "))
+	}
+	if s.SSO != nil {
+		fmt.Fprintf(&b, "SSO (%s):  %s\n", s.SSO.Org, describeSSO(s.SSO))
+	}
+
+	if rl := s.RateLimits; rl != nil {
+		b.WriteString("Rate limits:\n")
+		for _, l := range []struct {
+			name string
+			rate *github.Rate
+		}{{"core", rl.Core}, {"search", rl.Search}, {"graphql", rl.GraphQL}} {
+			if l.rate == nil {
+				continue
+			}
+			fmt.Fprintf(&b, "  %-8s %d/%d (resets %s)\n", l.name, l.rate.Remaining, l.rate.Limit, l.rate.Reset.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if len(s.RuleImpact) == 0 {
+		b.WriteString("Rule impact:   none (every registered rule has the permissions it declares)\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+	b.WriteString("Rule impact:\n")
+	for _, ri := range s.RuleImpact {
+		var needs []string
+		for _, m := range ri.Missing {
+			needs = append(needs, fmt.Sprintf("%s (%s)", m.Permission, m.Key))
+		}
+		suffix := ""
+		if !ri.Verified() {
+			suffix = " [unverified]"
+		}
+		fmt.Fprintf(&b, "  %-8s %s%s\n           needs %s\n", ri.Outcome, ri.RuleID, suffix, strings.Join(needs, "; "))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func describeSSO(st *gh.SSOStatus) string {
+	switch st.State {
+	case gh.SSOStateRequired:
+		if st.URL != "" {
+			return "token not authorized for SSO; authorize at " + st.URL
+		}
+		return "token not authorized for SSO"
+	case gh.SSOStatePartial:
+		return "partially authorized (some SSO orgs are not authorized)"
+	case gh.SSOStateError:
+		return "could not check (" + st.Message + ")"
+	default:
+		return "ok"
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authStatusCmd.Flags().StringVar(&authStatusOrg, "org", "", "Organization to check SSO authorization against (name or URL)")
+	authStatusCmd.Flags().StringVar(&authStatusFormat, "format", "text", "Output format: text|json (default: text)")
+	authStatusCmd.Flags().StringVar(&authStatusTokenFile, flags.FlagTokenFile, "", "Report on the tokens in this file, one per line, as scan --token-file would use them")
+	addAppFlags(authStatusCmd.Flags(), &cfg.Runtime.App)
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"repomedic/internal/data"
+	"repomedic/internal/engine"
+	"repomedic/internal/fetcher"
+	gh "repomedic/internal/github"
+)
+
+func TestWriteAuthStatusText(t *testing.T) {
+	s := &authStatus{
+		Source: gh.AuthTokenSourceEnv,
+		Type:   gh.TokenKindClassic,
+		Login:  "octocat",
+		Scopes: []string{"repo"},
+		SSO:    &gh.SSOStatus{Org: "acme", State: gh.SSOStateRequired, URL: "https://github.com/orgs/acme/sso"},
+		RuleImpact: []engine.RuleImpact{{
+			RuleID:  "default-branch-protected",
+			Outcome: "SKIPPED",
+			Missing: []engine.MissingPermission{{
+				Key:        data.DepRepoDefaultBranchClassicProtection,
+				Permission: fetcher.Permission{Scope: "repo", Role: "admin"},
+			}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := writeAuthStatusText(&buf, s); err != nil {
+		t.Fatalf("writeAuthStatusText: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Token source:  env:GITHUB_TOKEN",
+		"Token type:    classic PAT",
+		"Login:         octocat",
+		"Scopes:        repo",
+		"authorize at https://github.com/orgs/acme/sso",
+		"SKIPPED  default-branch-protected [unverified]",
+		"needs scope repo, repo role admin (repo.default_branch_protection)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteAuthStatusText_NoScopesNoImpact(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeAuthStatusText(&buf, &authStatus{Source: gh.AuthTokenSourceEnv, Type: gh.TokenKindApp}); err != nil {
+		t.Fatalf("writeAuthStatusText: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "Scopes:        n/a") || !strings.Contains(out, "Rule impact:   none") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
+
+func TestWriteAuthStatusText_PoolMember(t *testing.T) {
+	var buf bytes.Buffer
+	s := &authStatus{PoolMember: 2, PoolSize: 3, Source: gh.AuthTokenSourceEnvPool, Type: gh.TokenKindClassic}
+	if err := writeAuthStatusText(&buf, s); err != nil {
+		t.Fatalf("writeAuthStatusText: %v", err)
+	}
+	if out := buf.String(); !strings.HasPrefix(out, "Token 2 of 3\nToken source:  env:GITHUB_TOKENS\n") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
+	"repomedic/internal/rules"
+
+	"github.com/google/go-github/v81/github"
+	"github.com/spf13/cobra"
+)
+
+var depsGraphFormat string
+
+var depsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Inspect data dependencies between rules and fetchers",
+	Long: `Inspect how rules depend on data and how that data is fetched.
+
+Rules declare dependency keys; each key is produced by exactly one fetcher,
+and fetchers may declare upstream keys they read from other fetchers.
+
+Examples:
+  # Render the dependency graph as Graphviz DOT
+  repomedic deps graph | dot -Tsvg > deps.svg
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cmd.Help()
+	},
+}
+
+var depsGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Render the rule -> key -> fetcher dependency graph",
+	Long: `Render the dependency graph for all rules and fetchers registered in this build.
+
+Nodes:
+  rule     a registered rule
+  key      a data dependency key (with its fetch priority)
+  fetcher  the fetcher that produces a key (with its scope: repo or org)
+
+Edges:
+  requires     rule -> key the rule declares as a dependency
+  provided_by  key -> fetcher that produces it
+  upstream     fetcher -> key the fetcher reads via another fetcher
+
+Examples:
+  repomedic deps graph
+  repomedic deps graph --format mermaid
+  repomedic deps graph --format json
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g, err := buildDepsGraph(cmd.Context(), rules.List(), fetcher.ListDataFetchers())
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(strings.TrimSpace(depsGraphFormat)) {
+		case "dot", "":
+			return writeDepsGraphDOT(cmd.OutOrStdout(), g)
+		case "mermaid":
+			return writeDepsGraphMermaid(cmd.OutOrStdout(), g)
+		case "json":
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(g)
+		default:
+			return fmt.Errorf("unsupported --format: %s (must be one of: dot, mermaid, json)", depsGraphFormat)
+		}
+	},
+}
+
+type depsGraph struct {
+	Nodes []depsNode `json:"nodes"`
+	Edges []depsEdge `json:"edges"`
+}
+
+type depsNode struct {
+	ID       string `json:"id"`
+	Kind     string `json:"kind"`
+	Label    string `json:"label"`
+	Priority *int   `json:"priority,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+}
+
+type depsEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// buildDepsGraph collects rules, the keys they require, the fetchers providing
+// those keys, and fetcher upstream keys. Node and edge order is deterministic.
+func buildDepsGraph(ctx context.Context, ruleList []rules.Rule, fetchers []fetcher.DataFetcher) (*depsGraph, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	g := &depsGraph{Nodes: []depsNode{}, Edges: []depsEdge{}}
+	keys := make(map[data.DependencyKey]bool)
+
+	for _, r := range ruleList {
+		// Rule dependencies are static in practice; an empty repo is enough to list them.
+		deps, err := r.Dependencies(ctx, &github.Repository{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies for rule %s: %w", r.ID(), err)
+		}
+		g.Nodes = append(g.Nodes, depsNode{ID: "rule:" + r.ID(), Kind: "rule", Label: r.ID()})
+		for _, d := range dedupeKeys(deps) {
+			keys[d] = true
+			g.Edges = append(g.Edges, depsEdge{From: "rule:" + r.ID(), To: "key:" + string(d), Kind: "requires"})
+		}
+	}
+
+	for _, df := range fetchers {
+		id := "fetcher:" + string(df.Key())
+		keys[df.Key()] = true
+		g.Nodes = append(g.Nodes, depsNode{ID: id, Kind: "fetcher", Label: fetcher.Name(df), Scope: string(df.Scope())})
+		g.Edges = append(g.Edges, depsEdge{From: "key:" + string(df.Key()), To: id, Kind: "provided_by"})
+		if ud, ok := df.(fetcher.UpstreamDeclarer); ok {
+			for _, up := range dedupeKeys(ud.Upstream()) {
+				keys[up] = true
+				g.Edges = append(g.Edges, depsEdge{From: id, To: "key:" + string(up), Kind: "upstream"})
+			}
+		}
+	}
+
+	sortedKeys := make([]data.DependencyKey, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Slice(sortedKeys, func(i, j int) bool { return sortedKeys[i] < sortedKeys[j] })
+	for _, k := range sortedKeys {
+		p := data.Priority(k)
+		g.Nodes = append(g.Nodes, depsNode{ID: "key:" + string(k), Kind: "key", Label: string(k), Priority: &p})
+	}
+
+	return g, nil
+}
+
+func dedupeKeys(in []data.DependencyKey) []data.DependencyKey {
+	seen := make(map[data.DependencyKey]bool, len(in))
+	out := make([]data.DependencyKey, 0, len(in))
+	for _, k := range in {
+		if !seen[k] {
+			seen[k] = true
+			out = append(out, k)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func depsNodeLabel(n depsNode) string {
+	switch {
+	case n.Priority != nil:
+		return fmt.Sprintf("%s (P%d)", n.Label, *n.Priority)
+	case n.Scope != "":
+		return fmt.Sprintf("%s [%s]", n.Label, n.Scope)
+	default:
+		return n.Label
+	}
+}
+
+func writeDepsGraphDOT(w io.Writer, g *depsGraph) error {
+	shapes := map[string]string{"rule": "box", "key": "ellipse", "fetcher": "component"}
+
+	var b strings.Builder
+	b.WriteString("digraph repomedic {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %q [label=%q, shape=%s];\n", n.ID, depsNodeLabel(n), shapes[n.Kind])
+	}
+	for _, e := range g.Edges {
+		if e.Kind == "upstream" {
+			fmt.Fprintf(&b, "  %q -> %q [style=dashed];\n", e.From, e.To)
+			continue
+		}
+		fmt.Fprintf(&b, "  %q -> %q;\n", e.From, e.To)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDepsGraphMermaid(w io.Writer, g *depsGraph) error {
+	// Mermaid IDs cannot contain '.', ':' or '-', so nodes get positional IDs.
+	ids := make(map[string]string, len(g.Nodes))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.ID] = id
+		label := strings.ReplaceAll(depsNodeLabel(n), `"`, "#quot;")
+		switch n.Kind {
+		case "rule":
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, label)
+		case "key":
+			fmt.Fprintf(&b, "  %s([\"%s\"])\n", id, label)
+		default:
+			fmt.Fprintf(&b, "  %s[[\"%s\"]]\n", id, label)
+		}
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Kind == "upstream" {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", ids[e.From], arrow, ids[e.To])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(depsCmd)
+	depsCmd.AddCommand(depsGraphCmd)
+	depsGraphCmd.Flags().StringVar(&depsGraphFormat, "format", "dot", "Output format: dot|mermaid|json (default: dot)")
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
+	"repomedic/internal/rules"
+
+	"github.com/google/go-github/v81/github"
+)
+
+type depsTestRule struct {
+	mockRule
+	deps []data.DependencyKey
+}
+
+func (r *depsTestRule) Dependencies(ctx context.Context, repo *github.Repository) ([]data.DependencyKey, error) {
+	return r.deps, nil
+}
+
+type depsTestFetcher struct {
+	key      data.DependencyKey
+	scope    data.FetchScope
+	upstream []data.DependencyKey
+}
+
+func (f *depsTestFetcher) Key() data.DependencyKey        { return f.key }
+func (f *depsTestFetcher) Scope() data.FetchScope         { return f.scope }
+func (f *depsTestFetcher) Upstream() []data.DependencyKey { return f.upstream }
+func (f *depsTestFetcher) Fetch(context.Context, *github.Repository, map[string]string, *fetcher.Fetcher) (any, error) {
+	return nil, nil
+}
+
+func testDepsGraph(t *testing.T) *depsGraph {
+	t.Helper()
+	ruleList := []rules.Rule{
+		&depsTestRule{mockRule: mockRule{id: "merge-rule"}, deps: []data.DependencyKey{data.DepRepoEffectiveMergeMethods}},
+	}
+	fetchers := []fetcher.DataFetcher{
+		&depsTestFetcher{key: data.DepRepoEffectiveMergeMethods, scope: data.ScopeRepo, upstream: []data.DependencyKey{data.DepRepoMetadata}},
+		&depsTestFetcher{key: data.DepRepoMetadata, scope: data.ScopeRepo},
+	}
+	g, err := buildDepsGraph(context.Background(), ruleList, fetchers)
+	if err != nil {
+		t.Fatalf("buildDepsGraph failed: %v", err)
+	}
+	return g
+}
+
+func TestBuildDepsGraph_RulesKeysFetchers(t *testing.T) {
+	g := testDepsGraph(t)
+
+	wantEdges := []depsEdge{
+		{From: "rule:merge-rule", To: "key:" + string(data.DepRepoEffectiveMergeMethods), Kind: "requires"},
+		{From: "key:" + string(data.DepRepoEffectiveMergeMethods), To: "fetcher:" + string(data.DepRepoEffectiveMergeMethods), Kind: "provided_by"},
+		{From: "fetcher:" + string(data.DepRepoEffectiveMergeMethods), To: "key:" + string(data.DepRepoMetadata), Kind: "upstream"},
+		{From: "key:" + string(data.DepRepoMetadata), To: "fetcher:" + string(data.DepRepoMetadata), Kind: "provided_by"},
+	}
+	if len(g.Edges) != len(wantEdges) {
+		t.Fatalf("got edges %+v, want %+v", g.Edges, wantEdges)
+	}
+	for i := range wantEdges {
+		if g.Edges[i] != wantEdges[i] {
+			t.Fatalf("edge %d = %+v, want %+v", i, g.Edges[i], wantEdges[i])
+		}
+	}
+
+	var fetcherLabel string
+	for _, n := range g.Nodes {
+		if n.ID == "fetcher:"+string(data.DepRepoMetadata) {
+			fetcherLabel = n.Label
+		}
+	}
+	if fetcherLabel != "depsTestFetcher" {
+		t.Fatalf("expected fetcher node labelled with its type name, got %q", fetcherLabel)
+	}
+}
+
+func TestWriteDepsGraph_Formats(t *testing.T) {
+	g := testDepsGraph(t)
+
+	var dot bytes.Buffer
+	if err := writeDepsGraphDOT(&dot, g); err != nil {
+		t.Fatalf("writeDepsGraphDOT failed: %v", err)
+	}
+	for _, want := range []string{
+		"digraph repomedic {",
+		`"rule:merge-rule" -> "key:repo.effective_merge_methods";`,
+		`"fetcher:repo.effective_merge_methods" -> "key:repo.metadata" [style=dashed];`,
+		`label="repo.metadata (P0)"`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Fatalf("expected DOT output to contain %q, got:\n%s", want, dot.String())
+		}
+	}
+
+	var mermaid bytes.Buffer
+	if err := writeDepsGraphMermaid(&mermaid, g); err != nil {
+		t.Fatalf("writeDepsGraphMermaid failed: %v", err)
+	}
+	out := mermaid.String()
+	if !strings.HasPrefix(out, "flowchart LR\n") || !strings.Contains(out, " -.-> ") || !strings.Contains(out, `["merge-rule"]`) {
+		t.Fatalf("unexpected mermaid output:\n%s", out)
+	}
+}
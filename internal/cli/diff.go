@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"repomedic/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var diffFormat string
+
+var diffCmd = &cobra.Command{
+	Use:   "diff OLD NEW",
+	Short: "Compare two scan outputs and show new, resolved and changed findings",
+	Long: `Compare two scans written with --out (JSON or NDJSON) and report how
+their findings (FAIL and ERROR results) changed.
+
+Findings are matched by rule, repository and file (artifact), never by
+message, so a message that embeds a count does not make a finding look new.
+
+Reports:
+  new            findings in NEW that were passing, skipped or absent in OLD
+  resolved       findings in OLD that pass, are skipped or are no longer
+                 reported in NEW (repos missing from NEW are not resolved)
+  changed        findings that moved between FAIL and ERROR
+  still failing  findings with the same status in both scans
+  repos          repositories added to or removed from the scan
+
+Exit codes:
+  0 = no new failures
+  1 = new failures (a new FAIL, or an ERROR that became a FAIL)
+  3 = the scans could not be read
+
+Examples:
+  repomedic diff last-week.json today.json
+  repomedic diff old.ndjson new.ndjson --format markdown > diff.md
+  repomedic diff old.json new.json --format json
+`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		format := strings.ToLower(strings.TrimSpace(diffFormat))
+		if format == "md" {
+			format = "markdown"
+		}
+		if format != "text" && format != "json" && format != "markdown" {
+			fmt.Fprintf(os.Stderr, "Error: unsupported --format: %s (must be one of: text, json, markdown)\n", diffFormat)
+			os.Exit(3)
+		}
+
+		oldScan, err := readScanFile(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(3)
+		}
+		newScan, err := readScanFile(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(3)
+		}
+
+		d := output.DiffScans(oldScan, newScan)
+		if err := output.WriteDiff(cmd.OutOrStdout(), d, format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(3)
+		}
+		if d.NewFailures() > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func readScanFile(path string) (*output.ScanOutput, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scan, err := output.ReadScanOutput(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return scan, nil
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "Output format: text|json|markdown (default: text)")
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiff_ExitCodes(t *testing.T) {
+	binary := buildRepoMedicBinary(t)
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		return p
+	}
+	oldPath := write("old.json", `[{"rule_id":"readme-root-exists","repo":"acme/a","status":"FAIL"}]`)
+	fixedPath := write("fixed.json", `[{"rule_id":"readme-root-exists","repo":"acme/a","status":"PASS"}]`)
+	regressedPath := write("regressed.ndjson", `{"type":"rule.result","repo":"acme/a","rule_id":"readme-root-exists","status":"FAIL"}
+{"type":"rule.result","repo":"acme/a","rule_id":"codeowners-exists","status":"FAIL","artifact":"CODEOWNERS"}
+`)
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{"resolved only", []string{"diff", oldPath, fixedPath}, 0, "Resolved (1):"},
+		{"new failure", []string{"diff", oldPath, regressedPath}, 1, "New findings (1):"},
+		{"unreadable", []string{"diff", oldPath, filepath.Join(dir, "missing.json")}, 3, "Error:"},
+		{"bad format", []string{"diff", oldPath, fixedPath, "--format", "xml"}, 3, "unsupported --format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := exec.Command(binary, tt.args...).CombinedOutput()
+			code := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.ProcessState.ExitCode()
+			} else if err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if code != tt.wantCode {
+				t.Fatalf("expected exit code %d, got %d; output=%s", tt.wantCode, code, out)
+			}
+			if !strings.Contains(string(out), tt.wantOut) {
+				t.Fatalf("expected %q in output; output=%s", tt.wantOut, out)
+			}
+		})
+	}
+}
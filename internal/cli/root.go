@@ -1,10 +1,16 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 
+	"repomedic/internal/config"
+	"repomedic/internal/flags"
+	gh "repomedic/internal/github"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -30,6 +36,18 @@ Examples:
 	# List rules
 	repomedic rules list
 
+	# Check the token's scopes, SSO authorization and rule impact
+	repomedic auth status --org my-org
+
+	# Compare two scans: new, resolved and changed findings
+	repomedic diff last-week.json today.json
+
+	# Pass rates, time-to-fix and oldest open findings from recorded scans
+	repomedic trends --history-dir ~/.repomedic/history
+
+	# Render the rule -> data dependency graph
+	repomedic deps graph --format mermaid
+
 	# Print build info
 	repomedic version
 
@@ -40,6 +58,77 @@ Output:
 
 func init() {
 	rootCmd.PersistentFlags().BoolVar(&cfg.Runtime.Verbose, "verbose", false, "Enable verbose logging (prints every GitHub API call and full error details)")
+
+	network := &cfg.Runtime.Network
+	rootCmd.PersistentFlags().StringVar(&network.CAFile, flags.FlagCAFile, "", "Trust this PEM CA bundle in addition to the system roots (GHES, TLS-intercepting proxies)")
+	rootCmd.PersistentFlags().StringVar(&network.ClientCert, flags.FlagClientCert, "", "PEM client certificate for mTLS gateways (requires --client-key)")
+	rootCmd.PersistentFlags().StringVar(&network.ClientKey, flags.FlagClientKey, "", "PEM private key for --client-cert")
+	rootCmd.PersistentFlags().StringVar(&network.Proxy, flags.FlagProxy, "", "Proxy URL for GitHub API traffic; hosts in NO_PROXY bypass it (default: HTTPS_PROXY, HTTP_PROXY and NO_PROXY as Go applies them)")
+}
+
+// clientOptions returns the GitHub client options for the global flags.
+func clientOptions() []gh.Option {
+	n := cfg.Runtime.Network
+	return []gh.Option{
+		gh.WithVerbose(cfg.Runtime.Verbose, nil),
+		gh.WithNetwork(gh.NetworkOptions{
+			CAFile:     n.CAFile,
+			ClientCert: n.ClientCert,
+			ClientKey:  n.ClientKey,
+			Proxy:      n.Proxy,
+		}),
+	}
+}
+
+// addAppFlags registers the GitHub App flags that build the token pool from
+// App installations.
+func addAppFlags(fs *pflag.FlagSet, app *config.App) {
+	fs.Int64Var(&app.ID, flags.FlagAppID, 0, "GitHub App ID whose installations form the token pool (requires --app-key-file and --app-installation)")
+	fs.StringVar(&app.KeyFile, flags.FlagAppKeyFile, "", "PEM private key of the --app-id App")
+	fs.Int64SliceVar(&app.Installations, flags.FlagAppInstallation, nil, "App installation ID to mint tokens for; each is a pool member with its own rate limit (repeatable; comma-separated accepted)")
+}
+
+// poolCredential is one member of the client pool.
+type poolCredential struct {
+	client *gh.Client
+	// token is empty for App installations, whose tokens are minted and
+	// refreshed by the client.
+	token string
+}
+
+// resolvePoolCredentials creates the clients of the token pool: one per App
+// installation when app is set, otherwise one per token from
+// gh.ResolveAuthTokens. It returns no credentials when no token is found.
+func resolvePoolCredentials(ctx context.Context, tokenFile string, app config.App) ([]poolCredential, gh.AuthTokenSource, error) {
+	if app.Enabled() {
+		key, err := gh.ReadAppPrivateKey(app.KeyFile)
+		if err != nil {
+			return nil, "", err
+		}
+		creds := make([]poolCredential, 0, len(app.Installations))
+		for _, id := range app.Installations {
+			client, err := gh.NewAppInstallationClient(ctx, gh.AppCredentials{AppID: app.ID, Key: key}, id, clientOptions()...)
+			if err != nil {
+				return nil, "", err
+			}
+			creds = append(creds, poolCredential{client: client})
+		}
+		return creds, gh.AuthTokenSourceApp, nil
+	}
+
+	tokens, source, err := gh.ResolveAuthTokens(ctx, tokenFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve GitHub auth token: %w", err)
+	}
+	creds := make([]poolCredential, 0, len(tokens))
+	for _, token := range tokens {
+		client, err := gh.NewClient(ctx, token, clientOptions()...)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create GitHub client: %w", err)
+		}
+		creds = append(creds, poolCredential{client: client, token: token})
+	}
+	return creds, source, nil
 }
 
 func SetBuildInfo(version, commit, date string) {
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"repomedic/internal/config"
 	"repomedic/internal/engine"
+	"repomedic/internal/fetcher"
 	"repomedic/internal/flags"
-	gh "repomedic/internal/github"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -78,8 +82,11 @@ Authentication:
 Output:
 	Console output is controlled by --console-format (default: text).
 	Structured outputs can be written via:
-	- --out / --out-format: write an aggregate JSON array or NDJSON stream to a file
-	- --emit: write an additional structured stream to stdout (json or ndjson)
+	- --out / --out-format: write an aggregate JSON array, NDJSON stream, SARIF log
+	  (.sarif), JUnit XML report (.xml), CSV/TSV table (.csv, .tsv; use
+	  --out-format csv-matrix or tsv-matrix for one row per repo) or OpenMetrics
+	  metrics for the node_exporter textfile collector (.prom) to a file
+	- --emit: write an additional structured stream to stdout (json, ndjson or junit)
 	- --no-console: suppress the console sink (use with --emit/--out for machine output)
 
 	NDJSON mode emits one JSON object per line. Objects are lifecycle Events with a
@@ -87,11 +94,23 @@ Output:
 	Rule results are represented as an Event with type "rule.result" and a nested
 	"result" object.
 
+Interruption:
+	On SIGINT (Ctrl-C) or SIGTERM, in-flight GitHub requests are cancelled and
+	results for repositories already evaluated are kept. All outputs are closed
+	normally: NDJSON streams end with a "run.interrupted" event (instead of
+	run.finished) carrying "evaluated", "repos" and "reason", JSON arrays contain
+	the evaluated results, and the Markdown report starts with a partial-report
+	banner. A second signal terminates immediately.
+
 Exit codes:
 	0 = clean run, no wrongs
 	1 = wrongs detected
 	2 = partial failure (some rules/repos errored)
 	3 = fatal error (scan did not run)
+	4 = interrupted (partial results written)
+
+	With --baseline, only failures missing from the baseline count toward
+	exit code 1; ERROR results always count toward exit code 2.
 
 Examples:
   # Token via environment variable
@@ -102,6 +121,62 @@ Examples:
   gh auth login
 	repomedic scan --user https://github.com/octocat
 
+	# Scan a list of repositories from a file or stdin
+	repomedic scan --repos-file in-scope.txt
+	cut -d, -f1 cmdb.csv | repomedic scan --repos -
+
+	# Preview which active Go services would be scanned
+	repomedic scan --org my-org --language go --pushed-within 180d --property tier=critical --dry-run
+
+	# Break the report down by service tier and allow-list sandbox repos
+	repomedic scan --org my-org --report report.md --report-group-by tier --set repo-visibility-public.allow.properties=tier=sandbox
+
+	# Render the report with your own template
+	repomedic scan --org my-org --report summary.html --report-template summary.html.tmpl
+
+	# Scan the repositories a team administers or maintains, including child teams
+	repomedic scan --team my-org/platform --team-permission maintain --team-children
+
+	# Target repositories by search query
+	repomedic scan --search "org:my-org language:go topic:service pushed:>2025-01-01"
+
+	# Spread requests across several tokens (comma or whitespace separated)
+	GITHUB_TOKENS="$TOKEN_A,$TOKEN_B" repomedic scan --org my-org
+
+	# Or across GitHub App installations, minting and refreshing their tokens
+	repomedic scan --org my-org --app-id 12345 --app-key-file app.pem --app-installation 111,222
+
+	# GitHub Enterprise Server behind a corporate proxy with a private CA
+	repomedic scan --org my-org --ca-file corp-ca.pem --proxy http://proxy.corp:3128
+
+	# Fail the run up front instead of scanning with rules the token cannot serve
+	repomedic scan --org my-org --require-full-coverage
+
+	# Export findings as SARIF (format inferred from the .sarif extension)
+	repomedic scan --org my-org --out results.sarif
+
+	# Interactive offline HTML report (sortable/filterable, per-repo drilldowns)
+	repomedic scan --org my-org --report report.html
+
+	# Spreadsheet exports: one row per result, or a repo x rule status matrix
+	repomedic scan --org my-org --out results.csv
+	repomedic scan --org my-org --out matrix.csv --out-format csv-matrix
+
+	# Enforce in CI without fixing existing findings first: fail only on regressions
+	repomedic scan --org my-org --out results.json --baseline baseline.json
+
+	# Accept the current findings as the new baseline
+	repomedic scan --org my-org --baseline baseline.json --update-baseline
+
+	# Keep a local history for "repomedic trends" and a "Since Last Run" report section
+	repomedic scan --org my-org --report report.md --history-dir ~/.repomedic/history
+
+	# Metrics for Grafana alerts via the node_exporter textfile collector (cron)
+	repomedic scan --org my-org --no-console --out /var/lib/node_exporter/textfile/repomedic.prom
+
+	# JUnit XML for CI test dashboards (Jenkins, GitLab, Buildkite)
+	repomedic scan --org my-org --out repomedic-junit.xml
+
 	# AI Agent: stream machine-readable events to stdout
 	repomedic scan --org my-org --no-console --emit ndjson
 `,
@@ -111,6 +186,10 @@ Examples:
 			return
 		}
 
+		if err := cfg.LoadRepoTargets(os.Stdin); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(3)
+		}
 		if err := cfg.Validate(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(3)
@@ -118,27 +197,146 @@ Examples:
 
 		applyImplicitDefaults(cmd, cfg)
 
-		ctx := context.Background()
-		token, _, err := gh.ResolveAuthToken(ctx, "")
+		ctx, stop := signalContext(context.Background())
+
+		creds, _, err := resolvePoolCredentials(ctx, cfg.Runtime.TokenFile, cfg.Runtime.App)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: failed to resolve GitHub auth token: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(3)
 		}
-		if strings.TrimSpace(token) == "" {
+		if len(creds) == 0 {
 			fmt.Fprintln(os.Stderr, "Error: GitHub auth token is required (set GITHUB_TOKEN or run 'gh auth login')")
 			os.Exit(3)
 		}
 
-		client, err := gh.NewClient(ctx, token, gh.WithVerbose(cfg.Runtime.Verbose, nil))
+		members := make([]fetcher.PoolMember, 0, len(creds))
+		for _, c := range creds {
+			members = append(members, fetcher.PoolMember{Client: c.client, Budget: fetcher.NewRequestBudget()})
+		}
+		pool, err := fetcher.NewClientPool(members...)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: failed to create GitHub client: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(3)
 		}
-		eng := engine.NewEngine(client)
-		os.Exit(eng.Run(ctx, cfg))
+		eng := engine.NewEngine(members[0].Client)
+		eng.Pool = pool
+		code := eng.Run(ctx, cfg)
+		stop()
+		os.Exit(code)
 	},
 }
 
+// signalContext returns a context that is cancelled on the first SIGINT or
+// SIGTERM so the engine can stop fetching and flush partial results. After the
+// first signal, default handling is restored so a second signal terminates the
+// process immediately.
+func signalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// concurrencyValue implements pflag.Value for --concurrency, which accepts a
+// positive worker count or "auto" for adaptive concurrency.
+type concurrencyValue struct {
+	rt *config.Runtime
+}
+
+func (v concurrencyValue) String() string {
+	if v.rt == nil {
+		return ""
+	}
+	if v.rt.ConcurrencyAuto {
+		return "auto"
+	}
+	return strconv.Itoa(v.rt.Concurrency)
+}
+
+func (v concurrencyValue) Set(s string) error {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "auto") {
+		v.rt.ConcurrencyAuto = true
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("must be a positive integer or auto")
+	}
+	v.rt.Concurrency = n
+	v.rt.ConcurrencyAuto = false
+	return nil
+}
+
+func (v concurrencyValue) Type() string {
+	return "int|auto"
+}
+
+// ageValue implements pflag.Value for look-back windows such as "180d" (see config.ParseAge).
+type ageValue struct{ d *time.Duration }
+
+func (v ageValue) String() string {
+	if v.d == nil || *v.d == 0 {
+		return ""
+	}
+	return v.d.String()
+}
+
+func (v ageValue) Set(s string) error {
+	d, err := config.ParseAge(s)
+	if err != nil {
+		return err
+	}
+	*v.d = d
+	return nil
+}
+
+func (v ageValue) Type() string { return "duration" }
+
+// dateValue implements pflag.Value for YYYY-MM-DD or RFC 3339 dates.
+type dateValue struct{ t *time.Time }
+
+func (v dateValue) String() string {
+	if v.t == nil || v.t.IsZero() {
+		return ""
+	}
+	return v.t.Format(time.RFC3339)
+}
+
+func (v dateValue) Set(s string) error {
+	t, err := config.ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*v.t = t
+	return nil
+}
+
+func (v dateValue) Type() string { return "date" }
+
+// sizeValue implements pflag.Value for repository sizes in KB (see config.ParseSizeKB).
+type sizeValue struct{ kb *int64 }
+
+func (v sizeValue) String() string {
+	if v.kb == nil || *v.kb == 0 {
+		return ""
+	}
+	return strconv.FormatInt(*v.kb, 10) + "KB"
+}
+
+func (v sizeValue) Set(s string) error {
+	kb, err := config.ParseSizeKB(s)
+	if err != nil {
+		return err
+	}
+	*v.kb = kb
+	return nil
+}
+
+func (v sizeValue) Type() string { return "size" }
+
 func applyImplicitDefaults(cmd *cobra.Command, cfg *config.Config) {
 	// When scanning a user account, include forks by default. Many GitHub users
 	// have a significant portion of their repos as forks, and excluding them by
@@ -164,10 +362,22 @@ func init() {
 	scanCmd.Flags().StringVar(&cfg.Targeting.Org, flags.FlagOrg, "", "GitHub organization account to scan (name or URL)")
 	scanCmd.Flags().StringVar(&cfg.Targeting.User, flags.FlagUser, "", "GitHub user account to scan (name or URL)")
 	scanCmd.Flags().StringVar(&cfg.Targeting.Enterprise, flags.FlagEnterprise, "", "GitHub enterprise to scan (not yet implemented)")
-	scanCmd.Flags().StringSliceVar(&cfg.Targeting.Repos, flags.FlagRepos, nil, "Repositories to scan as OWNER/REPO (repeatable; comma-separated accepted)")
+	scanCmd.Flags().StringVar(&cfg.Targeting.Search, flags.FlagSearch, "", "GitHub repository search query (e.g. \"org:acme language:go topic:service\"); results still pass the filters below")
+	scanCmd.Flags().StringSliceVar(&cfg.Targeting.Team, flags.FlagTeam, nil, "Scan the repositories of a team as ORG/TEAM-SLUG (repeatable; comma-separated accepted); results still pass the filters below")
+	scanCmd.Flags().StringVar(&cfg.Targeting.TeamPermission, flags.FlagTeamPerm, "", "Minimum team permission on a repository: push|maintain|admin (default: any access)")
+	scanCmd.Flags().BoolVar(&cfg.Targeting.TeamChildren, flags.FlagTeamChildren, false, "Also scan the repositories of child teams (recursively)")
+	scanCmd.Flags().StringSliceVar(&cfg.Targeting.Repos, flags.FlagRepos, nil, "Repositories to scan as OWNER/REPO (repeatable; comma-separated accepted; - reads a newline-delimited list from stdin)")
+	scanCmd.Flags().StringVar(&cfg.Targeting.ReposFile, flags.FlagReposFile, "", "Read repositories from a newline-delimited file (- for stdin); blank lines and # comments are ignored")
 	scanCmd.Flags().StringSliceVar(&cfg.Targeting.Include, flags.FlagInclude, nil, "Include pattern(s) (repeatable; comma-separated accepted). Go path.Match style; if pattern contains '/', matches OWNER/REPO, else matches repo name")
 	scanCmd.Flags().StringSliceVar(&cfg.Targeting.Exclude, flags.FlagExclude, nil, "Exclude pattern(s) (repeatable; comma-separated accepted). Same matching rules as --include")
 	scanCmd.Flags().StringSliceVar(&cfg.Targeting.Topic, flags.FlagTopic, nil, "Require at least one topic match (repeatable; comma-separated accepted; exact match)")
+	scanCmd.Flags().StringSliceVar(&cfg.Targeting.Language, flags.FlagLanguage, nil, "Require the primary language to be one of these (repeatable; comma-separated accepted; case-insensitive)")
+	scanCmd.Flags().Var(ageValue{d: &cfg.Targeting.PushedWithin}, flags.FlagPushedWithin, "Only repos pushed to within this window (e.g. 180d, 2w, 36h)")
+	scanCmd.Flags().Var(dateValue{t: &cfg.Targeting.CreatedBefore}, flags.FlagCreatedBefore, "Only repos created before this date (YYYY-MM-DD or RFC 3339)")
+	scanCmd.Flags().Var(sizeValue{kb: &cfg.Targeting.MinSizeKB}, flags.FlagMinSize, "Minimum repo size as reported by GitHub (KB by default; KB/MB/GB suffixes accepted)")
+	scanCmd.Flags().Var(sizeValue{kb: &cfg.Targeting.MaxSizeKB}, flags.FlagMaxSize, "Maximum repo size as reported by GitHub (KB by default; KB/MB/GB suffixes accepted)")
+	scanCmd.Flags().StringVar(&cfg.Targeting.IsTemplate, flags.FlagIsTemplate, "include", "Template repos policy: include|exclude|only (default: include)")
+	scanCmd.Flags().StringArrayVar(&cfg.Targeting.Property, flags.FlagProperty, nil, "Require an org custom property value as key=value (repeatable; repeat a key to accept several values)")
 	scanCmd.Flags().StringVar(&cfg.Targeting.Visibility, flags.FlagVisibility, "all", "Visibility filter: public|private|internal|all (default: all)")
 	scanCmd.Flags().StringVar(&cfg.Targeting.Archived, flags.FlagArchived, "exclude", "Archived repos policy: include|exclude|only (default: exclude)")
 	scanCmd.Flags().StringVar(&cfg.Targeting.Forks, flags.FlagForks, "exclude", "Forks policy: include|exclude|only (default: exclude). If --user is set and this flag is omitted, forks default to include")
@@ -182,14 +392,24 @@ func init() {
 	// Output
 	scanCmd.Flags().StringVar(&cfg.Output.ConsoleFormat, flags.FlagConsoleFormat, "text", "Console output format: text|json|ndjson (default: text)")
 	scanCmd.Flags().StringSliceVar(&cfg.Output.ConsoleFilterStatus, flags.FlagConsoleFilterStatus, nil, "Filter console output by status (PASS, FAIL, ERROR, SKIPPED). Comma-separated.")
-	scanCmd.Flags().StringVar(&cfg.Output.Report, flags.FlagReport, "", "Write a Markdown report to this path")
+	scanCmd.Flags().StringVar(&cfg.Output.Report, flags.FlagReport, "", "Write a report to this path (Markdown; .html writes an interactive, self-contained HTML report)")
+	scanCmd.Flags().StringVar(&cfg.Output.ReportGroupBy, flags.FlagReportGroupBy, "", "Break report results down by the values of this org custom property (requires --report)")
+	scanCmd.Flags().StringVar(&cfg.Output.ReportTemplate, flags.FlagReportTemplate, "", "Render the report with this Go template instead of the built-in layout; *.html/*.html.tmpl use html/template (requires --report)")
 	scanCmd.Flags().StringVar(&cfg.Output.Out, flags.FlagOut, "", "Write structured output to this path")
-	scanCmd.Flags().StringVar(&cfg.Output.OutFormat, flags.FlagOutFormat, "", "Structured output format for --out: json|ndjson (default: inferred from file extension)")
-	scanCmd.Flags().StringSliceVar(&cfg.Output.Emit, flags.FlagEmit, nil, "Emit additional structured stream to stdout: json|ndjson (repeatable; comma-separated accepted)")
+	scanCmd.Flags().StringVar(&cfg.Output.OutFormat, flags.FlagOutFormat, "", "Structured output format for --out: json|ndjson|sarif|junit|csv|tsv|csv-matrix|tsv-matrix|openmetrics (default: inferred from file extension)")
+	scanCmd.Flags().StringSliceVar(&cfg.Output.Emit, flags.FlagEmit, nil, "Emit additional structured stream to stdout: json|ndjson|junit (repeatable; comma-separated accepted)")
 	scanCmd.Flags().BoolVar(&cfg.Output.NoConsole, flags.FlagNoConsole, false, "Suppress console output (use with --emit/--out/--report)")
+	scanCmd.Flags().StringVar(&cfg.Output.Baseline, flags.FlagBaseline, "", "Previous scan output (--out JSON/NDJSON); its findings are marked baselined and do not affect the exit code")
+	scanCmd.Flags().BoolVar(&cfg.Output.UpdateBaseline, flags.FlagUpdateBaseline, false, "Rewrite the --baseline file with this run's failures after the scan completes without errors (creates it if missing)")
+	scanCmd.Flags().StringVar(&cfg.Output.HistoryDir, flags.FlagHistoryDir, "", "Record each completed run in this directory (for repomedic trends and the report's Since Last Run section)")
 
 	// Runtime
-	scanCmd.Flags().IntVar(&cfg.Runtime.Concurrency, flags.FlagConcurrency, 5, "Concurrent workers (default: 5)")
+	scanCmd.Flags().Var(concurrencyValue{rt: &cfg.Runtime}, flags.FlagConcurrency, "Concurrent workers, or auto to adapt to rate-limit headroom, latency and errors (default: 5)")
+	scanCmd.Flags().IntVar(&cfg.Runtime.FetchConcurrency, flags.FlagFetchConcurrency, 0, "Dependency fetches in flight across all repos, dispatched by priority (0 = same as --concurrency; caps --concurrency auto)")
+	scanCmd.Flags().IntVar(&cfg.Runtime.MaxCacheMB, flags.FlagMaxCacheMB, cfg.Runtime.MaxCacheMB, "Memory bound in MB for fetch results cached after their repo finished (in-flight repos are bounded by --concurrency, org data is kept for the run) (0 = disable) (default: 256)")
 	scanCmd.Flags().DurationVar(&cfg.Runtime.Timeout, flags.FlagTimeout, cfg.Runtime.Timeout, "Global timeout (default: 30m)")
 	scanCmd.Flags().BoolVar(&cfg.Runtime.FailFast, flags.FlagFailFast, false, "Stop on first fatal error (default: false)")
+	scanCmd.Flags().StringVar(&cfg.Runtime.TokenFile, flags.FlagTokenFile, "", "Spread requests across the tokens in this file, one per line (default: GITHUB_TOKENS, then the single token)")
+	addAppFlags(scanCmd.Flags(), &cfg.Runtime.App)
+	scanCmd.Flags().BoolVar(&cfg.Runtime.RequireFullCoverage, flags.FlagRequireCoverage, false, "Abort before scanning if the permission preflight finds rules that would be SKIPPED or ERROR (default: false)")
 }
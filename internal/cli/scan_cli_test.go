@@ -75,7 +75,7 @@ func TestScan_ExitCode3_WhenNoScopeProvided(t *testing.T) {
 	if code := exitErr.ProcessState.ExitCode(); code != 3 {
 		t.Fatalf("expected exit code 3, got %d; output=%s", code, string(out))
 	}
-	if !strings.Contains(string(out), "at least one of --org, --user, --enterprise, or --repos must be provided") {
+	if !strings.Contains(string(out), "at least one of --org, --user, --enterprise, --search, --team, or --repos must be provided") {
 		t.Fatalf("expected validation message; output=%s", string(out))
 	}
 }
@@ -40,3 +40,26 @@ func TestApplyImplicitDefaults_UserScan_DoesNotOverrideExplicitForksFlag(t *test
 		t.Fatalf("expected forks to remain exclude when --forks explicitly set; got %q", cfg.Targeting.Forks)
 	}
 }
+
+func TestConcurrencyValue_AcceptsIntegerOrAuto(t *testing.T) {
+	cfg := config.New()
+	v := concurrencyValue{rt: &cfg.Runtime}
+
+	if err := v.Set("auto"); err != nil {
+		t.Fatalf("Set(auto) failed: %v", err)
+	}
+	if !cfg.Runtime.ConcurrencyAuto || v.String() != "auto" {
+		t.Fatalf("expected auto mode, got auto=%v string=%q", cfg.Runtime.ConcurrencyAuto, v.String())
+	}
+
+	if err := v.Set("12"); err != nil {
+		t.Fatalf("Set(12) failed: %v", err)
+	}
+	if cfg.Runtime.ConcurrencyAuto || cfg.Runtime.Concurrency != 12 || v.String() != "12" {
+		t.Fatalf("expected fixed 12, got auto=%v concurrency=%d", cfg.Runtime.ConcurrencyAuto, cfg.Runtime.Concurrency)
+	}
+
+	if err := v.Set("lots"); err == nil {
+		t.Fatal("expected error for non-numeric value")
+	}
+}
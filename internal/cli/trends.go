@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"repomedic/internal/flags"
+	"repomedic/internal/output"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	trendsHistoryDir string
+	trendsTarget     string
+	trendsRuns       int
+	trendsOldest     int
+	trendsFormat     string
+)
+
+var trendsCmd = &cobra.Command{
+	Use:   "trends",
+	Short: "Report pass rates, time-to-fix and open findings from scan history",
+	Long: `Summarize the runs recorded by "repomedic scan --history-dir".
+
+Reports:
+  runs             the runs covered, with their overall pass rate
+  pass rate        per rule, per category and per org for each run
+                   (PASS / (PASS + FAIL + ERROR); SKIPPED is ignored)
+  time to fix      median and longest time findings stayed open before a
+                   later run scanned the repo with the rule and no longer
+                   reported them, per rule
+  oldest open      findings still open in the latest run, longest open first
+
+Runs are keyed by scan target (for example "org:acme"); use --target to
+report on one target when the directory holds several.
+
+Exit codes:
+  0 = trends reported
+  3 = the history could not be read or holds no runs
+
+Examples:
+  repomedic trends --history-dir ~/.repomedic/history
+  repomedic trends --history-dir history --target org:acme --runs 12 --format markdown > trends.md
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format := strings.ToLower(strings.TrimSpace(trendsFormat))
+		if format == "md" {
+			format = "markdown"
+		}
+		if format != "text" && format != "json" && format != "markdown" {
+			fmt.Fprintf(os.Stderr, "Error: unsupported --format: %s (must be one of: text, json, markdown)\n", trendsFormat)
+			os.Exit(3)
+		}
+		dir := strings.TrimSpace(trendsHistoryDir)
+		if dir == "" {
+			fmt.Fprintf(os.Stderr, "Error: --%s is required\n", flags.FlagHistoryDir)
+			os.Exit(3)
+		}
+
+		runs, err := output.LoadHistory(dir, strings.TrimSpace(trendsTarget))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(3)
+		}
+		if len(runs) == 0 {
+			if trendsTarget != "" {
+				fmt.Fprintf(os.Stderr, "Error: no runs for target %s in %s\n", trendsTarget, dir)
+			} else {
+				fmt.Fprintf(os.Stderr, "Error: no runs recorded in %s\n", dir)
+			}
+			os.Exit(3)
+		}
+
+		t := output.ComputeTrends(runs, output.TrendOptions{Runs: trendsRuns, Oldest: trendsOldest})
+		if err := output.WriteTrends(cmd.OutOrStdout(), t, format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(3)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(trendsCmd)
+	trendsCmd.Flags().StringVar(&trendsHistoryDir, flags.FlagHistoryDir, "", "Directory written by repomedic scan --history-dir (required)")
+	trendsCmd.Flags().StringVar(&trendsTarget, "target", "", "Only use runs of this scan target, e.g. org:acme (default: all runs)")
+	trendsCmd.Flags().IntVar(&trendsRuns, "runs", 10, "Number of most recent runs in the pass-rate series (0 = all)")
+	trendsCmd.Flags().IntVar(&trendsOldest, "oldest", 10, "Number of oldest open findings to list")
+	trendsCmd.Flags().StringVar(&trendsFormat, "format", "text", "Output format: text|json|markdown (default: text)")
+}
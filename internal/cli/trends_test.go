@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"repomedic/internal/output"
+	"repomedic/internal/rules"
+)
+
+func TestTrends_ExitCodes(t *testing.T) {
+	binary := buildRepoMedicBinary(t)
+	dir := t.TempDir()
+
+	hs, err := output.NewHistorySink(dir, "org:acme")
+	if err != nil {
+		t.Fatalf("NewHistorySink failed: %v", err)
+	}
+	_ = hs.Write(output.Event{Type: "run.started"})
+	_ = hs.Write(rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail})
+	_ = hs.Write(output.Event{Type: "run.finished", ExitCode: 1})
+	if err := hs.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{"text", []string{"trends", "--history-dir", dir}, 0, "Oldest open findings:"},
+		{"markdown", []string{"trends", "--history-dir", dir, "--format", "md"}, 0, "# RepoMedic Trends"},
+		{"unknown target", []string{"trends", "--history-dir", dir, "--target", "org:other"}, 3, "no runs for target org:other"},
+		{"missing dir", []string{"trends", "--history-dir", filepath.Join(dir, "missing")}, 3, "failed to read history directory"},
+		{"no dir", []string{"trends"}, 3, "--history-dir is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := exec.Command(binary, tt.args...).CombinedOutput()
+			code := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.ProcessState.ExitCode()
+			} else if err != nil {
+				t.Fatalf("run failed: %v", err)
+			}
+			if code != tt.wantCode {
+				t.Fatalf("expected exit code %d, got %d; output=%s", tt.wantCode, code, out)
+			}
+			if !strings.Contains(string(out), tt.wantOut) {
+				t.Fatalf("expected %q in output; output=%s", tt.wantOut, out)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 )
@@ -31,10 +32,32 @@ type Targeting struct {
 	// Note: enterprise scanning is not yet implemented.
 	Enterprise string
 
+	// Search is a GitHub repository search query resolved via the Search API (see --search),
+	// e.g. "org:acme language:go topic:service pushed:>2025-01-01".
+	Search string
+
+	// Team scans the repositories of these teams, given as ORG/TEAM-SLUG (see --team).
+	// Values may be provided as repeated flags and/or comma-separated lists.
+	Team []string
+
+	// TeamPermission is the minimum permission a team must hold on a repository
+	// for it to be scanned (see --team-permission).
+	// Allowed values: push, maintain, admin. Empty accepts any access.
+	TeamPermission string
+
+	// TeamChildren also scans the repositories of the teams' child teams,
+	// recursively (see --team-children).
+	TeamChildren bool
+
 	// Repos is an explicit list of repositories to scan as OWNER/REPO (see --repos).
 	// Values may be provided as repeated flags and/or comma-separated lists.
 	Repos []string
 
+	// ReposFile reads additional repositories from a newline-delimited file, or
+	// from stdin when "-" (see --repos-file). Blank lines and '#' comments are ignored.
+	// A "-" entry in Repos also reads stdin. Both are expanded by LoadRepoTargets.
+	ReposFile string
+
 	// Include filters repositories by name using Go path.Match style (see --include).
 	// If a pattern contains '/', it matches OWNER/REPO; otherwise it matches repo name.
 	Include []string
@@ -60,6 +83,31 @@ type Targeting struct {
 	// Note: if --user is set and --forks is omitted, forks default to include.
 	Forks string
 
+	// Language requires the repository's primary language to be one of these
+	// (case-insensitive; see --language). Comma-separated values are accepted.
+	Language []string
+
+	// PushedWithin keeps repositories pushed to within this window before the scan
+	// (see --pushed-within). 0 disables the filter.
+	PushedWithin time.Duration
+
+	// CreatedBefore keeps repositories created before this time (see --created-before).
+	// The zero value disables the filter.
+	CreatedBefore time.Time
+
+	// MinSizeKB and MaxSizeKB bound the repository size reported by GitHub, in KB
+	// (see --min-size and --max-size). 0 disables the respective bound.
+	MinSizeKB int64
+	MaxSizeKB int64
+
+	// IsTemplate controls how template repositories are handled (see --is-template).
+	// Allowed values: include, exclude, only.
+	IsTemplate string
+
+	// Property requires org custom property values as key=value (see --property).
+	// Different keys must all match; repeating a key accepts any of its values.
+	Property []string
+
 	// MaxRepos limits how many repositories to scan (see --max-repos). 0 means unlimited.
 	MaxRepos int
 
@@ -90,30 +138,68 @@ type Output struct {
 	// Allowed values: PASS, FAIL, ERROR, SKIPPED.
 	ConsoleFilterStatus []string
 
-	// Report writes a Markdown report to this path (see --report).
+	// Report writes a Markdown report to this path (see --report), or an HTML
+	// report when the path ends in .html/.htm.
 	Report string
 
+	// ReportGroupBy adds a per-value breakdown of results to the report for
+	// this org custom property (see --report-group-by). Requires Report.
+	ReportGroupBy string
+
+	// ReportTemplate renders the report with this Go template file instead of
+	// the built-in layout (see --report-template). Requires Report.
+	ReportTemplate string
+
 	// Out writes structured output to this path (see --out).
 	Out string
 
 	// OutFormat selects the format for --out (see --out-format).
-	// Allowed values: json, ndjson. If empty, it is inferred from the --out file extension.
+	// Allowed values: json, ndjson, sarif, junit, csv, tsv, csv-matrix, tsv-matrix, openmetrics. If empty,
+	// it is inferred from the --out file extension (.xml is junit, .prom is openmetrics; the matrix
+	// formats are never inferred).
 	OutFormat string
 
 	// Emit writes an additional structured event stream to stdout (see --emit).
-	// Allowed values: json, ndjson.
+	// Allowed values: json, ndjson, junit.
 	Emit []string
 
 	// NoConsole suppresses the console sink (see --no-console).
 	// Use with --emit/--out/--report for machine-readable output.
 	NoConsole bool
+
+	// Baseline is a previous scan output whose findings are reported as
+	// baselined and do not affect the exit code (see --baseline).
+	Baseline string
+
+	// UpdateBaseline rewrites Baseline with this run's findings once the scan
+	// completes (see --update-baseline). Requires Baseline.
+	UpdateBaseline bool
+
+	// HistoryDir records each completed run in this directory for
+	// "repomedic trends" and the report's "Since Last Run" section (see --history-dir).
+	HistoryDir string
 }
 
 type Runtime struct {
 	// Concurrency controls parallelism for repository processing (see --concurrency).
-	// Must be >= 1.
+	// Must be >= 1 unless ConcurrencyAuto is set.
 	Concurrency int
 
+	// ConcurrencyAuto enables adaptive concurrency (--concurrency auto): fetch workers
+	// are resized during the run based on rate-limit headroom, latency and error rates.
+	ConcurrencyAuto bool
+
+	// FetchConcurrency bounds dependency fetches in flight across all repos (see --fetch-concurrency).
+	// 0 means the same as Concurrency; with ConcurrencyAuto it caps the adaptive range.
+	FetchConcurrency int
+
+	// MaxCacheMB bounds the fetch cache LRU in megabytes (see --max-cache-mb).
+	// It does not bound the scan's main cache use: repo-scoped entries are held
+	// only while their repo is in flight (so --concurrency bounds them) and
+	// org-scoped entries are kept for the run. The LRU holds values fetched for
+	// a repo after its results were emitted. 0 disables the LRU.
+	MaxCacheMB int
+
 	// Timeout is the global scan timeout for the run (see --timeout).
 	// Must be > 0.
 	Timeout time.Duration
@@ -121,8 +207,54 @@ type Runtime struct {
 	// FailFast stops the scan on the first fatal error (see --fail-fast).
 	FailFast bool
 
+	// TokenFile reads the scan's token pool from a file, one token per line
+	// (see --token-file). Without it, GITHUB_TOKENS or the single token is used.
+	TokenFile string
+
+	// App builds the token pool from GitHub App installations instead of tokens.
+	App App
+
+	// RequireFullCoverage aborts the scan when the permission preflight finds
+	// rules that would be SKIPPED or ERROR (see --require-full-coverage).
+	RequireFullCoverage bool
+
 	// Verbose enables more detailed diagnostics (primarily for dependency/fetch failures).
 	Verbose bool
+
+	// Network configures TLS and proxying for GitHub API traffic.
+	Network Network
+}
+
+type Network struct {
+	// CAFile is a PEM bundle trusted in addition to the system roots (see --ca-file).
+	CAFile string
+
+	// ClientCert and ClientKey are presented to mTLS gateways (see --client-cert, --client-key).
+	// Both or neither must be set.
+	ClientCert string
+	ClientKey  string
+
+	// Proxy routes GitHub API traffic through this proxy URL (see --proxy).
+	// Hosts in NO_PROXY are reached directly. Empty keeps the standard
+	// HTTPS_PROXY/HTTP_PROXY/NO_PROXY handling.
+	Proxy string
+}
+
+type App struct {
+	// ID is the GitHub App ID (see --app-id).
+	ID int64
+
+	// KeyFile is the App's PEM private key (see --app-key-file).
+	KeyFile string
+
+	// Installations lists the installation IDs to mint tokens for, one pool
+	// member each (see --app-installation).
+	Installations []int64
+}
+
+// Enabled reports whether any App flag was set.
+func (a *App) Enabled() bool {
+	return a.ID != 0 || a.KeyFile != "" || len(a.Installations) > 0
 }
 
 func New() *Config {
@@ -131,6 +263,7 @@ func New() *Config {
 			Visibility: "all",
 			Archived:   "exclude",
 			Forks:      "exclude",
+			IsTemplate: "include",
 		},
 		Rules: Rules{
 			Evidence: "standard",
@@ -140,6 +273,7 @@ func New() *Config {
 		},
 		Runtime: Runtime{
 			Concurrency: 5,
+			MaxCacheMB:  256,
 			Timeout:     30 * time.Minute,
 		},
 	}
@@ -149,6 +283,7 @@ func (c *Config) Validate() error {
 	// Normalize comma-delimited list inputs.
 	c.Targeting.Repos = splitCommaList(c.Targeting.Repos)
 	c.Targeting.Topic = splitCommaList(c.Targeting.Topic)
+	c.Targeting.Language = splitCommaList(c.Targeting.Language)
 	c.Rules.Set = splitCommaList(c.Rules.Set)
 
 	// Normalize account selectors.
@@ -174,13 +309,37 @@ func (c *Config) Validate() error {
 		c.Targeting.Enterprise = ent
 	}
 
+	c.Targeting.Search = strings.TrimSpace(c.Targeting.Search)
+
+	c.Targeting.Team = splitCommaList(c.Targeting.Team)
+	for i, t := range c.Targeting.Team {
+		team, err := NormalizeTeamSelector(t)
+		if err != nil {
+			return fmt.Errorf("invalid --team value: %w", err)
+		}
+		c.Targeting.Team[i] = team
+	}
+	c.Targeting.TeamPermission = normalizeEnumValue(c.Targeting.TeamPermission)
+	if c.Targeting.TeamPermission != "" && !slices.Contains(teamPermissions, c.Targeting.TeamPermission) {
+		return fmt.Errorf("unsupported --team-permission: %s (must be one of: admin, maintain, push)", c.Targeting.TeamPermission)
+	}
+	if len(c.Targeting.Team) == 0 && (c.Targeting.TeamPermission != "" || c.Targeting.TeamChildren) {
+		return errors.New("--team-permission and --team-children require --team")
+	}
+
 	// Targeting validation
-	if c.Targeting.Org == "" && c.Targeting.User == "" && c.Targeting.Enterprise == "" && len(c.Targeting.Repos) == 0 {
-		return errors.New("at least one of --org, --user, --enterprise, or --repos must be provided")
+	if c.Targeting.Org == "" && c.Targeting.User == "" && c.Targeting.Enterprise == "" && c.Targeting.Search == "" && len(c.Targeting.Team) == 0 && len(c.Targeting.Repos) == 0 {
+		return errors.New("at least one of --org, --user, --enterprise, --search, --team, or --repos must be provided")
 	}
 	if c.Targeting.Org != "" && c.Targeting.User != "" {
 		return errors.New("--org and --user are mutually exclusive")
 	}
+	if c.Targeting.Search != "" && (c.Targeting.Org != "" || c.Targeting.User != "" || c.Targeting.Enterprise != "") {
+		return errors.New("--search cannot be combined with --org, --user, or --enterprise (use org: or user: qualifiers in the query)")
+	}
+	if len(c.Targeting.Team) > 0 && (c.Targeting.Org != "" || c.Targeting.User != "" || c.Targeting.Enterprise != "" || c.Targeting.Search != "") {
+		return errors.New("--team cannot be combined with --org, --user, --enterprise, or --search")
+	}
 
 	// Output validation
 	c.Output.ConsoleFormat = normalizeEnumValue(c.Output.ConsoleFormat)
@@ -194,10 +353,10 @@ func (c *Config) Validate() error {
 	for _, emit := range c.Output.Emit {
 		v := normalizeEnumValue(emit)
 		if v == "" {
-			return errors.New("--emit must be one of: json, ndjson")
+			return errors.New("--emit must be one of: json, ndjson, junit")
 		}
-		if v != "json" && v != "ndjson" {
-			return fmt.Errorf("unsupported --emit value: %s (must be one of: json, ndjson)", v)
+		if v != "json" && v != "ndjson" && v != "junit" {
+			return fmt.Errorf("unsupported --emit value: %s (must be one of: json, ndjson, junit)", v)
 		}
 	}
 
@@ -235,16 +394,52 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("unsupported --forks: %s (must be one of: include, exclude, only)", c.Targeting.Forks)
 	}
 
+	c.Targeting.IsTemplate = normalizeEnumValue(c.Targeting.IsTemplate)
+	if c.Targeting.IsTemplate == "" {
+		c.Targeting.IsTemplate = "include"
+	}
+	if c.Targeting.IsTemplate != "include" && c.Targeting.IsTemplate != "exclude" && c.Targeting.IsTemplate != "only" {
+		return fmt.Errorf("unsupported --is-template: %s (must be one of: include, exclude, only)", c.Targeting.IsTemplate)
+	}
+
+	if c.Targeting.PushedWithin < 0 {
+		return errors.New("--pushed-within must be >= 0")
+	}
+	if c.Targeting.MinSizeKB < 0 || c.Targeting.MaxSizeKB < 0 {
+		return errors.New("--min-size and --max-size must be >= 0")
+	}
+	if c.Targeting.MaxSizeKB > 0 && c.Targeting.MinSizeKB > c.Targeting.MaxSizeKB {
+		return errors.New("--min-size must not exceed --max-size")
+	}
+	if _, err := ParsePropertyFilters(c.Targeting.Property); err != nil {
+		return err
+	}
+
 	// Runtime validation
 	if c.Targeting.MaxRepos < 0 {
 		return errors.New("--max-repos must be >= 0")
 	}
-	if c.Runtime.Concurrency <= 0 {
+	if !c.Runtime.ConcurrencyAuto && c.Runtime.Concurrency <= 0 {
 		return errors.New("--concurrency must be >= 1")
 	}
+	if c.Runtime.FetchConcurrency < 0 {
+		return errors.New("--fetch-concurrency must be >= 0")
+	}
+	if c.Runtime.MaxCacheMB < 0 {
+		return errors.New("--max-cache-mb must be >= 0")
+	}
 	if c.Runtime.Timeout <= 0 {
 		return errors.New("--timeout must be > 0")
 	}
+	if err := c.Runtime.Network.Validate(); err != nil {
+		return err
+	}
+	if err := c.Runtime.App.Validate(); err != nil {
+		return err
+	}
+	if c.Runtime.App.Enabled() && strings.TrimSpace(c.Runtime.TokenFile) != "" {
+		return errors.New("--token-file cannot be combined with --app-installation")
+	}
 
 	if c.Output.Out != "" {
 		c.Output.OutFormat = normalizeEnumValue(c.Output.OutFormat)
@@ -255,6 +450,16 @@ func (c *Config) Validate() error {
 				c.Output.OutFormat = "json"
 			case ".ndjson":
 				c.Output.OutFormat = "ndjson"
+			case ".sarif":
+				c.Output.OutFormat = "sarif"
+			case ".xml":
+				c.Output.OutFormat = "junit"
+			case ".csv":
+				c.Output.OutFormat = "csv"
+			case ".tsv":
+				c.Output.OutFormat = "tsv"
+			case ".prom":
+				c.Output.OutFormat = "openmetrics"
 			default:
 				if ext == "" {
 					return errors.New("cannot infer output format from file extension (missing extension); use --out-format")
@@ -262,12 +467,29 @@ func (c *Config) Validate() error {
 				return fmt.Errorf("cannot infer output format from file extension %q; use --out-format", ext)
 			}
 		} else {
-			if c.Output.OutFormat != "json" && c.Output.OutFormat != "ndjson" {
+			switch c.Output.OutFormat {
+			case "json", "ndjson", "sarif", "junit", "csv", "tsv", "csv-matrix", "tsv-matrix", "openmetrics":
+			default:
 				return fmt.Errorf("unsupported output format: %s", c.Output.OutFormat)
 			}
 		}
 	}
 
+	c.Output.ReportGroupBy = strings.TrimSpace(c.Output.ReportGroupBy)
+	if c.Output.ReportGroupBy != "" && c.Output.Report == "" {
+		return errors.New("--report-group-by requires --report")
+	}
+	c.Output.Baseline = strings.TrimSpace(c.Output.Baseline)
+	if c.Output.UpdateBaseline && c.Output.Baseline == "" {
+		return errors.New("--update-baseline requires --baseline")
+	}
+	c.Output.HistoryDir = strings.TrimSpace(c.Output.HistoryDir)
+
+	c.Output.ReportTemplate = strings.TrimSpace(c.Output.ReportTemplate)
+	if c.Output.ReportTemplate != "" && c.Output.Report == "" {
+		return errors.New("--report-template requires --report")
+	}
+
 	// Ruleset option syntax validation (rule.option=value)
 	if len(c.Rules.Set) > 0 {
 		if _, err := ParseRuleOptionAssignments(c.Rules.Set); err != nil {
@@ -278,6 +500,59 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// Validate checks the network flags without touching the files they name;
+// unreadable files are reported when the client is created.
+func (n *Network) Validate() error {
+	n.CAFile = strings.TrimSpace(n.CAFile)
+	n.ClientCert = strings.TrimSpace(n.ClientCert)
+	n.ClientKey = strings.TrimSpace(n.ClientKey)
+	n.Proxy = strings.TrimSpace(n.Proxy)
+	if (n.ClientCert == "") != (n.ClientKey == "") {
+		return errors.New("--client-cert and --client-key must be used together")
+	}
+	if n.Proxy != "" {
+		raw := n.Proxy
+		if !strings.Contains(raw, "://") {
+			raw = "http://" + raw
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid --proxy value: %q", n.Proxy)
+		}
+		switch u.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return fmt.Errorf("unsupported --proxy scheme: %s (must be one of: http, https, socks5)", u.Scheme)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the App flags are set together; the key file is read
+// when the clients are created. Duplicate installations are dropped.
+func (a *App) Validate() error {
+	a.KeyFile = strings.TrimSpace(a.KeyFile)
+	if !a.Enabled() {
+		return nil
+	}
+	if a.ID <= 0 || a.KeyFile == "" || len(a.Installations) == 0 {
+		return errors.New("--app-id, --app-key-file and --app-installation must be used together")
+	}
+	seen := make(map[int64]bool, len(a.Installations))
+	installations := a.Installations[:0]
+	for _, id := range a.Installations {
+		if id <= 0 {
+			return fmt.Errorf("invalid --app-installation value: %d", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			installations = append(installations, id)
+		}
+	}
+	a.Installations = installations
+	return nil
+}
+
 func normalizeEnumValue(raw string) string {
 	return strings.ToLower(strings.TrimSpace(raw))
 }
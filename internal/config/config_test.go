@@ -126,6 +126,72 @@ func TestValidate_RejectsOrgAndUserTogether(t *testing.T) {
 	}
 }
 
+func TestValidate_Search(t *testing.T) {
+	cfg := New()
+	cfg.Targeting.Search = "  org:acme language:go  "
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if cfg.Targeting.Search != "org:acme language:go" {
+		t.Fatalf("expected trimmed query, got %q", cfg.Targeting.Search)
+	}
+
+	cfg = New()
+	cfg.Targeting.Search = "language:go"
+	cfg.Targeting.Org = "acme"
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected error combining --search with --org, got nil")
+	}
+}
+
+func TestValidate_ReportGroupByRequiresReport(t *testing.T) {
+	cfg := New()
+	cfg.Targeting.Org = "acme"
+	cfg.Output.ReportGroupBy = " tier "
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected error for --report-group-by without --report, got nil")
+	}
+
+	cfg.Output.Report = "report.md"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if cfg.Output.ReportGroupBy != "tier" {
+		t.Fatalf("expected trimmed property, got %q", cfg.Output.ReportGroupBy)
+	}
+}
+
+func TestValidate_ReportTemplateRequiresReport(t *testing.T) {
+	cfg := New()
+	cfg.Targeting.Org = "acme"
+	cfg.Output.ReportTemplate = "summary.tmpl"
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected error for --report-template without --report, got nil")
+	}
+
+	cfg.Output.Report = "summary.md"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+}
+
+func TestValidate_UpdateBaselineRequiresBaseline(t *testing.T) {
+	cfg := New()
+	cfg.Targeting.Org = "acme"
+	cfg.Output.UpdateBaseline = true
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("expected error for --update-baseline without --baseline, got nil")
+	}
+
+	cfg.Output.Baseline = " baseline.json "
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if cfg.Output.Baseline != "baseline.json" {
+		t.Fatalf("expected trimmed baseline path, got %q", cfg.Output.Baseline)
+	}
+}
+
 func TestValidate_RejectsInvalidConsoleFormat(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -315,6 +381,18 @@ func TestValidate_RejectsInvalidRuntimeBounds(t *testing.T) {
 				cfg.Runtime.Concurrency = 0
 			},
 		},
+		{
+			name: "negative_fetch_concurrency",
+			mutateCfg: func(cfg *Config) {
+				cfg.Runtime.FetchConcurrency = -1
+			},
+		},
+		{
+			name: "negative_max_cache_mb",
+			mutateCfg: func(cfg *Config) {
+				cfg.Runtime.MaxCacheMB = -1
+			},
+		},
 		{
 			name: "negative_timeout",
 			mutateCfg: func(cfg *Config) {
@@ -334,3 +412,108 @@ func TestValidate_RejectsInvalidRuntimeBounds(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate_Network(t *testing.T) {
+	tests := []struct {
+		name    string
+		network Network
+		wantErr bool
+	}{
+		{name: "empty", network: Network{}},
+		{name: "cert_and_key", network: Network{ClientCert: "c.pem", ClientKey: "k.pem"}},
+		{name: "cert_without_key", network: Network{ClientCert: "c.pem"}, wantErr: true},
+		{name: "key_without_cert", network: Network{ClientKey: "k.pem"}, wantErr: true},
+		{name: "proxy_url", network: Network{Proxy: "http://proxy.corp:3128"}},
+		{name: "proxy_host_port", network: Network{Proxy: "proxy.corp:3128"}},
+		{name: "proxy_socks5", network: Network{Proxy: "socks5://proxy.corp:1080"}},
+		{name: "proxy_bad_scheme", network: Network{Proxy: "ftp://proxy.corp"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := New()
+			cfg.Targeting.Repos = []string{"acme/repo"}
+			cfg.Runtime.Network = tt.network
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_App(t *testing.T) {
+	tests := []struct {
+		name      string
+		app       App
+		tokenFile string
+		wantErr   bool
+	}{
+		{name: "empty", app: App{}},
+		{name: "complete", app: App{ID: 7, KeyFile: "app.pem", Installations: []int64{1, 2}}},
+		{name: "without_key", app: App{ID: 7, Installations: []int64{1}}, wantErr: true},
+		{name: "without_installations", app: App{ID: 7, KeyFile: "app.pem"}, wantErr: true},
+		{name: "installation_only", app: App{Installations: []int64{1}}, wantErr: true},
+		{name: "bad_installation", app: App{ID: 7, KeyFile: "app.pem", Installations: []int64{0}}, wantErr: true},
+		{name: "with_token_file", app: App{ID: 7, KeyFile: "app.pem", Installations: []int64{1}}, tokenFile: "tokens.txt", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := New()
+			cfg.Targeting.Repos = []string{"acme/repo"}
+			cfg.Runtime.App = tt.app
+			cfg.Runtime.TokenFile = tt.tokenFile
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	app := App{ID: 7, KeyFile: "app.pem", Installations: []int64{2, 1, 2}}
+	if err := app.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if len(app.Installations) != 2 || app.Installations[0] != 2 || app.Installations[1] != 1 {
+		t.Fatalf("expected duplicate installations to be dropped, got %v", app.Installations)
+	}
+}
+
+func TestValidate_OutFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		out       string
+		outFormat string
+		want      string
+		wantErr   bool
+	}{
+		{name: "infer_json", out: "results.json", want: "json"},
+		{name: "infer_ndjson", out: "results.ndjson", want: "ndjson"},
+		{name: "infer_sarif", out: "results.sarif", want: "sarif"},
+		{name: "infer_junit", out: "junit.xml", want: "junit"},
+		{name: "infer_csv", out: "results.csv", want: "csv"},
+		{name: "infer_openmetrics", out: "repomedic.prom", want: "openmetrics"},
+		{name: "infer_tsv", out: "results.tsv", want: "tsv"},
+		{name: "explicit_matrix", out: "matrix.csv", outFormat: "csv-matrix", want: "csv-matrix"},
+		{name: "explicit_sarif", out: "results.out", outFormat: "SARIF", want: "sarif"},
+		{name: "unknown_extension", out: "results.txt", wantErr: true},
+		{name: "unsupported_format", out: "results.json", outFormat: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := New()
+			cfg.Targeting.Repos = []string{"acme/repo"}
+			cfg.Output.Out = tt.out
+			cfg.Output.OutFormat = tt.outFormat
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.Output.OutFormat != tt.want {
+				t.Fatalf("OutFormat = %q, want %q", cfg.Output.OutFormat, tt.want)
+			}
+		})
+	}
+}
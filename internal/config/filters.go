@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseAge parses a look-back window such as "180d", "2w", "36h" or "90m".
+// Days and weeks are accepted in addition to time.ParseDuration units.
+func ParseAge(raw string) (time.Duration, error) {
+	s := strings.ToLower(strings.TrimSpace(raw))
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid duration %q (examples: 180d, 2w, 36h)", raw)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q (examples: 180d, 2w, 36h)", raw)
+	}
+	return d, nil
+}
+
+// ParseDate parses a calendar date (YYYY-MM-DD, interpreted as UTC midnight)
+// or an RFC 3339 timestamp.
+func ParseDate(raw string) (time.Time, error) {
+	s := strings.TrimSpace(raw)
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD or RFC 3339)", raw)
+}
+
+// ParseSizeKB parses a repository size. GitHub reports sizes in kilobytes, so a
+// bare number is KB; KB, MB and GB suffixes (1024-based) are also accepted.
+func ParseSizeKB(raw string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	mult := int64(1)
+	for _, u := range []struct {
+		suffix string
+		mult   int64
+	}{{"GB", 1 << 20}, {"MB", 1 << 10}, {"KB", 1}} {
+		if n, ok := strings.CutSuffix(s, u.suffix); ok {
+			s, mult = strings.TrimSpace(n), u.mult
+			break
+		}
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid size %q (examples: 500, 500KB, 10MB, 1GB)", raw)
+	}
+	return v * mult, nil
+}
+
+// ParsePropertyFilters parses --property key=value entries into a map from
+// property name to accepted values. Repeating a key accepts any of its values.
+func ParsePropertyFilters(values []string) (map[string][]string, error) {
+	out := make(map[string][]string)
+	for _, raw := range values {
+		key, value, ok := strings.Cut(raw, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --property entry %q: expected key=value", raw)
+		}
+		out[key] = append(out[key], value)
+	}
+	return out, nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "180d", want: 180 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: " 90M ", want: 90 * time.Minute},
+		{in: "", wantErr: true},
+		{in: "-1d", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, %v; want %v (err %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	got, err := ParseDate("2024-03-01")
+	if err != nil || !got.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("ParseDate(date) = %v, %v", got, err)
+	}
+	got, err = ParseDate("2024-03-01T12:00:00+02:00")
+	if err != nil || !got.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("ParseDate(RFC 3339) = %v, %v", got, err)
+	}
+	if _, err := ParseDate("03/01/2024"); err == nil {
+		t.Fatalf("expected error for unsupported format")
+	}
+}
+
+func TestParseSizeKB(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "500", want: 500},
+		{in: "500kb", want: 500},
+		{in: "10MB", want: 10 << 10},
+		{in: "1 GB", want: 1 << 20},
+		{in: "-5", wantErr: true},
+		{in: "big", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSizeKB(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSizeKB(%q) = %v, %v; want %v (err %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParsePropertyFilters(t *testing.T) {
+	got, err := ParsePropertyFilters([]string{"tier=critical", " tier = pci ", "team=payments=core"})
+	if err != nil {
+		t.Fatalf("ParsePropertyFilters returned error: %v", err)
+	}
+	want := map[string][]string{"tier": {"critical", "pci"}, "team": {"payments=core"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, err := ParsePropertyFilters([]string{"tier"}); err == nil {
+		t.Fatalf("expected error for missing '='")
+	}
+}
+
+func TestValidate_DiscoveryFilters(t *testing.T) {
+	cfg := New()
+	cfg.Targeting.Org = "acme"
+	cfg.Targeting.Language = []string{"go, python"}
+	cfg.Targeting.IsTemplate = " ONLY "
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Targeting.Language, []string{"go", "python"}) || cfg.Targeting.IsTemplate != "only" {
+		t.Fatalf("unexpected normalization: %+v", cfg.Targeting)
+	}
+
+	for name, apply := range map[string]func(c *Config){
+		"is_template":   func(c *Config) { c.Targeting.IsTemplate = "sometimes" },
+		"size_range":    func(c *Config) { c.Targeting.MinSizeKB, c.Targeting.MaxSizeKB = 10, 5 },
+		"bad_property":  func(c *Config) { c.Targeting.Property = []string{"tier"} },
+		"negative_push": func(c *Config) { c.Targeting.PushedWithin = -time.Hour },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := New()
+			cfg.Targeting.Org = "acme"
+			apply(cfg)
+			if err := cfg.Validate(); err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+		})
+	}
+}
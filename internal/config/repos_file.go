@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// StdinSelector is the --repos / --repos-file value that reads the list from stdin.
+const StdinSelector = "-"
+
+// ReadRepoList parses a newline-delimited repository list such as a CMDB export.
+// Blank lines are skipped, and '#' starts a comment when it begins a line or
+// follows whitespace (so URL fragments like repo#readme are kept). Entries are
+// returned as written; Validate and discovery normalize them like --repos values.
+func ReadRepoList(r io.Reader) ([]string, error) {
+	var out []string
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := stripComment(sc.Text())
+		if line = strings.TrimSpace(line); line != "" {
+			out = append(out, line)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func stripComment(line string) string {
+	for i, r := range line {
+		if r != '#' {
+			continue
+		}
+		if i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// LoadRepoTargets expands --repos-file and a "-" entry in --repos into
+// Targeting.Repos. Either may name stdin ("-"), which is read at most once.
+// It must run before Validate.
+func (c *Config) LoadRepoTargets(stdin io.Reader) error {
+	usedStdin := false
+	readStdin := func(flag string) ([]string, error) {
+		if usedStdin {
+			return nil, fmt.Errorf("%s: stdin (-) can only be used once", flag)
+		}
+		usedStdin = true
+		list, err := ReadRepoList(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to read stdin: %w", flag, err)
+		}
+		return list, nil
+	}
+
+	repos := make([]string, 0, len(c.Targeting.Repos))
+	for _, v := range c.Targeting.Repos {
+		if strings.TrimSpace(v) != StdinSelector {
+			repos = append(repos, v)
+			continue
+		}
+		list, err := readStdin("--repos")
+		if err != nil {
+			return err
+		}
+		repos = append(repos, list...)
+	}
+
+	if path := strings.TrimSpace(c.Targeting.ReposFile); path != "" {
+		var list []string
+		var err error
+		if path == StdinSelector {
+			list, err = readStdin("--repos-file")
+		} else {
+			list, err = readRepoListFile(path)
+		}
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			return errors.New("--repos-file: no repositories listed")
+		}
+		repos = append(repos, list...)
+	}
+
+	c.Targeting.Repos = repos
+	return nil
+}
+
+func readRepoListFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("--repos-file: %w", err)
+	}
+	defer f.Close()
+
+	list, err := ReadRepoList(f)
+	if err != nil {
+		return nil, fmt.Errorf("--repos-file: failed to read %s: %w", path, err)
+	}
+	return list, nil
+}
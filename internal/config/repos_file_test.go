@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadRepoList_SkipsBlankLinesAndComments(t *testing.T) {
+	in := strings.Join([]string{
+		"# exported from CMDB",
+		"acme/foo",
+		"",
+		"   https://github.com/acme/bar   # payments team",
+		"\tacme/baz\t",
+		"https://github.com/acme/qux#readme",
+	}, "\n")
+
+	got, err := ReadRepoList(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("ReadRepoList returned error: %v", err)
+	}
+	want := []string{"acme/foo", "https://github.com/acme/bar", "acme/baz", "https://github.com/acme/qux#readme"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestLoadRepoTargets(t *testing.T) {
+	t.Run("file and flag values are merged", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "repos.txt")
+		if err := os.WriteFile(path, []byte("acme/a\n# skip\nacme/b\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		cfg := New()
+		cfg.Targeting.Repos = []string{"acme/x"}
+		cfg.Targeting.ReposFile = path
+
+		if err := cfg.LoadRepoTargets(strings.NewReader("")); err != nil {
+			t.Fatalf("LoadRepoTargets returned error: %v", err)
+		}
+		if want := []string{"acme/x", "acme/a", "acme/b"}; !reflect.DeepEqual(cfg.Targeting.Repos, want) {
+			t.Fatalf("got %v, want %v", cfg.Targeting.Repos, want)
+		}
+	})
+
+	t.Run("repos dash reads stdin", func(t *testing.T) {
+		cfg := New()
+		cfg.Targeting.Repos = []string{"-"}
+		if err := cfg.LoadRepoTargets(strings.NewReader("acme/a\nacme/b\n")); err != nil {
+			t.Fatalf("LoadRepoTargets returned error: %v", err)
+		}
+		if want := []string{"acme/a", "acme/b"}; !reflect.DeepEqual(cfg.Targeting.Repos, want) {
+			t.Fatalf("got %v, want %v", cfg.Targeting.Repos, want)
+		}
+	})
+
+	t.Run("stdin can only be used once", func(t *testing.T) {
+		cfg := New()
+		cfg.Targeting.Repos = []string{"-"}
+		cfg.Targeting.ReposFile = "-"
+		if err := cfg.LoadRepoTargets(strings.NewReader("acme/a\n")); err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		cfg := New()
+		cfg.Targeting.ReposFile = filepath.Join(t.TempDir(), "nope.txt")
+		if err := cfg.LoadRepoTargets(strings.NewReader("")); err == nil || !strings.Contains(err.Error(), "--repos-file") {
+			t.Fatalf("expected --repos-file error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Team permission levels accepted by --team-permission, lowest first.
+var teamPermissions = []string{"push", "maintain", "admin"}
+
+// NormalizeTeamSelector returns a --team value as "org/team-slug".
+//
+// Accepted forms: org/slug, @org/slug (as written in CODEOWNERS), and
+// https://github.com/orgs/<org>/teams/<slug>.
+func NormalizeTeamSelector(raw string) (string, error) {
+	s := strings.TrimSpace(raw)
+	if strings.HasPrefix(s, "github.com/") || strings.HasPrefix(s, "www.github.com/") {
+		s = "https://" + s
+	}
+	if strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return "", fmt.Errorf("%q", raw)
+		}
+		parts := strings.FieldsFunc(strings.Trim(u.Path, "/"), func(r rune) bool { return r == '/' })
+		if len(parts) != 4 || parts[0] != "orgs" || parts[2] != "teams" {
+			return "", fmt.Errorf("%q (expected https://github.com/orgs/ORG/teams/SLUG)", raw)
+		}
+		s = parts[1] + "/" + parts[3]
+	}
+
+	org, slug, ok := strings.Cut(strings.TrimPrefix(s, "@"), "/")
+	org, slug = strings.TrimSpace(org), strings.TrimSpace(slug)
+	if !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+		return "", fmt.Errorf("%q (expected ORG/TEAM-SLUG)", raw)
+	}
+	return strings.ToLower(org) + "/" + strings.ToLower(slug), nil
+}
+
+// TeamPermissionSatisfied reports whether a repository permission map (as
+// returned for team repositories) grants at least the minimum level. An empty
+// minimum accepts any access.
+func TeamPermissionSatisfied(perms map[string]bool, minimum string) bool {
+	if minimum == "" {
+		return true
+	}
+	at := -1
+	for i, p := range teamPermissions {
+		if p == minimum {
+			at = i
+		}
+	}
+	if at < 0 {
+		return false
+	}
+	for _, p := range teamPermissions[at:] {
+		if perms[p] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package config
+
+import "testing"
+
+func TestNormalizeTeamSelector(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "acme/platform", want: "acme/platform"},
+		{in: " @Acme/Platform ", want: "acme/platform"},
+		{in: "https://github.com/orgs/acme/teams/platform", want: "acme/platform"},
+		{in: "github.com/orgs/acme/teams/platform/", want: "acme/platform"},
+		{in: "acme", wantErr: true},
+		{in: "acme/", wantErr: true},
+		{in: "acme/platform/extra", wantErr: true},
+		{in: "https://github.com/acme/platform", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizeTeamSelector(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeTeamSelector(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("NormalizeTeamSelector(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeamPermissionSatisfied(t *testing.T) {
+	maintainer := map[string]bool{"pull": true, "triage": true, "push": true, "maintain": true}
+	tests := []struct {
+		minimum string
+		want    bool
+	}{
+		{minimum: "", want: true},
+		{minimum: "push", want: true},
+		{minimum: "maintain", want: true},
+		{minimum: "admin", want: false},
+	}
+	for _, tt := range tests {
+		if got := TeamPermissionSatisfied(maintainer, tt.minimum); got != tt.want {
+			t.Errorf("TeamPermissionSatisfied(maintain, %q) = %v, want %v", tt.minimum, got, tt.want)
+		}
+	}
+}
+
+func TestValidate_Team(t *testing.T) {
+	cfg := New()
+	cfg.Targeting.Team = []string{"acme/platform,@acme/security"}
+	cfg.Targeting.TeamPermission = " Maintain "
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+	if len(cfg.Targeting.Team) != 2 || cfg.Targeting.Team[1] != "acme/security" || cfg.Targeting.TeamPermission != "maintain" {
+		t.Fatalf("unexpected normalization: %v %q", cfg.Targeting.Team, cfg.Targeting.TeamPermission)
+	}
+
+	for name, mutate := range map[string]func(*Config){
+		"bad permission":          func(c *Config) { c.Targeting.Team = []string{"acme/platform"}; c.Targeting.TeamPermission = "pull" },
+		"combined with org":       func(c *Config) { c.Targeting.Team = []string{"acme/platform"}; c.Targeting.Org = "acme" },
+		"permission without team": func(c *Config) { c.Targeting.Org = "acme"; c.Targeting.TeamPermission = "admin" },
+	} {
+		cfg := New()
+		mutate(cfg)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
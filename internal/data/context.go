@@ -5,6 +5,12 @@ type DataContext interface {
 	Get(key DependencyKey) (any, bool)
 }
 
+// Releaser is implemented by DataContexts that can drop their fetched values
+// once every rule for the repository has been evaluated.
+type Releaser interface {
+	Release()
+}
+
 // MapDataContext is a simple read-only map-based implementation of DataContext.
 type MapDataContext struct {
 	data map[DependencyKey]any
@@ -23,3 +29,12 @@ func (c *MapDataContext) Get(key DependencyKey) (any, bool) {
 	val, ok := c.data[key]
 	return val, ok
 }
+
+// Release drops the reference to the fetched values so they can be garbage
+// collected. Get reports every key as missing afterwards.
+func (c *MapDataContext) Release() {
+	if c == nil {
+		return
+	}
+	c.data = nil
+}
@@ -54,3 +54,21 @@ func TestMapDataContext_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestMapDataContext_Release(t *testing.T) {
+	dc := NewMapDataContext(map[DependencyKey]any{
+		DepRepoMetadata: "value",
+	})
+	if _, ok := dc.Get(DepRepoMetadata); !ok {
+		t.Fatalf("expected value before Release")
+	}
+
+	dc.Release()
+	if _, ok := dc.Get(DepRepoMetadata); ok {
+		t.Fatalf("expected no value after Release")
+	}
+
+	// Release on a nil receiver must not panic.
+	var nilDC *MapDataContext
+	nilDC.Release()
+}
@@ -1,5 +1,11 @@
 package data
 
+import (
+	"repomedic/internal/data/models"
+
+	"github.com/google/go-github/v81/github"
+)
+
 // FetchScope declares the caching/singleflight scope for a dependency fetch.
 //
 // RepoMedic fetchers are typically repo-scoped, but some dependencies can be
@@ -60,7 +66,7 @@ const (
 
 	// DepReposScanned represents the repositories discovered for the current scan.
 	//
-	// Value type: []*github.Repository
+	// Value type: []*models.ScannedRepo
 	DepReposScanned DependencyKey = "org.repos_scanned"
 
 	// DepReposMergeConvention represents a convention baseline derived from a sample
@@ -79,6 +85,39 @@ const (
 	//
 	// Value type: models.MergeMethodMask
 	DepRepoEffectiveMergeMethods DependencyKey = "repo.effective_merge_methods"
+
+	// DepOrgCustomProperties represents the custom property values of every
+	// repository in the organization, listed in bulk.
+	//
+	// Value type: *models.OrgCustomProperties
+	DepOrgCustomProperties DependencyKey = "org.custom_properties"
+
+	// DepRepoCustomProperties represents the repository's own custom property
+	// values, taken from the org-wide listing.
+	//
+	// Value type: models.CustomProperties
+	DepRepoCustomProperties DependencyKey = "repo.custom_properties"
+)
+
+// Typed keys bind each DependencyKey to the value type its fetcher returns.
+// Rules read them with Get; the fetcher checks every fetched value against
+// these types (see CheckValue).
+var (
+	RepoMetadata                        = NewKey[*github.Repository](DepRepoMetadata)
+	RepoDefaultBranchClassicProtection  = NewKey[*github.Protection](DepRepoDefaultBranchClassicProtection)
+	RepoDefaultBranchEffectiveRules     = NewKey[*github.BranchRules](DepRepoDefaultBranchEffectiveRules)
+	RepoDefaultBranchCodeowners         = NewKey[*models.CodeownersPresence](DepRepoDefaultBranchCodeowners)
+	RepoDefaultBranchReadme             = NewKey[*models.ReadmePresence](DepRepoDefaultBranchReadme)
+	RepoProtectedBranchesDeletionStatus = NewKey[*models.ProtectedBranchesDeletionStatus](DepRepoProtectedBranchesDeletionStatus)
+	RepoClassicBranchProtections        = NewKey[*models.ClassicBranchProtections](DepRepoClassicBranchProtections)
+	RepoAllRulesets                     = NewKey[[]*github.RepositoryRuleset](DepRepoAllRulesets)
+	OrgMergeBaseline                    = NewKey[*models.MergeBaseline](DepOrgMergeBaseline)
+	ReposScanned                        = NewKey[[]*models.ScannedRepo](DepReposScanned)
+	ReposMergeConvention                = NewKey[*models.MergeBaseline](DepReposMergeConvention)
+	MergeBaseline                       = NewKey[*models.MergeBaseline](DepMergeBaseline)
+	RepoEffectiveMergeMethods           = NewKey[models.MergeMethodMask](DepRepoEffectiveMergeMethods)
+	OrgCustomProperties                 = NewKey[*models.OrgCustomProperties](DepOrgCustomProperties)
+	RepoCustomProperties                = NewKey[models.CustomProperties](DepRepoCustomProperties)
 )
 
 // Priority returns the fetch priority for a dependency key (lower is higher priority).
@@ -0,0 +1,87 @@
+package models
+
+import "sort"
+
+// CustomProperties holds a repository's organization custom property values,
+// keyed by property name. Single-value properties have one element;
+// multi-select properties may have several. Unset properties are absent.
+type CustomProperties map[string][]string
+
+// CustomPropertiesFromAny converts GitHub's loosely typed property values
+// (string, []string or []any of strings; nil when unset) into CustomProperties.
+func CustomPropertiesFromAny(raw map[string]any) CustomProperties {
+	out := make(CustomProperties, len(raw))
+	for name, value := range raw {
+		var vals []string
+		switch v := value.(type) {
+		case string:
+			vals = []string{v}
+		case []string:
+			vals = append(vals, v...)
+		case []any:
+			for _, item := range v {
+				if s, ok := item.(string); ok {
+					vals = append(vals, s)
+				}
+			}
+		}
+		if len(vals) > 0 {
+			out[name] = vals
+		}
+	}
+	return out
+}
+
+// Has reports whether property name has any of the accepted values.
+func (p CustomProperties) Has(name string, accepted []string) bool {
+	for _, h := range p[name] {
+		for _, a := range accepted {
+			if h == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Matches reports whether every property in want has one of its accepted values.
+func (p CustomProperties) Matches(want map[string][]string) bool {
+	for name, accepted := range want {
+		if !p.Has(name, accepted) {
+			return false
+		}
+	}
+	return true
+}
+
+// Names returns the property names that have a value, sorted.
+func (p CustomProperties) Names() []string {
+	out := make([]string, 0, len(p))
+	for name := range p {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// OrgCustomProperties is the bulk listing of custom property values for every
+// repository in an organization.
+//
+// Available is false when the owner has no custom properties endpoint (user
+// accounts) or the token cannot read it; every repository then reads as
+// having no properties. Denied tells the latter apart: the API refused the
+// listing (403/404), usually for lack of organization_custom_properties:read.
+type OrgCustomProperties struct {
+	Org       string
+	Available bool
+	Denied    bool
+	ByRepoID  map[int64]CustomProperties
+}
+
+// ForRepo returns the property values for repository id (never nil).
+func (o *OrgCustomProperties) ForRepo(id int64) CustomProperties {
+	if o == nil || o.ByRepoID[id] == nil {
+		return CustomProperties{}
+	}
+	return o.ByRepoID[id]
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCustomPropertiesFromAny(t *testing.T) {
+	got := CustomPropertiesFromAny(map[string]any{
+		"tier":    "critical",
+		"labels":  []any{"pci", 3, "sox"},
+		"owners":  []string{"platform"},
+		"unset":   nil,
+		"ignored": 42,
+	})
+	want := CustomProperties{
+		"tier":   {"critical"},
+		"labels": {"pci", "sox"},
+		"owners": {"platform"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CustomPropertiesFromAny() = %v, want %v", got, want)
+	}
+}
+
+func TestCustomProperties_Matches(t *testing.T) {
+	props := CustomProperties{"tier": {"low", "pci"}, "team": {"payments"}}
+
+	tests := []struct {
+		name string
+		want map[string][]string
+		ok   bool
+	}{
+		{name: "empty filter", want: nil, ok: true},
+		{name: "any value of multi-select", want: map[string][]string{"tier": {"pci"}}, ok: true},
+		{name: "all keys required", want: map[string][]string{"tier": {"pci"}, "team": {"core"}}, ok: false},
+		{name: "missing property", want: map[string][]string{"region": {"eu"}}, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := props.Matches(tt.want); got != tt.ok {
+				t.Fatalf("Matches(%v) = %v, want %v", tt.want, got, tt.ok)
+			}
+		})
+	}
+}
+
+func TestOrgCustomProperties_ForRepo(t *testing.T) {
+	var nilOrg *OrgCustomProperties
+	if got := nilOrg.ForRepo(1); got == nil || len(got) != 0 {
+		t.Fatalf("ForRepo on nil listing = %v, want empty", got)
+	}
+	org := &OrgCustomProperties{ByRepoID: map[int64]CustomProperties{1: {"tier": {"critical"}}}}
+	if got := org.ForRepo(1); !got.Has("tier", []string{"critical"}) {
+		t.Fatalf("ForRepo(1) = %v", got)
+	}
+}
@@ -0,0 +1,29 @@
+package models
+
+// ScannedRepo is a slim projection of a repository discovered for the current
+// scan.
+//
+// It keeps only the fields org-scoped fetchers need to derive baselines and
+// conventions, so the scanned set stays small even for very large orgs.
+//
+// Merge settings are nil when the discovery listing did not include them; in
+// that case consumers should fall back to DepRepoMetadata.
+type ScannedRepo struct {
+	ID            int64
+	Owner         string
+	Name          string
+	FullName      string
+	DefaultBranch string
+
+	AllowMergeCommit *bool
+	AllowSquashMerge *bool
+	AllowRebaseMerge *bool
+}
+
+// HasMergeSettings reports whether any merge-method setting is known.
+func (r *ScannedRepo) HasMergeSettings() bool {
+	if r == nil {
+		return false
+	}
+	return r.AllowMergeCommit != nil || r.AllowSquashMerge != nil || r.AllowRebaseMerge != nil
+}
@@ -0,0 +1,110 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Key is a DependencyKey bound to the Go type of the value its fetcher
+// returns. Typed keys are declared once (see NewKey) and read with Get, so
+// rules do not repeat type assertions and fetchers returning the wrong type
+// are caught centrally (see CheckValue).
+type Key[T any] struct {
+	id DependencyKey
+}
+
+// ID returns the untyped DependencyKey, e.g. for Rule.Dependencies.
+func (k Key[T]) ID() DependencyKey {
+	return k.id
+}
+
+var (
+	valueTypes   = make(map[DependencyKey]reflect.Type)
+	valueTypesMu sync.RWMutex
+)
+
+// NewKey registers T as the value type of id and returns the typed key.
+// Registering a different type for the same id panics.
+func NewKey[T any](id DependencyKey) Key[T] {
+	if id == "" {
+		panic("typed dependency key is empty")
+	}
+	t := reflect.TypeFor[T]()
+
+	valueTypesMu.Lock()
+	defer valueTypesMu.Unlock()
+	if existing, ok := valueTypes[id]; ok && existing != t {
+		panic(fmt.Sprintf("dependency key %s already registered with value type %s", id, existing))
+	}
+	valueTypes[id] = t
+	return Key[T]{id: id}
+}
+
+// ValueType returns the registered value type for key, if any.
+func ValueType(key DependencyKey) (reflect.Type, bool) {
+	valueTypesMu.RLock()
+	defer valueTypesMu.RUnlock()
+	t, ok := valueTypes[key]
+	return t, ok
+}
+
+// ErrDependencyMissing is returned (wrapped) by Get when the key is not in the DataContext.
+var ErrDependencyMissing = errors.New("dependency missing")
+
+// TypeMismatchError reports a dependency value whose type does not match the
+// type registered for its key.
+type TypeMismatchError struct {
+	Key  DependencyKey
+	Got  string
+	Want string
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("invalid dependency type: %s (got %s, want %s)", e.Key, e.Got, e.Want)
+}
+
+// CheckValue verifies that v matches the value type registered for key.
+// Keys without a registered type accept any value. A nil value is accepted
+// for types that can be nil (pointers, slices, maps, interfaces), since
+// fetchers use nil to report "not present".
+func CheckValue(key DependencyKey, v any) error {
+	want, ok := ValueType(key)
+	if !ok {
+		return nil
+	}
+	if v == nil {
+		switch want.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			return nil
+		}
+		return &TypeMismatchError{Key: key, Got: "nil", Want: want.String()}
+	}
+	if got := reflect.TypeOf(v); !got.AssignableTo(want) {
+		return &TypeMismatchError{Key: key, Got: got.String(), Want: want.String()}
+	}
+	return nil
+}
+
+// Get reads a typed dependency from dc. It returns an error wrapping
+// ErrDependencyMissing when the key is absent, and a *TypeMismatchError when
+// the stored value has the wrong type. A stored nil yields the zero value of T.
+func Get[T any](dc DataContext, k Key[T]) (T, error) {
+	var zero T
+	if dc == nil {
+		return zero, fmt.Errorf("%w: %s", ErrDependencyMissing, k.id)
+	}
+	v, ok := dc.Get(k.id)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrDependencyMissing, k.id)
+	}
+	if v == nil {
+		return zero, nil
+	}
+	typed, ok := v.(T)
+	if !ok {
+		return zero, &TypeMismatchError{Key: k.id, Got: reflect.TypeOf(v).String(), Want: reflect.TypeFor[T]().String()}
+	}
+	return typed, nil
+}
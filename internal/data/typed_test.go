@@ -0,0 +1,100 @@
+package data
+
+import (
+	"errors"
+	"testing"
+)
+
+type typedTestValue struct{ N int }
+
+func TestGet_Typed(t *testing.T) {
+	key := NewKey[*typedTestValue]("test.typed.get")
+
+	t.Run("present", func(t *testing.T) {
+		dc := NewMapDataContext(map[DependencyKey]any{key.ID(): &typedTestValue{N: 7}})
+		got, err := Get(dc, key)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got == nil || got.N != 7 {
+			t.Fatalf("got %+v, want N=7", got)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := Get(NewMapDataContext(nil), key)
+		if !errors.Is(err, ErrDependencyMissing) {
+			t.Fatalf("expected ErrDependencyMissing, got %v", err)
+		}
+		if err.Error() != "dependency missing: test.typed.get" {
+			t.Fatalf("expected key in message, got %q", err.Error())
+		}
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		dc := NewMapDataContext(map[DependencyKey]any{key.ID(): nil})
+		got, err := Get(dc, key)
+		if err != nil || got != nil {
+			t.Fatalf("got (%v, %v), want (nil, nil)", got, err)
+		}
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		dc := NewMapDataContext(map[DependencyKey]any{key.ID(): "nope"})
+		_, err := Get(dc, key)
+		var mismatch *TypeMismatchError
+		if !errors.As(err, &mismatch) {
+			t.Fatalf("expected TypeMismatchError, got %v", err)
+		}
+		if mismatch.Got != "string" || mismatch.Want != "*data.typedTestValue" {
+			t.Fatalf("unexpected mismatch: %+v", mismatch)
+		}
+	})
+}
+
+func TestCheckValue(t *testing.T) {
+	ptrKey := NewKey[*typedTestValue]("test.typed.check.ptr")
+	intKey := NewKey[int]("test.typed.check.int")
+
+	tests := []struct {
+		name    string
+		key     DependencyKey
+		value   any
+		wantErr bool
+	}{
+		{name: "matching pointer", key: ptrKey.ID(), value: &typedTestValue{}},
+		{name: "nil pointer allowed", key: ptrKey.ID(), value: nil},
+		{name: "wrong type", key: ptrKey.ID(), value: typedTestValue{}, wantErr: true},
+		{name: "matching value type", key: intKey.ID(), value: 3},
+		{name: "nil for value type", key: intKey.ID(), value: nil, wantErr: true},
+		{name: "unregistered key accepts anything", key: "test.typed.check.unregistered", value: "x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckValue(tt.key, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewKey_ConflictingTypePanics(t *testing.T) {
+	NewKey[string]("test.typed.conflict")
+	NewKey[string]("test.typed.conflict") // same type is fine
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for conflicting value type")
+		}
+	}()
+	NewKey[int]("test.typed.conflict")
+}
+
+func TestTypedKeys_RegisterValueTypes(t *testing.T) {
+	for _, key := range []DependencyKey{DepRepoMetadata, DepRepoAllRulesets, DepRepoEffectiveMergeMethods, DepReposScanned} {
+		if _, ok := ValueType(key); !ok {
+			t.Errorf("no value type registered for %s", key)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"repomedic/internal/output"
+	"time"
+)
+
+// Bounds and tuning for --concurrency auto.
+const (
+	autoConcurrencyInitial = 4
+	autoConcurrencyMin     = 1
+	autoConcurrencyMax     = 32
+
+	// autoConcurrencyInterval is how often the controller re-evaluates the worker count.
+	autoConcurrencyInterval = 2 * time.Second
+
+	// autoRequestsPerWorkerMinute is the rate-limit headroom (remaining requests
+	// per minute until reset) each fetch worker is expected to need.
+	autoRequestsPerWorkerMinute = 10
+
+	// autoLatencyDegradation is how much slower than the best observed average
+	// fetch latency a window may be before it counts as unhealthy.
+	autoLatencyDegradation = 2
+)
+
+// concurrencySignals is what the adaptive controller observes for one interval.
+type concurrencySignals struct {
+	// remaining and resetIn describe the current rate-limit budget.
+	remaining int
+	resetIn   time.Duration
+
+	// throttled and serverErrors count secondary-rate-limit and 5xx responses
+	// observed since the previous interval.
+	throttled    int
+	serverErrors int
+
+	// avgLatency is the mean dependency fetch latency over the interval
+	// (0 when nothing completed).
+	avgLatency time.Duration
+
+	// backlog is the number of fetches queued and waiting for a worker.
+	backlog int
+}
+
+// adaptiveConcurrency is an AIMD controller for the fetch worker count: it
+// grows by one worker per interval while rate-limit headroom and latency are
+// healthy and there is queued work, halves on secondary rate limits or 5xx
+// responses, and steps down when headroom gets tight or latency degrades.
+type adaptiveConcurrency struct {
+	min, max int
+	initial  int
+	current  int
+
+	// baseline is the best average fetch latency observed so far.
+	baseline time.Duration
+
+	changes []output.ConcurrencyChange
+}
+
+func newAdaptiveConcurrency(initial, minWorkers, maxWorkers int) *adaptiveConcurrency {
+	initial = min(max(initial, minWorkers), maxWorkers)
+	return &adaptiveConcurrency{
+		min:     minWorkers,
+		max:     maxWorkers,
+		initial: initial,
+		current: initial,
+	}
+}
+
+// adjust applies one interval of signals and returns the new worker count.
+func (a *adaptiveConcurrency) adjust(now time.Time, s concurrencySignals) int {
+	if s.avgLatency > 0 && (a.baseline == 0 || s.avgLatency < a.baseline) {
+		a.baseline = s.avgLatency
+	}
+
+	minutes := max(s.resetIn.Minutes(), 1)
+	headroom := int(float64(s.remaining) / minutes / autoRequestsPerWorkerMinute)
+
+	next, reason := a.current, ""
+	switch {
+	case s.throttled > 0:
+		next, reason = a.current/2, "secondary rate limit"
+	case s.serverErrors > 0:
+		next, reason = a.current/2, "server errors (5xx)"
+	case headroom < a.current:
+		next, reason = headroom, "rate-limit headroom low"
+	case a.baseline > 0 && s.avgLatency > a.baseline*autoLatencyDegradation:
+		next, reason = a.current-1, "latency degraded"
+	case s.backlog > 0 && headroom > a.current:
+		next, reason = a.current+1, "headroom healthy"
+	}
+	next = min(max(next, a.min), a.max)
+
+	if next != a.current {
+		a.current = next
+		a.changes = append(a.changes, output.ConcurrencyChange{At: now, Workers: next, Reason: reason})
+	}
+	return a.current
+}
@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdaptiveConcurrency_Adjust(t *testing.T) {
+	// 60k remaining over 10 minutes leaves room for 600 workers, i.e. plenty.
+	healthy := concurrencySignals{remaining: 60000, resetIn: 10 * time.Minute, avgLatency: 100 * time.Millisecond, backlog: 5}
+
+	tests := []struct {
+		name       string
+		current    int
+		signals    concurrencySignals
+		want       int
+		wantReason string
+	}{
+		{
+			name:       "grows by one while healthy with queued work",
+			current:    4,
+			signals:    healthy,
+			want:       5,
+			wantReason: "headroom healthy",
+		},
+		{
+			name:    "holds when nothing is queued",
+			current: 4,
+			signals: func() concurrencySignals { s := healthy; s.backlog = 0; return s }(),
+			want:    4,
+		},
+		{
+			name:    "never exceeds max",
+			current: 8,
+			signals: healthy,
+			want:    8,
+		},
+		{
+			name:       "halves on secondary rate limit",
+			current:    8,
+			signals:    func() concurrencySignals { s := healthy; s.throttled = 1; return s }(),
+			want:       4,
+			wantReason: "secondary rate limit",
+		},
+		{
+			name:       "halves on server errors",
+			current:    6,
+			signals:    func() concurrencySignals { s := healthy; s.serverErrors = 3; return s }(),
+			want:       3,
+			wantReason: "server errors (5xx)",
+		},
+		{
+			name:       "drops to what the remaining budget per minute supports",
+			current:    6,
+			signals:    concurrencySignals{remaining: 600, resetIn: 30 * time.Minute, avgLatency: 100 * time.Millisecond, backlog: 5},
+			want:       2,
+			wantReason: "rate-limit headroom low",
+		},
+		{
+			name:       "never drops below min",
+			current:    2,
+			signals:    concurrencySignals{remaining: 0, resetIn: 30 * time.Minute, backlog: 5},
+			want:       1,
+			wantReason: "rate-limit headroom low",
+		},
+		{
+			name:       "steps down when latency degrades",
+			current:    6,
+			signals:    func() concurrencySignals { s := healthy; s.avgLatency = 300 * time.Millisecond; return s }(),
+			want:       5,
+			wantReason: "latency degraded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newAdaptiveConcurrency(tt.current, 1, 8)
+			a.baseline = 100 * time.Millisecond
+
+			now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+			if got := a.adjust(now, tt.signals); got != tt.want {
+				t.Fatalf("adjust() = %d, want %d", got, tt.want)
+			}
+
+			if tt.wantReason == "" {
+				if len(a.changes) != 0 {
+					t.Fatalf("expected no recorded change, got %+v", a.changes)
+				}
+				return
+			}
+			if len(a.changes) != 1 {
+				t.Fatalf("expected one recorded change, got %+v", a.changes)
+			}
+			c := a.changes[0]
+			if c.Workers != tt.want || c.Reason != tt.wantReason || !c.At.Equal(now) {
+				t.Fatalf("recorded change = %+v, want workers=%d reason=%q at %v", c, tt.want, tt.wantReason, now)
+			}
+		})
+	}
+}
+
+func TestScheduler_ConcurrencyStats(t *testing.T) {
+	t.Run("fixed", func(t *testing.T) {
+		s := &Scheduler{fetchConcurrency: 6}
+		stats := s.ConcurrencyStats()
+		if stats.Mode != "fixed" || stats.Initial != 6 || stats.Min != 6 || stats.Max != 6 || stats.Final != 6 {
+			t.Fatalf("unexpected fixed stats: %+v", stats)
+		}
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		s := &Scheduler{}
+		if err := s.EnableAdaptiveConcurrency(8); err != nil {
+			t.Fatalf("EnableAdaptiveConcurrency: %v", err)
+		}
+		now := time.Now()
+		s.adaptive.adjust(now, concurrencySignals{remaining: 60000, resetIn: 10 * time.Minute, backlog: 1})
+		s.adaptive.adjust(now, concurrencySignals{remaining: 60000, resetIn: 10 * time.Minute, throttled: 1})
+
+		stats := s.ConcurrencyStats()
+		if stats.Mode != "auto" || stats.Initial != autoConcurrencyInitial {
+			t.Fatalf("unexpected auto stats: %+v", stats)
+		}
+		if stats.Max != autoConcurrencyInitial+1 || stats.Final != (autoConcurrencyInitial+1)/2 || stats.Min != stats.Final {
+			t.Fatalf("unexpected auto range: %+v", stats)
+		}
+		if len(stats.Changes) != 2 {
+			t.Fatalf("expected 2 recorded changes, got %+v", stats.Changes)
+		}
+	})
+}
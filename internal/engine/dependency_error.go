@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-github/v81/github"
 
 	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
 )
 
 type depErrorDisposition int
@@ -40,6 +41,12 @@ func presentDependencyError(key data.DependencyKey, err error, verbose bool) dep
 
 	full := err.Error()
 
+	// A fetcher returning the wrong value type is a bug worth naming plainly.
+	var invalid *fetcher.InvalidValueError
+	if errors.As(err, &invalid) {
+		return depErrorPresentation{disposition: depErrDispositionError, message: invalid.Error(), verbose: full}
+	}
+
 	// Prefer structured GitHub error types to avoid leaking full request URLs.
 	var er *github.ErrorResponse
 	if errors.As(err, &er) {
@@ -2,11 +2,13 @@ package engine
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/google/go-github/v81/github"
 
 	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
 )
 
 func TestPresentDependencyError_Forbidden_DefaultBranchProtection_IsSkippableAndPreservesMessage(t *testing.T) {
@@ -40,6 +42,18 @@ func TestPresentDependencyError_Forbidden_Metadata_IsHardError(t *testing.T) {
 	}
 }
 
+func TestPresentDependencyError_InvalidValue_NamesFetcher(t *testing.T) {
+	err := &fetcher.InvalidValueError{Fetcher: "metadataFetcher", Key: data.DepRepoMetadata, Got: "string", Want: "*github.Repository"}
+
+	pres := presentDependencyError(data.DepRepoMetadata, err, false)
+	if pres.disposition != depErrDispositionError {
+		t.Fatalf("expected hard error disposition, got %v", pres.disposition)
+	}
+	if !strings.Contains(pres.message, "metadataFetcher") {
+		t.Fatalf("expected fetcher name in message, got %q", pres.message)
+	}
+}
+
 func TestScrubGitHubRequestFromErrorString_StripsURLPrefix(t *testing.T) {
 	s := "GET https://api.github.com/repos/acme/foo/branches/main/protection: 403 some message []"
 	out := scrubGitHubRequestFromErrorString(s)
@@ -5,23 +5,71 @@ import (
 	"fmt"
 	"net/url"
 	"repomedic/internal/config"
+	"repomedic/internal/fetcher"
 	gh "repomedic/internal/github"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/google/go-github/v81/github"
 )
 
-const defaultOrgDiscoveryRepoLimit = 1000
+// discoveryPageSize is the per_page value used for repository listings.
+const discoveryPageSize = 100
 
 type RepositoryRef struct {
 	Owner string
 	Name  string
 	ID    int64
 	Repo  *github.Repository // Keep the full object if we have it
+
+	// Teams lists the ORG/TEAM-SLUG teams through which --team targeting
+	// reached this repository (empty for other targeting modes).
+	Teams []string
+}
+
+// Discovery is the outcome of resolving scan targets.
+type Discovery struct {
+	Refs []RepositoryRef
+
+	// Limit is the discovery limit that was applied (0 means unlimited).
+	Limit int
+
+	// Truncated reports whether Limit stopped discovery before the listing
+	// was exhausted.
+	Truncated bool
+
+	// Total is the number of repositories the listing offered. When Truncated
+	// is true it is a best-effort count; 0 means the total could not be determined.
+	Total int
+
+	// SearchCapped reports whether --search matched more repositories than the
+	// Search API returns and the excess could not be split out by created date.
+	SearchCapped bool
 }
 
+// ResolveRepos resolves the configured targets to a list of repositories.
+//
+// See DiscoverRepos for details on discovery limits.
 func ResolveRepos(ctx context.Context, client *gh.Client, cfg *config.Config) ([]RepositoryRef, error) {
+	d, err := DiscoverRepos(ctx, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return d.Refs, nil
+}
+
+// DiscoverRepos resolves the configured targets and reports whether a repo
+// limit (--max-repos) truncated account-wide discovery.
+//
+// With --max-repos 0, org and user listings are paged until exhausted.
+func DiscoverRepos(ctx context.Context, client *gh.Client, cfg *config.Config) (*Discovery, error) {
+	return DiscoverReposWithBudget(ctx, client, cfg, fetcher.NewRequestBudget())
+}
+
+// DiscoverReposWithBudget is DiscoverRepos with explicit --repos lookups
+// acquired from budget, so they share the rate limit accounting of the scan.
+func DiscoverReposWithBudget(ctx context.Context, client *gh.Client, cfg *config.Config, budget *fetcher.RequestBudget) (*Discovery, error) {
 	orgSel, userSel, err := normalizeTargetSelectors(cfg)
 	if err != nil {
 		return nil, err
@@ -34,44 +82,77 @@ func ResolveRepos(ctx context.Context, client *gh.Client, cfg *config.Config) ([
 
 	// Organization scope (optionally filtered by --repos selectors)
 	if orgSel != "" {
-		refs, err := listOrgRepoRefs(ctx, client, orgSel, computeRepoLimit(cfg))
+		d, err := listOrgRepoRefs(ctx, client, orgSel, computeRepoLimit(cfg))
 		if err != nil {
 			return nil, err
 		}
 
 		// Per UI spec: when used with org scope, --repos acts as an include-filter.
-		refs, err = filterRefsByRepoSelectors(refs, cfg.Targeting.Repos)
+		d.Refs, err = filterRefsByRepoSelectors(d.Refs, cfg.Targeting.Repos)
 		if err != nil {
 			return nil, err
 		}
-		return dedupeRefs(refs), nil
+		d.Refs = dedupeRefs(d.Refs)
+		return d, nil
 	}
 
 	// User scope (optionally filtered by --repos selectors)
 	if userSel != "" {
-		refs, err := listUserRepoRefs(ctx, client, userSel, computeRepoLimit(cfg))
+		d, err := listUserRepoRefs(ctx, client, userSel, computeRepoLimit(cfg))
 		if err != nil {
 			return nil, err
 		}
 
 		// In user scope, --repos acts as an include-filter (same semantics as org scope).
-		refs, err = filterRefsByRepoSelectors(refs, cfg.Targeting.Repos)
+		d.Refs, err = filterRefsByRepoSelectors(d.Refs, cfg.Targeting.Repos)
+		if err != nil {
+			return nil, err
+		}
+		d.Refs = dedupeRefs(d.Refs)
+		return d, nil
+	}
+
+	// Search scope (optionally filtered by --repos selectors)
+	if cfg.Targeting.Search != "" {
+		d, err := searchRepoRefs(ctx, client, cfg.Targeting.Search, computeRepoLimit(cfg), fetcher.NewSearchBudget())
+		if err != nil {
+			return nil, err
+		}
+
+		d.Refs, err = filterRefsByRepoSelectors(d.Refs, cfg.Targeting.Repos)
+		if err != nil {
+			return nil, err
+		}
+		d.Refs = dedupeRefs(d.Refs)
+		return d, nil
+	}
+
+	// Team scope (optionally filtered by --repos selectors)
+	if len(cfg.Targeting.Team) > 0 {
+		d, err := listTeamRepoRefs(ctx, client, cfg.Targeting.Team, cfg.Targeting.TeamPermission, cfg.Targeting.TeamChildren)
+		if err != nil {
+			return nil, err
+		}
+
+		d.Refs, err = filterRefsByRepoSelectors(d.Refs, cfg.Targeting.Repos)
 		if err != nil {
 			return nil, err
 		}
-		return dedupeRefs(refs), nil
+		d.Refs = dedupeRefs(d.Refs)
+		return d, nil
 	}
 
 	// Explicit repos
 	if len(cfg.Targeting.Repos) > 0 {
-		refs, err := resolveExplicitRepoRefs(ctx, client, cfg.Targeting.Repos)
+		refs, err := resolveExplicitRepoRefs(ctx, client, cfg.Targeting.Repos, budget)
 		if err != nil {
 			return nil, err
 		}
-		return dedupeRefs(refs), nil
+		refs = dedupeRefs(refs)
+		return &Discovery{Refs: refs, Total: len(refs)}, nil
 	}
 
-	return nil, nil
+	return &Discovery{}, nil
 }
 
 func normalizeTargetSelectors(cfg *config.Config) (orgSel string, userSel string, err error) {
@@ -96,52 +177,94 @@ func normalizeTargetSelectors(cfg *config.Config) (orgSel string, userSel string
 	return orgSel, userSel, nil
 }
 
+// computeRepoLimit returns the discovery limit for account-wide listings.
+// 0 means unlimited.
 func computeRepoLimit(cfg *config.Config) int {
-	limit := defaultOrgDiscoveryRepoLimit
 	if cfg.Targeting.MaxRepos > 0 {
-		limit = cfg.Targeting.MaxRepos
-	}
-	if limit < 1 {
-		limit = 1
+		return cfg.Targeting.MaxRepos
 	}
-	return limit
+	return 0
 }
 
-func listOrgRepoRefs(ctx context.Context, client *gh.Client, org string, limit int) ([]RepositoryRef, error) {
-	refs := make([]RepositoryRef, 0, min(limit, 100))
+// repoListPage lists one page (1-based) of a paginated repository listing.
+type repoListPage func(page int) ([]*github.Repository, *github.Response, error)
 
-	opts := &github.RepositoryListByOrgOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
+// listRepoRefsPaged walks a paginated repository listing until it is exhausted
+// or limit refs have been collected (limit <= 0 means unlimited).
+//
+// When the limit truncates the listing, Total is derived from the Link header's
+// last page (one extra request) so the caller can report how much was skipped.
+func listRepoRefsPaged(limit int, list repoListPage) (*Discovery, error) {
+	d := &Discovery{Limit: limit}
+	if limit > 0 {
+		d.Refs = make([]RepositoryRef, 0, min(limit, discoveryPageSize))
 	}
+
+	seen := 0
+	page := 1
 	for {
-		repos, resp, err := client.Client.Repositories.ListByOrg(ctx, org, opts)
+		repos, resp, err := list(page)
 		if err != nil {
-			return nil, fmt.Errorf("failed to list org repos: %w", err)
+			return nil, err
 		}
+		seen += len(repos)
 		for _, repo := range repos {
-			if len(refs) >= limit {
+			if limit > 0 && len(d.Refs) >= limit {
 				break
 			}
-			refs = append(refs, RepositoryRef{
-				Owner: repo.GetOwner().GetLogin(),
-				Name:  repo.GetName(),
-				ID:    repo.GetID(),
-				Repo:  repo,
-			})
+			d.Refs = append(d.Refs, refFromRepo(repo))
 		}
-		if len(refs) >= limit {
-			break
+
+		nextPage, lastPage := 0, 0
+		if resp != nil {
+			nextPage, lastPage = resp.NextPage, resp.LastPage
+		}
+
+		if limit > 0 && len(d.Refs) >= limit && (seen > len(d.Refs) || nextPage != 0) {
+			d.Truncated = true
+			switch {
+			case nextPage == 0:
+				d.Total = seen
+			case lastPage > 0:
+				// Best-effort: a failed count only loses the total, not the scan.
+				if last, _, err := list(lastPage); err == nil {
+					d.Total = (lastPage-1)*discoveryPageSize + len(last)
+				}
+			}
+			return d, nil
 		}
-		if resp.NextPage == 0 {
-			break
+		if nextPage == 0 {
+			d.Total = seen
+			return d, nil
 		}
-		opts.Page = resp.NextPage
+		page = nextPage
 	}
+}
 
-	return refs, nil
+func refFromRepo(repo *github.Repository) RepositoryRef {
+	return RepositoryRef{
+		Owner: repo.GetOwner().GetLogin(),
+		Name:  repo.GetName(),
+		ID:    repo.GetID(),
+		Repo:  repo,
+	}
+}
+
+func listOrgRepoRefs(ctx context.Context, client *gh.Client, org string, limit int) (*Discovery, error) {
+	opts := &github.RepositoryListByOrgOptions{
+		ListOptions: github.ListOptions{PerPage: discoveryPageSize},
+	}
+	d, err := listRepoRefsPaged(limit, func(page int) ([]*github.Repository, *github.Response, error) {
+		opts.Page = page
+		return client.Client.Repositories.ListByOrg(ctx, org, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list org repos: %w", err)
+	}
+	return d, nil
 }
 
-func listUserRepoRefs(ctx context.Context, client *gh.Client, user string, limit int) ([]RepositoryRef, error) {
+func listUserRepoRefs(ctx context.Context, client *gh.Client, user string, limit int) (*Discovery, error) {
 	// If the requested user matches the authenticated token owner, use the
 	// authenticated endpoint so private repos can be included.
 	useAuthed := false
@@ -156,75 +279,35 @@ func listUserRepoRefs(ctx context.Context, client *gh.Client, user string, limit
 	return listPublicUserRepoRefs(ctx, client, user, limit)
 }
 
-func listAuthenticatedUserRepoRefs(ctx context.Context, client *gh.Client, limit int) ([]RepositoryRef, error) {
-	refs := make([]RepositoryRef, 0, min(limit, 100))
-
+func listAuthenticatedUserRepoRefs(ctx context.Context, client *gh.Client, limit int) (*Discovery, error) {
 	opts := &github.RepositoryListByAuthenticatedUserOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
+		ListOptions: github.ListOptions{PerPage: discoveryPageSize},
 		Visibility:  "all",
 		Affiliation: "owner",
 	}
-	for {
-		repos, resp, err := client.Client.Repositories.ListByAuthenticatedUser(ctx, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list authenticated user repos: %w", err)
-		}
-		for _, repo := range repos {
-			if len(refs) >= limit {
-				break
-			}
-			refs = append(refs, RepositoryRef{
-				Owner: repo.GetOwner().GetLogin(),
-				Name:  repo.GetName(),
-				ID:    repo.GetID(),
-				Repo:  repo,
-			})
-		}
-		if len(refs) >= limit {
-			break
-		}
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+	d, err := listRepoRefsPaged(limit, func(page int) ([]*github.Repository, *github.Response, error) {
+		opts.Page = page
+		return client.Client.Repositories.ListByAuthenticatedUser(ctx, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list authenticated user repos: %w", err)
 	}
-
-	return refs, nil
+	return d, nil
 }
 
-func listPublicUserRepoRefs(ctx context.Context, client *gh.Client, user string, limit int) ([]RepositoryRef, error) {
-	refs := make([]RepositoryRef, 0, min(limit, 100))
-
+func listPublicUserRepoRefs(ctx context.Context, client *gh.Client, user string, limit int) (*Discovery, error) {
 	opts := &github.RepositoryListByUserOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
+		ListOptions: github.ListOptions{PerPage: discoveryPageSize},
 		Type:        "all",
 	}
-	for {
-		repos, resp, err := client.Client.Repositories.ListByUser(ctx, user, opts)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list user repos: %w", err)
-		}
-		for _, repo := range repos {
-			if len(refs) >= limit {
-				break
-			}
-			refs = append(refs, RepositoryRef{
-				Owner: repo.GetOwner().GetLogin(),
-				Name:  repo.GetName(),
-				ID:    repo.GetID(),
-				Repo:  repo,
-			})
-		}
-		if len(refs) >= limit {
-			break
-		}
-		if resp.NextPage == 0 {
-			break
-		}
-		opts.Page = resp.NextPage
+	d, err := listRepoRefsPaged(limit, func(page int) ([]*github.Repository, *github.Response, error) {
+		opts.Page = page
+		return client.Client.Repositories.ListByUser(ctx, user, opts)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user repos: %w", err)
 	}
-
-	return refs, nil
+	return d, nil
 }
 
 func filterRefsByRepoSelectors(refs []RepositoryRef, selectors []string) ([]RepositoryRef, error) {
@@ -270,8 +353,43 @@ func filterRefsByRepoSelectors(refs []RepositoryRef, selectors []string) ([]Repo
 	return filtered, nil
 }
 
-func resolveExplicitRepoRefs(ctx context.Context, client *gh.Client, selectors []string) ([]RepositoryRef, error) {
-	refs := make([]RepositoryRef, 0, len(selectors))
+// explicitResolveConcurrency bounds concurrent lookups of explicit repo selectors.
+const explicitResolveConcurrency = 8
+
+// UnresolvedReposError lists every explicit repository that could not be resolved.
+type UnresolvedReposError struct {
+	Repos  []string
+	Errors []error
+}
+
+func (e *UnresolvedReposError) Error() string {
+	var b strings.Builder
+	if len(e.Repos) == 1 {
+		b.WriteString("failed to resolve 1 repository:")
+	} else {
+		fmt.Fprintf(&b, "failed to resolve %d repositories:", len(e.Repos))
+	}
+	for i, repo := range e.Repos {
+		msg := e.Errors[i].Error()
+		if scrubbed := scrubGitHubRequestFromErrorString(msg); scrubbed != "" {
+			msg = scrubbed
+		}
+		fmt.Fprintf(&b, "\n  %s: %s", repo, msg)
+	}
+	return b.String()
+}
+
+func (e *UnresolvedReposError) Unwrap() []error {
+	return e.Errors
+}
+
+// resolveExplicitRepoRefs looks up explicit repo selectors concurrently under
+// budget. Invalid selectors fail before any request is made; lookup failures are
+// collected and reported together as an *UnresolvedReposError.
+func resolveExplicitRepoRefs(ctx context.Context, client *gh.Client, selectors []string, budget *fetcher.RequestBudget) ([]RepositoryRef, error) {
+	type target struct{ sel, owner, name string }
+	targets := make([]target, 0, len(selectors))
+	seen := make(map[string]struct{}, len(selectors))
 
 	for _, raw := range selectors {
 		sel := strings.TrimSpace(raw)
@@ -290,18 +408,53 @@ func resolveExplicitRepoRefs(ctx context.Context, client *gh.Client, selectors [
 		if err != nil {
 			return nil, err
 		}
-		repo, _, err := client.Client.Repositories.Get(ctx, owner, name)
-		if err != nil {
-			return nil, fmt.Errorf("failed to resolve repo %s: %w", sel, err)
+		if _, dup := seen[strings.ToLower(sel)]; dup {
+			continue
 		}
-		refs = append(refs, RepositoryRef{
-			Owner: repo.GetOwner().GetLogin(),
-			Name:  repo.GetName(),
-			ID:    repo.GetID(),
-			Repo:  repo,
-		})
+		seen[strings.ToLower(sel)] = struct{}{}
+		targets = append(targets, target{sel: sel, owner: owner, name: name})
+	}
+
+	repos := make([]*github.Repository, len(targets))
+	errs := make([]error, len(targets))
+	sem := make(chan struct{}, explicitResolveConcurrency)
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := budget.Acquire(ctx, 1); err != nil {
+				errs[i] = err
+				return
+			}
+			repo, resp, err := client.Client.Repositories.Get(ctx, t.owner, t.name)
+			if resp != nil {
+				budget.UpdateFromResponse(resp.Response)
+			}
+			repos[i], errs[i] = repo, err
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
+	refs := make([]RepositoryRef, 0, len(targets))
+	var unresolved UnresolvedReposError
+	for i, t := range targets {
+		if errs[i] != nil {
+			unresolved.Repos = append(unresolved.Repos, t.sel)
+			unresolved.Errors = append(unresolved.Errors, errs[i])
+			continue
+		}
+		refs = append(refs, refFromRepo(repos[i]))
+	}
+	if len(unresolved.Repos) > 0 {
+		return nil, &unresolved
+	}
 	return refs, nil
 }
 
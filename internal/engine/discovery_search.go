@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"repomedic/internal/fetcher"
+	gh "repomedic/internal/github"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v81/github"
+)
+
+// searchResultCap is the maximum number of results the Search API returns for
+// a single query, regardless of pagination.
+const searchResultCap = 1000
+
+// searchEpoch predates the oldest repositories on GitHub; it is the lower
+// bound of the created-date range when a query has to be split.
+var searchEpoch = time.Date(2007, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+// searchNow is the upper bound of the created-date range (a test seam).
+var searchNow = time.Now
+
+var createdQualifierRe = regexp.MustCompile(`(^|\s)-?created:`)
+
+// repoSearch resolves a repository search query. Queries matching more than
+// searchResultCap repositories are split into created-date ranges until every
+// range fits under the cap.
+type repoSearch struct {
+	ctx    context.Context
+	client *gh.Client
+	budget *fetcher.RequestBudget
+	query  string
+	limit  int
+	// hi is the upper bound of the unsplit range; ranges narrower than
+	// [searchEpoch, hi] get an explicit created: qualifier.
+	hi time.Time
+
+	d    *Discovery
+	seen map[int64]struct{}
+}
+
+func searchRepoRefs(ctx context.Context, client *gh.Client, query string, limit int, budget *fetcher.RequestBudget) (*Discovery, error) {
+	s := &repoSearch{
+		ctx:    ctx,
+		client: client,
+		budget: budget,
+		query:  strings.TrimSpace(query),
+		limit:  limit,
+		hi:     searchNow().UTC().Truncate(time.Second),
+		d:      &Discovery{Limit: limit},
+		seen:   make(map[int64]struct{}),
+	}
+
+	// A query with its own created: qualifier cannot be split further.
+	splittable := !createdQualifierRe.MatchString(s.query)
+	total, err := s.collect(s.query, searchEpoch, s.hi, splittable, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search repos: %w", err)
+	}
+
+	s.d.Total = total
+	if len(s.d.Refs) < total {
+		if s.full() {
+			s.d.Truncated = true
+		} else {
+			s.d.SearchCapped = true
+		}
+	}
+	return s.d, nil
+}
+
+// collect gathers all results for q restricted to repositories created within
+// [lo, hi] (unrestricted when the range is the full search range). It returns
+// the total count the API reported for the range.
+//
+// known is the range's count when earlier responses imply it, or -1. Search
+// allows only 30 requests a minute, and the first page of a range that has to
+// be split is thrown away, so a range implied to exceed the cap is split
+// without requesting it.
+func (s *repoSearch) collect(q string, lo, hi time.Time, splittable bool, known int) (int, error) {
+	splittable = splittable && hi.Sub(lo) > time.Second
+	if known > searchResultCap && splittable {
+		return known, s.split(q, lo, hi, known)
+	}
+
+	windowed := q
+	if !lo.Equal(searchEpoch) || !hi.Equal(s.hi) {
+		windowed = fmt.Sprintf("%s created:%s..%s", q, lo.Format(time.RFC3339), hi.Format(time.RFC3339))
+	}
+
+	first, resp, err := s.page(windowed, 1)
+	if err != nil {
+		return 0, err
+	}
+	total := first.GetTotal()
+
+	if total > searchResultCap && splittable {
+		return total, s.split(q, lo, hi, total)
+	}
+
+	// The range fits (or cannot be split further): page through it.
+	repos := first.Repositories
+	for {
+		for _, repo := range repos {
+			if s.full() {
+				return total, nil
+			}
+			if _, dup := s.seen[repo.GetID()]; dup {
+				continue
+			}
+			s.seen[repo.GetID()] = struct{}{}
+			s.d.Refs = append(s.d.Refs, refFromRepo(repo))
+		}
+		if resp == nil || resp.NextPage == 0 || resp.NextPage > searchResultCap/discoveryPageSize {
+			return total, nil
+		}
+		next := resp.NextPage
+		var res *github.RepositoriesSearchResult
+		res, resp, err = s.page(windowed, next)
+		if err != nil {
+			return 0, err
+		}
+		repos = res.Repositories
+	}
+}
+
+// split collects [lo, hi] as two halves. The ranges partition the parent,
+// so the second half's count is implied by total minus the first half's; if
+// the API's counts disagree, the second half is requested after all or split
+// once more than needed, never skipped.
+func (s *repoSearch) split(q string, lo, hi time.Time, total int) error {
+	mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+	left, err := s.collect(q, lo, mid, true, -1)
+	if err != nil {
+		return err
+	}
+	if s.full() {
+		return nil
+	}
+	_, err = s.collect(q, mid.Add(time.Second), hi, true, total-left)
+	return err
+}
+
+func (s *repoSearch) full() bool {
+	return s.limit > 0 && len(s.d.Refs) >= s.limit
+}
+
+func (s *repoSearch) page(q string, page int) (*github.RepositoriesSearchResult, *github.Response, error) {
+	if err := s.budget.Acquire(s.ctx, 1); err != nil {
+		return nil, nil, err
+	}
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: discoveryPageSize, Page: page}}
+	res, resp, err := s.client.Client.Search.Repositories(s.ctx, q, opts)
+	if resp != nil {
+		s.budget.UpdateFromResponse(resp.Response)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return res, resp, nil
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"repomedic/internal/config"
+	gh "repomedic/internal/github"
+	"strings"
+
+	"github.com/google/go-github/v81/github"
+)
+
+// listTeamRepoRefs resolves --team selectors (ORG/TEAM-SLUG) to the
+// repositories each team can access with at least minPermission, optionally
+// walking child teams. A repository reached through several teams appears
+// once, with every such team recorded in RepositoryRef.Teams in discovery
+// order.
+func listTeamRepoRefs(ctx context.Context, client *gh.Client, teams []string, minPermission string, children bool) (*Discovery, error) {
+	d := &Discovery{}
+	byID := make(map[int64]int)
+	visited := make(map[string]struct{})
+
+	var walk func(org, slug string) error
+	walk = func(org, slug string) error {
+		team := org + "/" + slug
+		if _, ok := visited[team]; ok {
+			return nil
+		}
+		visited[team] = struct{}{}
+
+		opts := &github.ListOptions{PerPage: discoveryPageSize}
+		listed, err := listRepoRefsPaged(0, func(page int) ([]*github.Repository, *github.Response, error) {
+			opts.Page = page
+			return client.Client.Teams.ListTeamReposBySlug(ctx, org, slug, opts)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list repos for team %s: %w", team, err)
+		}
+		for _, ref := range listed.Refs {
+			if !config.TeamPermissionSatisfied(ref.Repo.GetPermissions(), minPermission) {
+				continue
+			}
+			if i, ok := byID[ref.ID]; ok {
+				d.Refs[i].Teams = append(d.Refs[i].Teams, team)
+				continue
+			}
+			ref.Teams = []string{team}
+			byID[ref.ID] = len(d.Refs)
+			d.Refs = append(d.Refs, ref)
+		}
+
+		if !children {
+			return nil
+		}
+		childOpts := &github.ListOptions{PerPage: discoveryPageSize}
+		for {
+			kids, resp, err := client.Client.Teams.ListChildTeamsByParentSlug(ctx, org, slug, childOpts)
+			if err != nil {
+				return fmt.Errorf("failed to list child teams of %s: %w", team, err)
+			}
+			for _, kid := range kids {
+				if err := walk(org, strings.ToLower(kid.GetSlug())); err != nil {
+					return err
+				}
+			}
+			if resp == nil || resp.NextPage == 0 {
+				return nil
+			}
+			childOpts.Page = resp.NextPage
+		}
+	}
+
+	for _, t := range teams {
+		org, slug, _ := strings.Cut(t, "/")
+		if err := walk(org, slug); err != nil {
+			return nil, err
+		}
+	}
+	d.Total = len(d.Refs)
+	return d, nil
+}
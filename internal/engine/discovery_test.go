@@ -2,15 +2,21 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"regexp"
 	"repomedic/internal/config"
+	"repomedic/internal/fetcher"
 	gh "repomedic/internal/github"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v81/github"
 )
@@ -236,6 +242,80 @@ func TestResolveRepos(t *testing.T) {
 	})
 }
 
+func TestResolveRepos_ExplicitReposResolveConcurrentlyAndReportAllFailures(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client := newTestGitHubClient(t, server.URL)
+
+	// Every found repo waits until all three lookups are in flight, so a
+	// sequential resolver would time out here.
+	var arrived sync.WaitGroup
+	arrived.Add(3)
+	allIn := make(chan struct{})
+	go func() { arrived.Wait(); close(allIn) }()
+
+	mux.HandleFunc("/repos/acme/", func(w http.ResponseWriter, r *http.Request) {
+		arrived.Done()
+		select {
+		case <-allIn:
+		case <-time.After(5 * time.Second):
+			t.Errorf("lookups were not concurrent")
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/repos/acme/")
+		if strings.HasPrefix(name, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id":%d,"name":%q,"owner":{"login":"acme"}}`, len(name), name)
+	})
+
+	cfg := config.New()
+	cfg.Targeting.Repos = []string{"acme/foo", "acme/missing-a", "https://github.com/acme/missing-b"}
+	_, err := ResolveRepos(context.Background(), client, cfg)
+
+	var unresolved *UnresolvedReposError
+	if !errors.As(err, &unresolved) {
+		t.Fatalf("expected UnresolvedReposError, got %v", err)
+	}
+	if want := []string{"acme/missing-a", "acme/missing-b"}; !reflect.DeepEqual(unresolved.Repos, want) {
+		t.Fatalf("unresolved = %v, want %v", unresolved.Repos, want)
+	}
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "failed to resolve 2 repositories:") || strings.Contains(msg, server.URL) {
+		t.Fatalf("unexpected error message: %q", msg)
+	}
+}
+
+func TestDiscoverReposWithBudget_ExplicitReposUseSharedBudget(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client := newTestGitHubClient(t, server.URL)
+
+	mux.HandleFunc("/repos/acme/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/repos/acme/")
+		fmt.Fprintf(w, `{"id":%d,"name":%q,"owner":{"login":"acme"}}`, len(name), name)
+	})
+
+	cfg := config.New()
+	cfg.Targeting.Repos = []string{"acme/foo", "acme/barbaz"}
+	budget := fetcher.NewRequestBudget()
+	before := budget.Remaining()
+
+	d, err := DiscoverReposWithBudget(context.Background(), client, cfg, budget)
+	if err != nil {
+		t.Fatalf("DiscoverReposWithBudget returned error: %v", err)
+	}
+	if len(d.Refs) != 2 {
+		t.Fatalf("expected 2 repos, got %+v", d.Refs)
+	}
+	if got := before - budget.Remaining(); got != 2 {
+		t.Fatalf("expected 2 requests from the shared budget, got %d", got)
+	}
+}
+
 func TestResolveRepos_GlobRequiresScope(t *testing.T) {
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
@@ -349,15 +429,18 @@ func TestResolveRepos_OrgDiscovery_IsBoundedByMaxRepos(t *testing.T) {
 	}
 }
 
-func TestResolveRepos_OrgDiscovery_IsBoundedByDefaultLimit(t *testing.T) {
+// newPagedOrgReposServer serves totalRepos repos for /orgs/acme/repos at 100 per
+// page and counts requests.
+func newPagedOrgReposServer(t *testing.T, totalRepos int, requestCount *int) *httptest.Server {
+	t.Helper()
+
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
-	defer server.Close()
+	t.Cleanup(server.Close)
 
-	requestCount := 0
-	maxPages := defaultOrgDiscoveryRepoLimit / 100
+	maxPages := (totalRepos + 99) / 100
 	mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
-		requestCount++
+		*requestCount++
 		page := 1
 		if p := r.URL.Query().Get("page"); p != "" {
 			if n, err := strconv.Atoi(p); err == nil {
@@ -373,8 +456,11 @@ func TestResolveRepos_OrgDiscovery_IsBoundedByDefaultLimit(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("["))
 		for i := 0; i < 100; i++ {
-			id := int64((page-1)*100 + i + 1)
-			name := fmt.Sprintf("repo-%04d", id)
+			id := (page-1)*100 + i + 1
+			if id > totalRepos {
+				break
+			}
+			name := fmt.Sprintf("repo-%05d", id)
 			if i > 0 {
 				_, _ = w.Write([]byte(","))
 			}
@@ -382,23 +468,91 @@ func TestResolveRepos_OrgDiscovery_IsBoundedByDefaultLimit(t *testing.T) {
 		}
 		_, _ = w.Write([]byte("]"))
 	})
+	return server
+}
 
+func TestResolveRepos_OrgDiscovery_MaxReposZeroIsUnlimited(t *testing.T) {
+	requestCount := 0
+	server := newPagedOrgReposServer(t, 1234, &requestCount)
 	client := newTestGitHubClient(t, server.URL)
 
 	cfg := config.New()
 	cfg.Targeting.Org = "acme"
 	cfg.Targeting.MaxRepos = 0
 
-	refs, err := ResolveRepos(context.Background(), client, cfg)
+	d, err := DiscoverRepos(context.Background(), client, cfg)
 	if err != nil {
-		t.Fatalf("ResolveRepos returned error: %v", err)
+		t.Fatalf("DiscoverRepos returned error: %v", err)
+	}
+	if len(d.Refs) != 1234 {
+		t.Fatalf("expected all %d repos, got %d", 1234, len(d.Refs))
 	}
-	if len(refs) != defaultOrgDiscoveryRepoLimit {
-		t.Fatalf("expected default limit %d repos, got %d", defaultOrgDiscoveryRepoLimit, len(refs))
+	if d.Truncated {
+		t.Fatalf("expected discovery not to be truncated")
+	}
+	if d.Total != 1234 {
+		t.Fatalf("expected total %d, got %d", 1234, d.Total)
+	}
+	if requestCount != 13 {
+		t.Fatalf("expected 13 requests, got %d", requestCount)
+	}
+}
+
+func TestDiscoverRepos_OrgDiscovery_ReportsTotalWhenTruncated(t *testing.T) {
+	tests := []struct {
+		name         string
+		totalRepos   int
+		maxRepos     int
+		wantRefs     int
+		wantRequests int
+	}{
+		{
+			// Pages 1-2 plus one request for the last page to count the total.
+			name:         "limit reached before last page",
+			totalRepos:   550,
+			maxRepos:     150,
+			wantRefs:     150,
+			wantRequests: 3,
+		},
+		{
+			name:         "limit reached within last page",
+			totalRepos:   250,
+			maxRepos:     220,
+			wantRefs:     220,
+			wantRequests: 3,
+		},
 	}
-	// With per_page=100, default limit 1000 should require 10 pages.
-	if requestCount != maxPages {
-		t.Fatalf("expected %d requests, got %d", maxPages, requestCount)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestCount := 0
+			server := newPagedOrgReposServer(t, tt.totalRepos, &requestCount)
+			client := newTestGitHubClient(t, server.URL)
+
+			cfg := config.New()
+			cfg.Targeting.Org = "acme"
+			cfg.Targeting.MaxRepos = tt.maxRepos
+
+			d, err := DiscoverRepos(context.Background(), client, cfg)
+			if err != nil {
+				t.Fatalf("DiscoverRepos returned error: %v", err)
+			}
+			if len(d.Refs) != tt.wantRefs {
+				t.Fatalf("expected %d repos, got %d", tt.wantRefs, len(d.Refs))
+			}
+			if !d.Truncated {
+				t.Fatalf("expected discovery to be truncated")
+			}
+			if d.Total != tt.totalRepos {
+				t.Fatalf("expected total %d, got %d", tt.totalRepos, d.Total)
+			}
+			if d.Limit != tt.maxRepos {
+				t.Fatalf("expected limit %d, got %d", tt.maxRepos, d.Limit)
+			}
+			if requestCount != tt.wantRequests {
+				t.Fatalf("expected %d requests, got %d", tt.wantRequests, requestCount)
+			}
+		})
 	}
 }
 
@@ -671,3 +825,193 @@ func TestDedupeRefs(t *testing.T) {
 		}
 	})
 }
+
+// newSearchReposServer serves /search/repositories over totalRepos repositories
+// created one per day from 2015-01-01, honouring created:A..B qualifiers and
+// the Search API's 1000-result cap. Each query is appended to queries and,
+// when totals is non-nil, the total count it reported to totals.
+func newSearchReposServer(t *testing.T, totalRepos int, queries *[]string, totals *[]int) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	start := time.Date(2015, 1, 1, 12, 0, 0, 0, time.UTC)
+	createdRe := regexp.MustCompile(`created:(\S+)\.\.(\S+)`)
+	mux.HandleFunc("/search/repositories", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		*queries = append(*queries, q)
+
+		lo, hi := time.Time{}, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+		if m := createdRe.FindStringSubmatch(q); m != nil {
+			lo, _ = time.Parse(time.RFC3339, m[1])
+			hi, _ = time.Parse(time.RFC3339, m[2])
+		}
+		var ids []int
+		for i := 0; i < totalRepos; i++ {
+			created := start.AddDate(0, 0, i)
+			if !created.Before(lo) && !created.After(hi) {
+				ids = append(ids, i+1)
+			}
+		}
+
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			page, _ = strconv.Atoi(p)
+		}
+		visible := min(len(ids), 1000)
+		from, to := (page-1)*100, min(page*100, visible)
+		if from < to && to < visible {
+			w.Header().Set("Link", fmt.Sprintf("<%s/search/repositories?page=%d>; rel=\"next\"", server.URL, page+1))
+		}
+		w.Header().Set("X-RateLimit-Remaining", "29")
+		w.Header().Set("Content-Type", "application/json")
+
+		items := make([]string, 0, 100)
+		for i := from; i < to; i++ {
+			items = append(items, fmt.Sprintf(`{"id":%d,"name":"repo-%05d","full_name":"acme/repo-%05d","owner":{"login":"acme"}}`, ids[i], ids[i], ids[i]))
+		}
+		if totals != nil {
+			*totals = append(*totals, len(ids))
+		}
+		fmt.Fprintf(w, `{"total_count":%d,"incomplete_results":false,"items":[%s]}`, len(ids), strings.Join(items, ","))
+	})
+	return server
+}
+
+func TestDiscoverRepos_Search_SplitsByCreatedDatePastResultCap(t *testing.T) {
+	orig := searchNow
+	searchNow = func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { searchNow = orig })
+
+	var queries []string
+	var totals []int
+	server := newSearchReposServer(t, 3500, &queries, &totals)
+	client := newTestGitHubClient(t, server.URL)
+
+	cfg := config.New()
+	cfg.Targeting.Search = "org:acme language:go"
+
+	d, err := DiscoverRepos(context.Background(), client, cfg)
+	if err != nil {
+		t.Fatalf("DiscoverRepos returned error: %v", err)
+	}
+	if len(d.Refs) != 3500 || d.Total != 3500 {
+		t.Fatalf("expected all 3500 repos (total 3500), got %d (total %d)", len(d.Refs), d.Total)
+	}
+	if d.Truncated || d.SearchCapped {
+		t.Fatalf("expected complete discovery, got truncated=%v capped=%v", d.Truncated, d.SearchCapped)
+	}
+	if queries[0] != "org:acme language:go" {
+		t.Fatalf("expected first query to be unsplit, got %q", queries[0])
+	}
+	for _, q := range queries[1:] {
+		if !strings.HasPrefix(q, "org:acme language:go created:") {
+			t.Fatalf("expected split queries to add a created range, got %q", q)
+		}
+	}
+	// Ranges whose count is implied by their parent's are split without a
+	// request; only a split's first half is fetched before it is known.
+	discarded := 0
+	for _, total := range totals {
+		if total > searchResultCap {
+			discarded++
+		}
+	}
+	if discarded != 2 {
+		t.Fatalf("expected 2 discarded first pages, got %d (totals %v)", discarded, totals)
+	}
+}
+
+func TestDiscoverRepos_Search_MaxReposAndUnsplittableCap(t *testing.T) {
+	t.Run("max repos truncates", func(t *testing.T) {
+		var queries []string
+		server := newSearchReposServer(t, 300, &queries, nil)
+		client := newTestGitHubClient(t, server.URL)
+
+		cfg := config.New()
+		cfg.Targeting.Search = "org:acme"
+		cfg.Targeting.MaxRepos = 150
+
+		d, err := DiscoverRepos(context.Background(), client, cfg)
+		if err != nil {
+			t.Fatalf("DiscoverRepos returned error: %v", err)
+		}
+		if len(d.Refs) != 150 || !d.Truncated || d.Total != 300 {
+			t.Fatalf("expected 150 of 300 repos truncated, got %d (total %d, truncated %v)", len(d.Refs), d.Total, d.Truncated)
+		}
+		if len(queries) != 2 {
+			t.Fatalf("expected 2 requests, got %d", len(queries))
+		}
+	})
+
+	t.Run("query with created qualifier is capped", func(t *testing.T) {
+		var queries []string
+		server := newSearchReposServer(t, 1200, &queries, nil)
+		client := newTestGitHubClient(t, server.URL)
+
+		cfg := config.New()
+		cfg.Targeting.Search = "org:acme created:>2010-01-01"
+
+		d, err := DiscoverRepos(context.Background(), client, cfg)
+		if err != nil {
+			t.Fatalf("DiscoverRepos returned error: %v", err)
+		}
+		if len(d.Refs) != 1000 || !d.SearchCapped || d.Truncated {
+			t.Fatalf("expected 1000 capped repos, got %d (capped %v, truncated %v)", len(d.Refs), d.SearchCapped, d.Truncated)
+		}
+	})
+}
+
+func TestDiscoverRepos_Team_FiltersPermissionAndWalksChildTeams(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client := newTestGitHubClient(t, server.URL)
+
+	repo := func(id int, name, perms string) string {
+		return fmt.Sprintf(`{"id":%d,"name":%q,"full_name":"acme/%s","owner":{"login":"acme"},"permissions":{%s}}`, id, name, name, perms)
+	}
+	mux.HandleFunc("/orgs/acme/teams/platform/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "[%s,%s]", repo(1, "api", `"admin":true,"maintain":true,"push":true`), repo(2, "docs", `"push":true`))
+	})
+	mux.HandleFunc("/orgs/acme/teams/platform/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":11,"slug":"platform-infra"}]`)
+	})
+	mux.HandleFunc("/orgs/acme/teams/platform-infra/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "[%s,%s]", repo(1, "api", `"maintain":true,"push":true`), repo(3, "terraform", `"maintain":true,"push":true`))
+	})
+	mux.HandleFunc("/orgs/acme/teams/platform-infra/teams", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	cfg := config.New()
+	cfg.Targeting.Team = []string{"acme/platform"}
+	cfg.Targeting.TeamPermission = "maintain"
+
+	d, err := DiscoverRepos(context.Background(), client, cfg)
+	if err != nil {
+		t.Fatalf("DiscoverRepos returned error: %v", err)
+	}
+	if len(d.Refs) != 1 || d.Refs[0].Name != "api" {
+		t.Fatalf("expected only acme/api without child teams, got %+v", d.Refs)
+	}
+
+	cfg.Targeting.TeamChildren = true
+	d, err = DiscoverRepos(context.Background(), client, cfg)
+	if err != nil {
+		t.Fatalf("DiscoverRepos returned error: %v", err)
+	}
+	got := make(map[string][]string)
+	for _, r := range d.Refs {
+		got[r.Name] = r.Teams
+	}
+	want := map[string][]string{
+		"api":       {"acme/platform", "acme/platform-infra"},
+		"terraform": {"acme/platform-infra"},
+	}
+	if len(d.Refs) != 2 || !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected teams %v, got %v", want, got)
+	}
+}
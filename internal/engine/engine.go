@@ -2,26 +2,36 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"repomedic/internal/config"
 	"repomedic/internal/data"
+	"repomedic/internal/data/models"
 	"repomedic/internal/fetcher"
+	"repomedic/internal/flags"
 	gh "repomedic/internal/github"
 	"repomedic/internal/output"
 	"repomedic/internal/rules"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v81/github"
 )
 
+// exitCodeInterrupted is returned when the scan was interrupted (SIGINT/SIGTERM)
+// before all repositories were evaluated. Partial results are still written.
+const exitCodeInterrupted = 4
+
 func exitCodeForRun(fatal, partial, wrongs bool) int {
 	// Exit code contract (UI spec):
 	// 0 = clean run, no wrongs
 	// 1 = wrongs detected
 	// 2 = partial failure (some rules/repos errored)
 	// 3 = fatal error (scan did not run)
+	// 4 = interrupted (partial results written; see exitCodeInterrupted)
 	if fatal {
 		return 3
 	}
@@ -34,7 +44,7 @@ func exitCodeForRun(fatal, partial, wrongs bool) int {
 	return 0
 }
 
-func setupOutputManager(cfg *config.Config) (*output.Manager, error) {
+func setupOutputManager(cfg *config.Config, selectedRules []rules.Rule) (*output.Manager, error) {
 	outMgr := output.NewManager()
 
 	// Console Sink
@@ -60,7 +70,20 @@ func setupOutputManager(cfg *config.Config) (*output.Manager, error) {
 
 	// File Sink
 	if cfg.Output.Out != "" {
-		fs, err := output.NewFileSink(cfg.Output.Out, cfg.Output.OutFormat)
+		var fs output.Sink
+		var err error
+		switch cfg.Output.OutFormat {
+		case "sarif":
+			fs, err = output.NewSARIFSink(cfg.Output.Out, selectedRules)
+		case "junit":
+			fs, err = output.NewJUnitSink(cfg.Output.Out)
+		case "csv", "tsv", "csv-matrix", "tsv-matrix":
+			fs, err = output.NewCSVSink(cfg.Output.Out, cfg.Output.OutFormat)
+		case "openmetrics":
+			fs, err = output.NewOpenMetricsSink(cfg.Output.Out)
+		default:
+			fs, err = output.NewFileSink(cfg.Output.Out, cfg.Output.OutFormat)
+		}
 		if err != nil {
 			outMgr.Close()
 			return nil, err
@@ -73,20 +96,87 @@ func setupOutputManager(cfg *config.Config) (*output.Manager, error) {
 
 	// Report Sink
 	if cfg.Output.Report != "" {
-		rs, err := output.NewReportSink(cfg.Output.Report)
+		var rs interface {
+			output.Sink
+			SetGroupBy(string)
+			SetPreviousRun(*output.HistoryRun)
+		}
+		var err error
+		switch {
+		case cfg.Output.ReportTemplate != "":
+			rs, err = output.NewTemplateReportSink(cfg.Output.Report, cfg.Output.ReportTemplate)
+		case output.IsHTMLReportPath(cfg.Output.Report):
+			rs, err = output.NewHTMLReportSink(cfg.Output.Report)
+		default:
+			rs, err = output.NewReportSink(cfg.Output.Report)
+		}
 		if err != nil {
 			outMgr.Close()
 			return nil, err
 		}
+		rs.SetGroupBy(cfg.Output.ReportGroupBy)
+		if cfg.Output.HistoryDir != "" {
+			prev, err := output.LatestHistoryRun(cfg.Output.HistoryDir, historyTarget(cfg))
+			if err != nil {
+				_ = rs.Close()
+				outMgr.Close()
+				return nil, err
+			}
+			rs.SetPreviousRun(prev)
+		}
 		if err := outMgr.AddSink(rs); err != nil {
 			outMgr.Close()
 			return nil, err
 		}
 	}
 
+	// History Sink
+	if cfg.Output.HistoryDir != "" {
+		hs, err := output.NewHistorySink(cfg.Output.HistoryDir, historyTarget(cfg))
+		if err != nil {
+			outMgr.Close()
+			return nil, err
+		}
+		if err := outMgr.AddSink(hs); err != nil {
+			outMgr.Close()
+			return nil, err
+		}
+	}
+
 	return outMgr, nil
 }
 
+// historyTarget keys --history-dir records by what the scan targeted, so
+// trends and "Since Last Run" compare like with like. Repo filters are not
+// part of the key.
+func historyTarget(cfg *config.Config) string {
+	t := cfg.Targeting
+	switch {
+	case t.Org != "":
+		return "org:" + strings.ToLower(t.Org)
+	case t.User != "":
+		return "user:" + strings.ToLower(t.User)
+	case t.Enterprise != "":
+		return "enterprise:" + strings.ToLower(t.Enterprise)
+	case t.Search != "":
+		return "search:" + t.Search
+	case len(t.Team) > 0:
+		teams := make([]string, len(t.Team))
+		for i, team := range t.Team {
+			teams[i] = strings.ToLower(team)
+		}
+		sort.Strings(teams)
+		return "team:" + strings.Join(teams, ",")
+	default:
+		repos := make([]string, len(t.Repos))
+		for i, repo := range t.Repos {
+			repos[i] = strings.ToLower(repo)
+		}
+		sort.Strings(repos)
+		return "repos:" + strings.Join(repos, ",")
+	}
+}
+
 func applyRuleOptionsIfAny(cfg *config.Config) error {
 	// applyRuleOptionsIfAny applies per-rule configuration supplied via repeated
 	// --set flags.
@@ -197,6 +287,10 @@ func ruleResultIfDependenciesMissingOrFailed(dc data.DataContext, deps []data.De
 type Engine struct {
 	Client *gh.Client
 
+	// Pool, when it has more than one member, spreads scan fetches across
+	// several tokens. Discovery always uses Client.
+	Pool *fetcher.ClientPool
+
 	// schedulerExecute is a test seam for streaming execution.
 	// If nil, Engine uses the real fetcher + scheduler.
 	schedulerExecute func(ctx context.Context, cfg *config.Config, plan *ScanPlan) (<-chan RepoExecutionResult, <-chan error)
@@ -208,78 +302,162 @@ func NewEngine(client *gh.Client) *Engine {
 	}
 }
 
-func (e *Engine) executePlanStream(ctx context.Context, cfg *config.Config, plan *ScanPlan) (<-chan RepoExecutionResult, <-chan error) {
+// newFetcher creates the fetcher shared by targeting and the scan, so
+// org-scoped values fetched while filtering are reused by rules.
+func (e *Engine) newFetcher(cfg *config.Config) *fetcher.Fetcher {
+	var f *fetcher.Fetcher
+	if e.Pool != nil && e.Pool.Len() > 1 {
+		f = fetcher.NewPooledFetcher(e.Pool)
+	} else {
+		// TODO: Get rate limit from client or config? For now use default.
+		f = fetcher.NewFetcher(e.Client, fetcher.NewRequestBudget())
+	}
+	f.SetMaxCacheBytes(int64(cfg.Runtime.MaxCacheMB) << 20)
+	return f
+}
+
+// executePlanStream starts streaming execution of plan. The returned stats
+// function reports execution statistics and must only be called after the
+// error channel has been drained.
+func (e *Engine) executePlanStream(ctx context.Context, cfg *config.Config, plan *ScanPlan, f *fetcher.Fetcher) (<-chan RepoExecutionResult, <-chan error, func() *output.RunStats) {
 	if e.schedulerExecute != nil {
-		return e.schedulerExecute(ctx, cfg, plan)
+		resCh, errCh := e.schedulerExecute(ctx, cfg, plan)
+		return resCh, errCh, func() *output.RunStats { return nil }
 	}
 
-	// Initialize Fetcher
-	// TODO: Get rate limit from client or config? For now use default.
-	budget := fetcher.NewRequestBudget()
-	f := fetcher.NewFetcher(e.Client, budget)
-
 	// Inject scanned repos list into fetcher so org-scoped dependencies
 	// (like DepReposScanned) can access it without additional API calls.
 	f.SetScannedRepos(extractReposFromPlan(plan))
 
 	// Initialize Scheduler
 	scheduler, err := NewScheduler(f, cfg.Runtime.Concurrency)
+	if err == nil {
+		switch {
+		case cfg.Runtime.ConcurrencyAuto:
+			// --fetch-concurrency caps the adaptive range when given.
+			maxWorkers := autoConcurrencyMax
+			if cfg.Runtime.FetchConcurrency > 0 {
+				maxWorkers = cfg.Runtime.FetchConcurrency
+			}
+			err = scheduler.EnableAdaptiveConcurrency(maxWorkers)
+		case cfg.Runtime.FetchConcurrency > 0:
+			err = scheduler.SetFetchConcurrency(cfg.Runtime.FetchConcurrency)
+		}
+	}
 	if err != nil {
 		resCh := make(chan RepoExecutionResult)
 		errCh := make(chan error, 1)
 		close(resCh)
 		errCh <- err
 		close(errCh)
-		return resCh, errCh
+		return resCh, errCh, func() *output.RunStats { return nil }
+	}
+	resCh, errCh := scheduler.Execute(ctx, plan)
+	return resCh, errCh, func() *output.RunStats {
+		return &output.RunStats{Concurrency: scheduler.ConcurrencyStats(), API: apiStats(f)}
+	}
+}
+
+// apiStats reports the requests each dependency made and the rate limit left.
+func apiStats(f *fetcher.Fetcher) *output.APIStats {
+	stats := &output.APIStats{RateLimitRemaining: f.BudgetSnapshot().Remaining}
+	if counts := f.RequestCounts(); len(counts) > 0 {
+		stats.Requests = make(map[string]int, len(counts))
+		for key, n := range counts {
+			stats.Requests[string(key)] = n
+		}
+	}
+	return stats
+}
+
+// evaluationSummary aggregates the outcome of streaming evaluation.
+type evaluationSummary struct {
+	hasErrors      bool
+	hasFailures    bool
+	reposEvaluated int
+
+	// newFindings and baselinedFindings count FAIL results missing from and
+	// present in the baseline. Baselined failures do not set hasFailures;
+	// ERROR results are never baselined.
+	newFindings       int
+	baselinedFindings int
+
+	// findings holds every FAIL result when collectFindings is set (for
+	// --update-baseline).
+	collectFindings bool
+	findings        []rules.Result
+}
+
+func (s *evaluationSummary) record(r rules.Result) {
+	switch r.Status {
+	case rules.StatusError:
+		s.hasErrors = true
+	case rules.StatusFail:
+		if s.collectFindings {
+			s.findings = append(s.findings, r)
+		}
+		if r.Baselined {
+			s.baselinedFindings++
+			return
+		}
+		s.newFindings++
+		s.hasFailures = true
 	}
-	return scheduler.Execute(ctx, plan)
 }
 
 // evaluateStreamingResults receives streamed per-repo execution results (fetched dependencies + any fetch errors),
 // validates that each rule's required dependencies are present, executes rule logic, and forwards results/events to
-// the configured output sinks.
-func evaluateStreamingResults(ctx context.Context, cfg *config.Config, plan *ScanPlan, resCh <-chan RepoExecutionResult, outMgr *output.Manager) (hasErrors bool, hasFailures bool) {
+// the configured output sinks. Results matching baseline (which may be nil) are marked baselined.
+func evaluateStreamingResults(ctx context.Context, cfg *config.Config, plan *ScanPlan, resCh <-chan RepoExecutionResult, outMgr *output.Manager, baseline *output.Baseline) evaluationSummary {
+	summary := evaluationSummary{collectFindings: cfg.Output.UpdateBaseline}
+	// teams is the responsible teams of the repo being evaluated.
+	var teams []string
+	emit := func(r rules.Result) {
+		r.Teams = teams
+		r = baseline.Mark(r)
+		summary.record(r)
+		_ = outMgr.Write(r)
+	}
 	for res := range resCh {
 		rp := plan.RepoPlans[res.RepoID]
 		if rp == nil {
-			hasErrors = true
+			summary.hasErrors = true
 			continue
 		}
 
 		repoFullName := fmt.Sprintf("%s/%s", rp.Repo.Owner, rp.Repo.Name)
-		_ = outMgr.Write(output.Event{Type: "repo.started", Repo: repoFullName})
-
 		dc := res.Data
 		if dc == nil {
 			dc = data.NewMapDataContext(map[data.DependencyKey]any{})
 		}
 
+		teams = rp.Repo.Teams
+		started := output.Event{Type: "repo.started", Repo: repoFullName, Teams: teams}
+		if props, err := data.Get(dc, data.RepoCustomProperties); err == nil && len(props) > 0 {
+			started.Properties = props
+		}
+		_ = outMgr.Write(started)
+		evalStart := time.Now()
+
 		for _, rule := range rp.Rules {
 			deps, err := rule.Dependencies(ctx, rp.Repo.Repo)
 			if err != nil {
-				_ = outMgr.Write(rules.Result{
+				emit(rules.Result{
 					Repo:    repoFullName,
 					RuleID:  rule.ID(),
 					Status:  rules.StatusError,
 					Message: fmt.Sprintf("Failed to determine dependencies: %v", err),
 				})
-				hasErrors = true
 				continue
 			}
 
 			if status, msg, ok := ruleResultIfDependenciesMissingOrFailed(dc, deps, res.DepErrs, cfg.Runtime.Verbose); ok {
-				_ = outMgr.Write(rules.Result{
+				emit(rules.Result{
 					Repo:    repoFullName,
 					RuleID:  rule.ID(),
 					Status:  status,
 					Message: msg,
 				})
-				if status == rules.StatusError {
-					hasErrors = true
-				}
-				if status == rules.StatusFail {
-					hasFailures = true
-				}
 				continue
 			}
 
@@ -294,18 +472,16 @@ func evaluateStreamingResults(ctx context.Context, cfg *config.Config, plan *Sca
 				if err != nil {
 					msg = fmt.Sprintf("%s (evaluation error: %v)", msg, err)
 				}
-				_ = outMgr.Write(rules.Result{Repo: repoFullName, RuleID: rule.ID(), Status: rules.StatusError, Message: msg})
-				hasErrors = true
+				emit(rules.Result{Repo: repoFullName, RuleID: rule.ID(), Status: rules.StatusError, Message: msg})
 				continue
 			}
 			if err != nil {
-				_ = outMgr.Write(rules.Result{
+				emit(rules.Result{
 					Repo:    repoFullName,
 					RuleID:  rule.ID(),
 					Status:  rules.StatusError,
 					Message: fmt.Sprintf("Evaluation failed: %v", err),
 				})
-				hasErrors = true
 				continue
 			}
 
@@ -319,20 +495,42 @@ func evaluateStreamingResults(ctx context.Context, cfg *config.Config, plan *Sca
 				ruleRes.RuleID = rule.ID()
 			}
 
-			switch ruleRes.Status {
-			case rules.StatusFail:
-				hasFailures = true
-			case rules.StatusError:
-				hasErrors = true
-			}
+			emit(ruleRes)
+		}
 
-			_ = outMgr.Write(ruleRes)
+		// Drop this repo's fetched values now that every rule has seen them, so
+		// memory stays bounded by in-flight repos rather than the whole scan.
+		if r, ok := dc.(data.Releaser); ok {
+			r.Release()
 		}
 
-		_ = outMgr.Write(output.Event{Type: "repo.finished", Repo: repoFullName})
+		_ = outMgr.Write(output.Event{Type: "repo.finished", Repo: repoFullName, DurationMS: time.Since(evalStart).Milliseconds()})
+		summary.reposEvaluated++
+	}
+
+	return summary
+}
+
+// loadBaseline reads --baseline. With --update-baseline a missing file starts
+// an empty baseline, so the first run can create it.
+func loadBaseline(cfg *config.Config) (*output.Baseline, error) {
+	if cfg.Output.Baseline == "" {
+		return nil, nil
 	}
+	b, err := output.LoadBaseline(cfg.Output.Baseline)
+	if errors.Is(err, fs.ErrNotExist) && cfg.Output.UpdateBaseline {
+		return output.NewBaseline(nil), nil
+	}
+	return b, err
+}
 
-	return hasErrors, hasFailures
+// baselineSummary reports the new/baselined split for lifecycle events, or
+// nil when no baseline is in use.
+func baselineSummary(cfg *config.Config, summary evaluationSummary) *output.BaselineSummary {
+	if cfg.Output.Baseline == "" {
+		return nil
+	}
+	return &output.BaselineSummary{New: summary.newFindings, Baselined: summary.baselinedFindings}
 }
 
 func undeclaredDependencyAccesses(accessed []data.DependencyKey, declared []data.DependencyKey) []string {
@@ -355,26 +553,39 @@ func undeclaredDependencyAccesses(accessed []data.DependencyKey, declared []data
 	return out
 }
 
-// extractReposFromPlan extracts the list of *github.Repository from a ScanPlan.
+// extractReposFromPlan builds the slim scanned-repo projection from a ScanPlan.
 // This is used to inject the scanned repos into the fetcher for org-scoped dependencies.
-func extractReposFromPlan(plan *ScanPlan) []*github.Repository {
+func extractReposFromPlan(plan *ScanPlan) []*models.ScannedRepo {
 	if plan == nil || plan.RepoPlans == nil {
 		return nil
 	}
-	repos := make([]*github.Repository, 0, len(plan.RepoPlans))
+	repos := make([]*models.ScannedRepo, 0, len(plan.RepoPlans))
 	for _, rp := range plan.RepoPlans {
 		if rp.Repo.Repo != nil {
-			repos = append(repos, rp.Repo.Repo)
+			repos = append(repos, scannedRepoFromGitHub(rp.Repo.Repo))
 		}
 	}
 	return repos
 }
 
+func scannedRepoFromGitHub(r *github.Repository) *models.ScannedRepo {
+	return &models.ScannedRepo{
+		ID:               r.GetID(),
+		Owner:            r.GetOwner().GetLogin(),
+		Name:             r.GetName(),
+		FullName:         r.GetFullName(),
+		DefaultBranch:    r.GetDefaultBranch(),
+		AllowMergeCommit: r.AllowMergeCommit,
+		AllowSquashMerge: r.AllowSquashMerge,
+		AllowRebaseMerge: r.AllowRebaseMerge,
+	}
+}
+
 func isExplicitReposOnly(cfg *config.Config) bool {
-	return cfg.Targeting.Org == "" && cfg.Targeting.Enterprise == "" && len(cfg.Targeting.Repos) > 0
+	return cfg.Targeting.Org == "" && cfg.Targeting.Enterprise == "" && cfg.Targeting.Search == "" && len(cfg.Targeting.Team) == 0 && len(cfg.Targeting.Repos) > 0
 }
 
-func (e *Engine) discoverRepos(ctx context.Context, cfg *config.Config, explicitReposOnly bool) ([]RepositoryRef, bool) {
+func (e *Engine) discoverRepos(ctx context.Context, cfg *config.Config, explicitReposOnly bool, budget *fetcher.RequestBudget) ([]RepositoryRef, bool) {
 	if !cfg.Output.NoConsole {
 		if explicitReposOnly {
 			fmt.Fprintln(os.Stderr, "Resolving repositories...")
@@ -382,7 +593,7 @@ func (e *Engine) discoverRepos(ctx context.Context, cfg *config.Config, explicit
 			fmt.Fprintln(os.Stderr, "Discovering repositories...")
 		}
 	}
-	repos, err := ResolveRepos(ctx, e.Client, cfg)
+	d, err := DiscoverReposWithBudget(ctx, e.Client, cfg, budget)
 	if err != nil {
 		if explicitReposOnly {
 			fmt.Fprintf(os.Stderr, "Error resolving repositories: %v\n", err)
@@ -391,7 +602,31 @@ func (e *Engine) discoverRepos(ctx context.Context, cfg *config.Config, explicit
 		}
 		return nil, false
 	}
-	return repos, true
+	reportDiscovery(d, cfg)
+	return d.Refs, true
+}
+
+// reportDiscovery prints the discovered total and warns when a repo limit
+// truncated discovery. The warning is printed even with --no-console because a
+// silently partial scan is worse than a noisy stderr.
+func reportDiscovery(d *Discovery, cfg *config.Config) {
+	if d == nil {
+		return
+	}
+	if d.SearchCapped {
+		fmt.Fprintf(os.Stderr, "Warning: --%s matched %d repositories but only %d could be retrieved (the Search API returns at most %d results per query and the query could not be split by created date).\n", flags.FlagSearch, d.Total, len(d.Refs), searchResultCap)
+	}
+	if !d.Truncated {
+		if !cfg.Output.NoConsole && d.Total > 0 {
+			fmt.Fprintf(os.Stderr, "Discovered %d repositories.\n", d.Total)
+		}
+		return
+	}
+	if d.Total > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: discovery stopped at %d of %d repositories (--%s=%d); %d repositories were not scanned.\n", len(d.Refs), d.Total, flags.FlagMaxRepos, d.Limit, d.Total-len(d.Refs))
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Warning: discovery stopped at %d repositories (--%s=%d); more repositories are available.\n", len(d.Refs), flags.FlagMaxRepos, d.Limit)
 }
 
 func filterReposIfNeeded(repos []RepositoryRef, cfg *config.Config, explicitReposOnly bool) []RepositoryRef {
@@ -410,9 +645,20 @@ func maybeDryRun(cfg *config.Config, repos []RepositoryRef) (int, bool) {
 
 	names := make([]string, 0, len(repos))
 	for _, r := range repos {
-		names = append(names, fmt.Sprintf("%s/%s", r.Owner, r.Name))
+		name := fmt.Sprintf("%s/%s", r.Owner, r.Name)
+		if len(r.Teams) > 0 {
+			name += " (teams: " + strings.Join(r.Teams, ", ") + ")"
+		}
+		names = append(names, name)
 	}
 	sort.Strings(names)
+	// Explicit repo lists bypass filtering, so there is nothing to show for them.
+	if filters := describeFilters(cfg); len(filters) > 0 && !isExplicitReposOnly(cfg) {
+		fmt.Println("Filters:")
+		for _, f := range filters {
+			fmt.Println("  " + f)
+		}
+	}
 	fmt.Println("Resolved repositories:")
 	for _, n := range names {
 		fmt.Println(n)
@@ -451,18 +697,45 @@ func buildPlanForRepos(ctx context.Context, cfg *config.Config, repos []Reposito
 			fmt.Fprintf(os.Stderr, "Error adding repo %s to plan: %v\n", repo.Name, err)
 			return nil, false
 		}
+		// The report groups results by a property value, so fetch it even
+		// when no selected rule needs it.
+		if cfg.Output.ReportGroupBy != "" {
+			plan.RepoPlans[repo.ID].AddDependency(data.DepRepoCustomProperties)
+		}
 	}
 	return plan, true
 }
 
 func (e *Engine) Run(ctx context.Context, cfg *config.Config) int {
+	baseline, err := loadBaseline(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeForRun(true, false, false)
+	}
+
 	explicitReposOnly := isExplicitReposOnly(cfg)
 
-	repos, ok := e.discoverRepos(ctx, cfg, explicitReposOnly)
+	// The fetcher's budget belongs to e.Client, which also resolves --repos.
+	f := e.newFetcher(cfg)
+
+	repos, ok := e.discoverRepos(ctx, cfg, explicitReposOnly, f.Budget())
 	if !ok {
+		if ctx.Err() != nil {
+			fmt.Fprintln(os.Stderr, "Scan interrupted before any repository was evaluated.")
+			return exitCodeInterrupted
+		}
 		return exitCodeForRun(true, false, false)
 	}
 
+	// Property filtering runs before FilterRepos so --max-repos applies to the final set.
+	if !explicitReposOnly && len(cfg.Targeting.Property) > 0 {
+		var err error
+		repos, err = FilterReposByProperty(ctx, f, repos, cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error filtering repositories by custom property: %v\n", err)
+			return exitCodeForRun(true, false, false)
+		}
+	}
 	repos = filterReposIfNeeded(repos, cfg, explicitReposOnly)
 	if !cfg.Output.NoConsole {
 		fmt.Fprintf(os.Stderr, "Found %d repositories.\n", len(repos))
@@ -472,6 +745,11 @@ func (e *Engine) Run(ctx context.Context, cfg *config.Config) int {
 		return code
 	}
 
+	if err := checkPoolVisibility(ctx, e.Pool, repos); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitCodeForRun(true, false, false)
+	}
+
 	selectedRules, ok := resolveAndConfigureRules(cfg)
 	if !ok {
 		return exitCodeForRun(true, false, false)
@@ -482,7 +760,7 @@ func (e *Engine) Run(ctx context.Context, cfg *config.Config) int {
 		return exitCodeForRun(true, false, false)
 	}
 
-	outMgr, err := setupOutputManager(cfg)
+	outMgr, err := setupOutputManager(cfg, selectedRules)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating output sinks: %v\n", err)
 		return exitCodeForRun(true, false, false)
@@ -491,9 +769,27 @@ func (e *Engine) Run(ctx context.Context, cfg *config.Config) int {
 
 	_ = outMgr.Write(output.Event{Type: "run.started", Repos: len(plan.RepoPlans), Rules: len(selectedRules)})
 
-	resCh, errCh := e.executePlanStream(ctx, cfg, plan)
+	// A preflight error only means the context ended; the scan reports that.
+	if impact, err := runPreflight(ctx, f, plan, selectedRules); err == nil && len(impact) > 0 {
+		_ = outMgr.Write(output.Event{Type: output.EventRunPreflight, Preflight: impact})
+		if !cfg.Output.NoConsole {
+			printPreflight(os.Stderr, impact)
+		}
+		if cfg.Runtime.RequireFullCoverage {
+			fmt.Fprintf(os.Stderr, "Error: aborting scan: --%s is set and %d rule(s) lack permissions.\n", flags.FlagRequireCoverage, len(impact))
+			code := exitCodeForRun(true, false, false)
+			_ = outMgr.Write(output.Event{Type: "run.finished", ExitCode: code})
+			return code
+		}
+	}
+
+	// Printed even with --no-console, like the discovery warnings: waivers
+	// that silently stop applying turn into unexplained failures.
+	warnUnreadableCustomProperties(ctx, os.Stderr, f, plan)
 
-	hasErrors, hasFailures := evaluateStreamingResults(ctx, cfg, plan, resCh, outMgr)
+	resCh, errCh, runStats := e.executePlanStream(ctx, cfg, plan, f)
+
+	summary := evaluateStreamingResults(ctx, cfg, plan, resCh, outMgr, baseline)
 
 	var schedErr error
 	// Drain scheduler errors; we only need to know whether any fatal error occurred (keep one non-nil error).
@@ -503,8 +799,37 @@ func (e *Engine) Run(ctx context.Context, cfg *config.Config) int {
 		}
 	}
 
+	// Interruption (SIGINT/SIGTERM or a canceled parent context) is not a fatal
+	// error: results evaluated so far are kept and every sink closes with a
+	// run.interrupted event instead of run.finished.
+	if ctx.Err() != nil && summary.reposEvaluated < len(plan.RepoPlans) {
+		reason := context.Cause(ctx).Error()
+		_ = outMgr.Write(output.Event{
+			Type:      output.EventRunInterrupted,
+			Repos:     len(plan.RepoPlans),
+			Evaluated: summary.reposEvaluated,
+			Reason:    reason,
+			ExitCode:  exitCodeInterrupted,
+			Stats:     runStats(),
+			Baseline:  baselineSummary(cfg, summary),
+		})
+		return exitCodeInterrupted
+	}
+
 	fatal := schedErr != nil
-	code := exitCodeForRun(fatal, hasErrors, hasFailures)
-	_ = outMgr.Write(output.Event{Type: "run.finished", ExitCode: code})
+	// The baseline is only rewritten from a complete scan without ERRORs: a
+	// rule that could not be evaluated would drop its failures from the file.
+	if cfg.Output.UpdateBaseline && !fatal && summary.hasErrors {
+		fmt.Fprintf(os.Stderr, "Warning: baseline not updated: some rules ended in ERROR; fix them and re-run with --%s.\n", flags.FlagUpdateBaseline)
+	} else if cfg.Output.UpdateBaseline && !fatal {
+		if err := output.WriteBaseline(cfg.Output.Baseline, summary.findings); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to update baseline: %v\n", err)
+			fatal = true
+		} else if !cfg.Output.NoConsole {
+			fmt.Fprintf(os.Stderr, "Baseline updated: %d findings written to %s\n", len(summary.findings), cfg.Output.Baseline)
+		}
+	}
+	code := exitCodeForRun(fatal, summary.hasErrors, summary.hasFailures)
+	_ = outMgr.Write(output.Event{Type: "run.finished", ExitCode: code, Stats: runStats(), Baseline: baselineSummary(cfg, summary)})
 	return code
 }
@@ -0,0 +1,171 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"repomedic/internal/config"
+	"repomedic/internal/data"
+	"repomedic/internal/output"
+	"repomedic/internal/rules"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v81/github"
+)
+
+func TestEngine_Run_Interrupted_FlushesPartialResults(t *testing.T) {
+	mux := http.NewServeMux()
+	for id, name := range map[int]string{1: "repo1", 2: "repo2"} {
+		body := fmt.Sprintf(`{"id":%d, "name":%q, "full_name":"acme/%s", "default_branch":"main", "owner":{"login":"acme"}}`, id, name, name)
+		mux.HandleFunc("/repos/acme/"+name, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, body)
+		})
+	}
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	ruleID := "test-interrupt-rule"
+	func() {
+		defer func() { _ = recover() }()
+		rules.Register(&alwaysFailRule{id: ruleID})
+	}()
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "out.ndjson")
+	reportPath := filepath.Join(dir, "report.md")
+
+	cfg := config.New()
+	cfg.Targeting.Repos = []string{"acme/repo1", "acme/repo2"}
+	cfg.Rules.Selector = ruleID
+	cfg.Output.NoConsole = true
+	cfg.Output.Out = outPath
+	cfg.Output.OutFormat = "ndjson"
+	cfg.Output.Report = reportPath
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	eng := NewEngine(newTestGitHubClient(t, server.URL))
+	// Deliver repo1, then interrupt before repo2 completes.
+	eng.schedulerExecute = func(ctx context.Context, cfg *config.Config, plan *ScanPlan) (<-chan RepoExecutionResult, <-chan error) {
+		resCh := make(chan RepoExecutionResult)
+		errCh := make(chan error, 1)
+		go func() {
+			defer close(resCh)
+			defer close(errCh)
+			resCh <- RepoExecutionResult{RepoID: 1, Data: data.NewMapDataContext(nil)}
+			cancel(errors.New("interrupt signal received"))
+			errCh <- ctx.Err()
+		}()
+		return resCh, errCh
+	}
+
+	code := eng.Run(ctx, cfg)
+	if code != exitCodeInterrupted {
+		t.Fatalf("expected exit code %d, got %d", exitCodeInterrupted, code)
+	}
+
+	f, err := os.Open(outPath)
+	if err != nil {
+		t.Fatalf("open ndjson: %v", err)
+	}
+	defer f.Close()
+
+	var events []output.Event
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e output.Event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			t.Fatalf("unmarshal %q: %v", sc.Text(), err)
+		}
+		events = append(events, e)
+	}
+	if len(events) == 0 {
+		t.Fatalf("expected ndjson events")
+	}
+
+	results := 0
+	for _, e := range events {
+		if e.Type == "run.finished" {
+			t.Fatalf("expected no run.finished event for an interrupted run")
+		}
+		if e.Type == "rule.result" {
+			results++
+		}
+	}
+	if results != 1 {
+		t.Fatalf("expected 1 evaluated result to be kept, got %d", results)
+	}
+
+	last := events[len(events)-1]
+	if last.Type != output.EventRunInterrupted {
+		t.Fatalf("expected last event %q, got %q", output.EventRunInterrupted, last.Type)
+	}
+	if last.Evaluated != 1 || last.Repos != 2 {
+		t.Fatalf("expected evaluated=1 repos=2, got evaluated=%d repos=%d", last.Evaluated, last.Repos)
+	}
+	if last.ExitCode != exitCodeInterrupted {
+		t.Fatalf("expected exit_code %d, got %d", exitCodeInterrupted, last.ExitCode)
+	}
+	if last.Reason != "interrupt signal received" {
+		t.Fatalf("unexpected reason %q", last.Reason)
+	}
+
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("read report: %v", err)
+	}
+	if !strings.Contains(string(report), "Partial report") {
+		t.Fatalf("expected partial-report banner, got:\n%s", report)
+	}
+	if !strings.Contains(string(report), "1 of 2 planned repositories") {
+		t.Fatalf("expected coverage in banner, got:\n%s", report)
+	}
+}
+
+func TestEngine_Run_CanceledAfterAllReposEvaluated_IsNotInterrupted(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/repo1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1, "name":"repo1", "full_name":"acme/repo1", "owner":{"login":"acme"}}`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	ruleID := "test-interrupt-rule-complete"
+	func() {
+		defer func() { _ = recover() }()
+		rules.Register(&alwaysFailRule{id: ruleID})
+	}()
+
+	cfg := config.New()
+	cfg.Targeting.Repos = []string{"acme/repo1"}
+	cfg.Rules.Selector = ruleID
+	cfg.Output.NoConsole = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eng := NewEngine(newTestGitHubClient(t, server.URL))
+	eng.schedulerExecute = func(ctx context.Context, cfg *config.Config, plan *ScanPlan) (<-chan RepoExecutionResult, <-chan error) {
+		resCh := make(chan RepoExecutionResult, 1)
+		errCh := make(chan error)
+		resCh <- RepoExecutionResult{RepoID: 1, Data: data.NewMapDataContext(map[data.DependencyKey]any{
+			data.DepRepoMetadata: &github.Repository{},
+		})}
+		close(resCh)
+		cancel()
+		close(errCh)
+		return resCh, errCh
+	}
+
+	if code := eng.Run(ctx, cfg); code != 1 {
+		t.Fatalf("expected exit code 1 (wrongs detected), got %d", code)
+	}
+}
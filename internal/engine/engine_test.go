@@ -16,6 +16,7 @@ import (
 	gh "repomedic/internal/github"
 	"repomedic/internal/output"
 	"repomedic/internal/rules"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -408,6 +409,141 @@ func TestEngine_Run_ExitCodeIs1WhenWrongsDetected(t *testing.T) {
 	}
 }
 
+func TestEngine_Run_BaselineSuppressesKnownFailures(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1, "name":"repo", "full_name":"acme/repo", "default_branch":"main", "owner":{"login":"acme"}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	u, _ := url.Parse(server.URL + "/")
+	client.BaseURL = u
+	ghClient := &gh.Client{Client: client}
+
+	ruleID := "test-baseline-fail-rule"
+	func() {
+		defer func() { _ = recover() }()
+		rules.Register(&alwaysFailRule{id: ruleID})
+	}()
+
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	cfg := &config.Config{
+		Targeting: config.Targeting{Repos: []string{"acme/repo"}},
+		Rules:     config.Rules{Selector: ruleID},
+		Output:    config.Output{NoConsole: true, Baseline: baselinePath, UpdateBaseline: true},
+		Runtime:   config.Runtime{Concurrency: 1},
+	}
+
+	// The first run starts from a missing baseline: the failure is new and is written out.
+	if exitCode := NewEngine(ghClient).Run(context.Background(), cfg); exitCode != 1 {
+		t.Fatalf("expected exit code 1 for a new failure, got %d", exitCode)
+	}
+	if _, err := os.Stat(baselinePath); err != nil {
+		t.Fatalf("expected baseline to be written: %v", err)
+	}
+
+	cfg.Output.UpdateBaseline = false
+	if exitCode := NewEngine(ghClient).Run(context.Background(), cfg); exitCode != 0 {
+		t.Fatalf("expected exit code 0 for a baselined failure, got %d", exitCode)
+	}
+}
+
+type alwaysErrorRule struct {
+	id string
+}
+
+func (r *alwaysErrorRule) ID() string          { return r.id }
+func (r *alwaysErrorRule) Title() string       { return "Always Error Rule" }
+func (r *alwaysErrorRule) Description() string { return "Always errors" }
+func (r *alwaysErrorRule) Dependencies(ctx context.Context, repo *github.Repository) ([]data.DependencyKey, error) {
+	return []data.DependencyKey{data.DepRepoMetadata}, nil
+}
+func (r *alwaysErrorRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
+	return rules.Result{Status: rules.StatusError, Message: "permission denied"}, nil
+}
+
+// newBaselineTestClient serves acme/repo and registers a failing and an
+// erroring rule, returning the client and the rule selector.
+func newBaselineTestClient(t *testing.T) (*gh.Client, string) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1, "name":"repo", "full_name":"acme/repo", "default_branch":"main", "owner":{"login":"acme"}}`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	u, _ := url.Parse(server.URL + "/")
+	client.BaseURL = u
+
+	failID, errorID := "test-baseline-fail-rule", "test-baseline-error-rule"
+	func() {
+		defer func() { _ = recover() }()
+		rules.Register(&alwaysFailRule{id: failID})
+	}()
+	func() {
+		defer func() { _ = recover() }()
+		rules.Register(&alwaysErrorRule{id: errorID})
+	}()
+	return &gh.Client{Client: client}, failID + "," + errorID
+}
+
+func TestEngine_Run_BaselineNeverSuppressesErrors(t *testing.T) {
+	ghClient, selector := newBaselineTestClient(t)
+
+	// A baseline holding both findings, as older versions wrote them.
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	baseline := `[
+		{"rule_id":"test-baseline-fail-rule","repo":"acme/repo","status":"FAIL"},
+		{"rule_id":"test-baseline-error-rule","repo":"acme/repo","status":"ERROR"}
+	]`
+	if err := os.WriteFile(baselinePath, []byte(baseline), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg := &config.Config{
+		Targeting: config.Targeting{Repos: []string{"acme/repo"}},
+		Rules:     config.Rules{Selector: selector},
+		Output:    config.Output{NoConsole: true, Baseline: baselinePath},
+		Runtime:   config.Runtime{Concurrency: 1},
+	}
+	if exitCode := NewEngine(ghClient).Run(context.Background(), cfg); exitCode != 2 {
+		t.Fatalf("expected exit code 2 for an ERROR despite the baseline, got %d", exitCode)
+	}
+}
+
+func TestEngine_Run_UpdateBaselineSkippedWhenRulesError(t *testing.T) {
+	ghClient, selector := newBaselineTestClient(t)
+
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	cfg := &config.Config{
+		Targeting: config.Targeting{Repos: []string{"acme/repo"}},
+		Rules:     config.Rules{Selector: selector},
+		Output:    config.Output{NoConsole: true, Baseline: baselinePath, UpdateBaseline: true},
+		Runtime:   config.Runtime{Concurrency: 1},
+	}
+	if exitCode := NewEngine(ghClient).Run(context.Background(), cfg); exitCode != 2 {
+		t.Fatalf("expected exit code 2, got %d", exitCode)
+	}
+	if _, err := os.Stat(baselinePath); !os.IsNotExist(err) {
+		t.Fatalf("expected no baseline from a run with ERRORs, stat err=%v", err)
+	}
+}
+
+func TestEngine_Run_ExitCodeIs3OnMissingBaseline(t *testing.T) {
+	cfg := &config.Config{
+		Targeting: config.Targeting{Repos: []string{"acme/repo"}},
+		Output:    config.Output{NoConsole: true, Baseline: filepath.Join(t.TempDir(), "missing.json")},
+		Runtime:   config.Runtime{Concurrency: 1},
+	}
+	if exitCode := NewEngine(&gh.Client{Client: github.NewClient(nil)}).Run(context.Background(), cfg); exitCode != 3 {
+		t.Fatalf("expected exit code 3 for a missing baseline, got %d", exitCode)
+	}
+}
+
 func TestEngine_Run_ExitCodeIs3OnFatalDiscoveryError(t *testing.T) {
 	// Mock Server returns 500 for discovery
 	mux := http.NewServeMux()
@@ -1012,7 +1148,7 @@ func TestEvaluateStreamingResults_StreamsPerRepoCompletion(t *testing.T) {
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		evaluateStreamingResults(context.Background(), cfg, plan, resCh, outMgr)
+		evaluateStreamingResults(context.Background(), cfg, plan, resCh, outMgr, nil)
 	}()
 
 	resCh <- RepoExecutionResult{
@@ -1111,7 +1247,7 @@ func TestEvaluateStreamingResults_ErrorsOnUndeclaredDependencyAccess(t *testing.
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		evaluateStreamingResults(context.Background(), cfg, plan, resCh, outMgr)
+		evaluateStreamingResults(context.Background(), cfg, plan, resCh, outMgr, nil)
 	}()
 
 	resCh <- RepoExecutionResult{
@@ -1144,6 +1280,48 @@ func TestEvaluateStreamingResults_ErrorsOnUndeclaredDependencyAccess(t *testing.
 	}
 }
 
+func TestEvaluateStreamingResults_ResultsCarryTeams(t *testing.T) {
+	cfg := config.New()
+	rule := &alwaysFailRule{id: "always-fail"}
+	repo := RepositoryRef{Owner: "acme", Name: "repo", ID: 1, Teams: []string{"acme/platform", "acme/sre"}, Repo: &github.Repository{ID: github.Ptr(int64(1))}}
+	plan := NewScanPlan()
+	plan.RepoPlans[1] = &RepoPlan{Repo: repo, Rules: []rules.Rule{rule}}
+
+	path := filepath.Join(t.TempDir(), "results.json")
+	sink, err := output.NewFileSink(path, "")
+	if err != nil {
+		t.Fatalf("NewFileSink error: %v", err)
+	}
+	outMgr := output.NewManager()
+	if err := outMgr.AddSink(sink); err != nil {
+		t.Fatalf("AddSink error: %v", err)
+	}
+
+	resCh := make(chan RepoExecutionResult, 1)
+	resCh <- RepoExecutionResult{
+		RepoID:  1,
+		Data:    data.NewMapDataContext(map[data.DependencyKey]any{data.DepRepoMetadata: &github.Repository{ID: github.Ptr(int64(1))}}),
+		DepErrs: map[data.DependencyKey]error{},
+	}
+	close(resCh)
+	evaluateStreamingResults(context.Background(), cfg, plan, resCh, outMgr, nil)
+	if err := outMgr.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	var got []rules.Result
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, b)
+	}
+	if len(got) != 1 || !slices.Equal(got[0].Teams, repo.Teams) {
+		t.Fatalf("expected the result to carry teams %v, got %s", repo.Teams, b)
+	}
+}
+
 func TestRuleResultIfDependenciesMissingOrFailed_SingleFailureDropsKeyPrefix(t *testing.T) {
 	dc := data.NewMapDataContext(map[data.DependencyKey]any{})
 
@@ -1187,3 +1365,75 @@ func TestRuleResultIfDependenciesMissingOrFailed_MultipleFailuresIncludesKeyPref
 		t.Fatalf("expected multi-failure message to include key prefixes, got %q", msg)
 	}
 }
+
+func TestHistoryTarget(t *testing.T) {
+	tests := []struct {
+		name      string
+		targeting config.Targeting
+		want      string
+	}{
+		{"org", config.Targeting{Org: "Acme", Topic: []string{"go"}}, "org:acme"},
+		{"user", config.Targeting{User: "octocat"}, "user:octocat"},
+		{"search", config.Targeting{Search: "org:acme language:go"}, "search:org:acme language:go"},
+		{"teams", config.Targeting{Team: []string{"acme/b", "Acme/A"}}, "team:acme/a,acme/b"},
+		{"repos", config.Targeting{Repos: []string{"acme/z", "Acme/A"}}, "repos:acme/a,acme/z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := historyTarget(&config.Config{Targeting: tt.targeting}); got != tt.want {
+				t.Fatalf("historyTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngine_Run_RecordsHistory(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/repo", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1, "name":"repo", "full_name":"acme/repo", "default_branch":"main", "owner":{"login":"acme"}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	u, _ := url.Parse(server.URL + "/")
+	client.BaseURL = u
+	ghClient := &gh.Client{Client: client}
+
+	ruleID := "test-history-fail-rule"
+	func() {
+		defer func() { _ = recover() }()
+		rules.Register(&alwaysFailRule{id: ruleID})
+	}()
+
+	dir := t.TempDir()
+	historyDir := filepath.Join(dir, "history")
+	cfg := &config.Config{
+		Targeting: config.Targeting{Repos: []string{"acme/repo"}},
+		Rules:     config.Rules{Selector: ruleID},
+		Output:    config.Output{NoConsole: true, HistoryDir: historyDir, Report: filepath.Join(dir, "report.md")},
+		Runtime:   config.Runtime{Concurrency: 1},
+	}
+
+	for i := 0; i < 2; i++ {
+		if exitCode := NewEngine(ghClient).Run(context.Background(), cfg); exitCode != 1 {
+			t.Fatalf("run %d: expected exit code 1, got %d", i+1, exitCode)
+		}
+	}
+
+	runs, err := output.LoadHistory(historyDir, "repos:acme/repo")
+	if err != nil {
+		t.Fatalf("LoadHistory failed: %v", err)
+	}
+	if len(runs) != 2 || len(runs[1].Results) != 1 || runs[1].Results[0].Status != rules.StatusFail {
+		t.Fatalf("expected 2 recorded runs with one failure each, got %+v", runs)
+	}
+
+	report, err := os.ReadFile(cfg.Output.Report)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(report), "0 new findings, 0 resolved, 0 changed status, 1 still failing.") {
+		t.Fatalf("expected a Since Last Run section comparing with the first run, got:\n%s", report)
+	}
+}
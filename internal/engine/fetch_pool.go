@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// fetchPool is a fixed-size pool of fetch workers shared by all in-flight repos.
+//
+// Jobs are dispatched by dependency priority (see data.Priority), so P0/P1
+// dependencies are fetched before P2 work across every repo currently being
+// processed. Jobs with equal priority run in submission order, which keeps
+// favoring completion of repos that started first.
+//
+// The pool starts a fixed number of workers but only limit of them run jobs at
+// once; SetLimit lets adaptive concurrency resize the pool while it is running.
+type fetchPool struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   fetchJobQueue
+	seq     uint64
+	limit   int
+	running int
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+type fetchJob struct {
+	priority int
+	seq      uint64
+	run      func()
+}
+
+func newFetchPool(workers int) *fetchPool {
+	p := &fetchPool{limit: workers}
+	p.cond = sync.NewCond(&p.mu)
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Submit enqueues run at the given priority (lower runs first).
+// Submitting after Close is a programming error and panics.
+func (p *fetchPool) Submit(priority int, run func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		panic("fetch pool: submit after close")
+	}
+	p.seq++
+	heap.Push(&p.queue, &fetchJob{priority: priority, seq: p.seq, run: run})
+	p.cond.Signal()
+}
+
+// SetLimit changes how many jobs may run at once (at least 1). Limits above
+// the worker count have no additional effect.
+func (p *fetchPool) SetLimit(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limit = max(n, 1)
+	p.cond.Broadcast()
+}
+
+// Backlog returns the number of queued jobs that have not started yet.
+func (p *fetchPool) Backlog() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.queue)
+}
+
+// Close drains any queued jobs and waits for all workers to exit.
+func (p *fetchPool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+func (p *fetchPool) work() {
+	defer p.wg.Done()
+	for {
+		p.mu.Lock()
+		for !(len(p.queue) > 0 && p.running < p.limit) && !(p.closed && len(p.queue) == 0) {
+			p.cond.Wait()
+		}
+		if len(p.queue) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		job := heap.Pop(&p.queue).(*fetchJob)
+		p.running++
+		p.mu.Unlock()
+
+		job.run()
+
+		p.mu.Lock()
+		p.running--
+		p.cond.Broadcast()
+		p.mu.Unlock()
+	}
+}
+
+// fetchJobQueue implements heap.Interface ordered by (priority, seq).
+type fetchJobQueue []*fetchJob
+
+func (q fetchJobQueue) Len() int { return len(q) }
+
+func (q fetchJobQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q fetchJobQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *fetchJobQueue) Push(x any) { *q = append(*q, x.(*fetchJob)) }
+
+func (q *fetchJobQueue) Pop() any {
+	old := *q
+	n := len(old)
+	job := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return job
+}
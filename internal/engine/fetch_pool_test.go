@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestFetchPool_DispatchesByPriorityThenSubmissionOrder(t *testing.T) {
+	pool := newFetchPool(1)
+
+	// Occupy the only worker so the remaining jobs queue up.
+	started := make(chan struct{})
+	release := make(chan struct{})
+	pool.Submit(0, func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+		}
+	}
+	pool.Submit(2, record("repoA/p2"))
+	pool.Submit(1, record("repoA/p1"))
+	pool.Submit(2, record("repoB/p2"))
+	pool.Submit(0, record("repoB/p0"))
+	pool.Submit(1, record("repoB/p1"))
+
+	close(release)
+	pool.Close()
+
+	want := []string{"repoB/p0", "repoA/p1", "repoB/p1", "repoA/p2", "repoB/p2"}
+	if len(order) != len(want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got %v, want %v", order, want)
+		}
+	}
+}
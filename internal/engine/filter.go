@@ -1,11 +1,17 @@
 package engine
 
 import (
+	"fmt"
 	"path"
 	"repomedic/internal/config"
+	"repomedic/internal/flags"
 	"strings"
+	"time"
 )
 
+// filterNow is the reference time for --pushed-within (a test seam).
+var filterNow = time.Now
+
 func FilterRepos(repos []RepositoryRef, cfg *config.Config) []RepositoryRef {
 	if cfg == nil {
 		panic("engine.FilterRepos: cfg must not be nil")
@@ -26,10 +32,22 @@ func FilterRepos(repos []RepositoryRef, cfg *config.Config) []RepositoryRef {
 		forksPolicy = "exclude"
 	}
 
+	templatePolicy := strings.TrimSpace(cfg.Targeting.IsTemplate)
+	if templatePolicy == "" {
+		templatePolicy = "include"
+	}
+
 	requiredTopics := cfg.Targeting.Topic
 	includePatterns := cfg.Targeting.Include
 	excludePatterns := cfg.Targeting.Exclude
 
+	var pushedAfter time.Time
+	if cfg.Targeting.PushedWithin > 0 {
+		pushedAfter = filterNow().Add(-cfg.Targeting.PushedWithin)
+	}
+	createdBefore := cfg.Targeting.CreatedBefore
+	minSize, maxSize := cfg.Targeting.MinSizeKB, cfg.Targeting.MaxSizeKB
+
 	for _, r := range repos {
 		// Visibility
 		if visibility != "all" {
@@ -56,11 +74,37 @@ func FilterRepos(repos []RepositoryRef, cfg *config.Config) []RepositoryRef {
 			continue
 		}
 
+		// Templates
+		if templatePolicy == "exclude" && r.Repo.GetIsTemplate() {
+			continue
+		}
+		if templatePolicy == "only" && !r.Repo.GetIsTemplate() {
+			continue
+		}
+
 		// Topics
 		if len(requiredTopics) > 0 && !matchesAnyTopic(requiredTopics, r.Repo.Topics) {
 			continue
 		}
 
+		// Primary language
+		if len(cfg.Targeting.Language) > 0 && !matchesAnyLanguage(cfg.Targeting.Language, r.Repo.GetLanguage()) {
+			continue
+		}
+
+		// Activity and age (repos without the timestamp do not match)
+		if !pushedAfter.IsZero() && (r.Repo.PushedAt == nil || r.Repo.GetPushedAt().Before(pushedAfter)) {
+			continue
+		}
+		if !createdBefore.IsZero() && (r.Repo.CreatedAt == nil || !r.Repo.GetCreatedAt().Before(createdBefore)) {
+			continue
+		}
+
+		// Size (KB)
+		if size := int64(r.Repo.GetSize()); (minSize > 0 && size < minSize) || (maxSize > 0 && size > maxSize) {
+			continue
+		}
+
 		// Include/exclude patterns (name matching)
 		fullName := r.Repo.GetFullName()
 		repoName := r.Repo.GetName()
@@ -115,6 +159,15 @@ func matchesAnyTopic(requiredTopics, repoTopics []string) bool {
 	return false
 }
 
+func matchesAnyLanguage(languages []string, repoLanguage string) bool {
+	for _, l := range languages {
+		if strings.EqualFold(strings.TrimSpace(l), repoLanguage) {
+			return true
+		}
+	}
+	return false
+}
+
 func matchesAnyPattern(patterns []string, fullName, repoName string) bool {
 	for _, p := range patterns {
 		if matchPattern(p, fullName, repoName) {
@@ -138,3 +191,65 @@ func matchPattern(pattern, fullName, repoName string) bool {
 	matched, _ := path.Match(pattern, repoName)
 	return matched
 }
+
+// describeFilters lists the active discovery filters as flag=value lines, in
+// flag order, for the dry-run output. Defaults are omitted.
+func describeFilters(cfg *config.Config) []string {
+	var out []string
+	add := func(flag, value string) {
+		out = append(out, fmt.Sprintf("--%s=%s", flag, value))
+	}
+	t := cfg.Targeting
+	if len(t.Include) > 0 {
+		add(flags.FlagInclude, strings.Join(t.Include, ","))
+	}
+	if len(t.Exclude) > 0 {
+		add(flags.FlagExclude, strings.Join(t.Exclude, ","))
+	}
+	if len(t.Topic) > 0 {
+		add(flags.FlagTopic, strings.Join(t.Topic, ","))
+	}
+	if len(t.Language) > 0 {
+		add(flags.FlagLanguage, strings.Join(t.Language, ","))
+	}
+	if t.PushedWithin > 0 {
+		add(flags.FlagPushedWithin, formatAge(t.PushedWithin))
+	}
+	if !t.CreatedBefore.IsZero() {
+		add(flags.FlagCreatedBefore, t.CreatedBefore.Format(time.RFC3339))
+	}
+	if t.MinSizeKB > 0 {
+		add(flags.FlagMinSize, fmt.Sprintf("%dKB", t.MinSizeKB))
+	}
+	if t.MaxSizeKB > 0 {
+		add(flags.FlagMaxSize, fmt.Sprintf("%dKB", t.MaxSizeKB))
+	}
+	if v := strings.TrimSpace(t.IsTemplate); v != "" && v != "include" {
+		add(flags.FlagIsTemplate, v)
+	}
+	for _, p := range t.Property {
+		add(flags.FlagProperty, p)
+	}
+	if v := strings.TrimSpace(t.Visibility); v != "" && v != "all" {
+		add(flags.FlagVisibility, v)
+	}
+	if v := strings.TrimSpace(t.Archived); v != "" && v != "exclude" {
+		add(flags.FlagArchived, v)
+	}
+	if v := strings.TrimSpace(t.Forks); v != "" && v != "exclude" {
+		add(flags.FlagForks, v)
+	}
+	if t.MaxRepos > 0 {
+		add(flags.FlagMaxRepos, fmt.Sprint(t.MaxRepos))
+	}
+	return out
+}
+
+// formatAge renders a duration in whole days when possible ("180d").
+func formatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+	if d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
@@ -1,9 +1,18 @@
 package engine
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"repomedic/internal/config"
+	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v81/github"
 )
@@ -146,3 +155,156 @@ func TestFilterRepos_NilConfigPanics(t *testing.T) {
 
 	_ = FilterRepos(nil, nil)
 }
+
+func TestFilterRepos_MetadataFilters(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	orig := filterNow
+	filterNow = func() time.Time { return now }
+	t.Cleanup(func() { filterNow = orig })
+
+	ts := func(days int) *github.Timestamp { return &github.Timestamp{Time: now.AddDate(0, 0, -days)} }
+	repos := []RepositoryRef{
+		{Repo: &github.Repository{Name: github.Ptr("go-svc"), Language: github.Ptr("Go"), PushedAt: ts(10), CreatedAt: ts(900), Size: github.Ptr(500)}},
+		{Repo: &github.Repository{Name: github.Ptr("py-old"), Language: github.Ptr("Python"), PushedAt: ts(400), CreatedAt: ts(2000), Size: github.Ptr(50_000)}},
+		{Repo: &github.Repository{Name: github.Ptr("template"), Language: github.Ptr("Go"), PushedAt: ts(30), CreatedAt: ts(100), Size: github.Ptr(20), IsTemplate: github.Ptr(true)}},
+		{Repo: &github.Repository{Name: github.Ptr("empty")}},
+	}
+
+	tests := []struct {
+		name     string
+		apply    func(c *config.Config)
+		expected []string
+	}{
+		{name: "language is case-insensitive", apply: func(c *config.Config) { c.Targeting.Language = []string{"go"} }, expected: []string{"go-svc", "template"}},
+		{name: "pushed within", apply: func(c *config.Config) { c.Targeting.PushedWithin = 180 * 24 * time.Hour }, expected: []string{"go-svc", "template"}},
+		{name: "created before", apply: func(c *config.Config) { c.Targeting.CreatedBefore = now.AddDate(-2, 0, 0) }, expected: []string{"go-svc", "py-old"}},
+		{name: "min size", apply: func(c *config.Config) { c.Targeting.MinSizeKB = 100 }, expected: []string{"go-svc", "py-old"}},
+		{name: "max size", apply: func(c *config.Config) { c.Targeting.MaxSizeKB = 1000 }, expected: []string{"go-svc", "template", "empty"}},
+		{name: "exclude templates", apply: func(c *config.Config) { c.Targeting.IsTemplate = "exclude" }, expected: []string{"go-svc", "py-old", "empty"}},
+		{name: "only templates", apply: func(c *config.Config) { c.Targeting.IsTemplate = "only" }, expected: []string{"template"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.New()
+			tt.apply(cfg)
+			var got []string
+			for _, r := range FilterRepos(repos, cfg) {
+				got = append(got, r.Repo.GetName())
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Fatalf("Expected repos %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFilterReposByProperty_ListsValuesOncePerOrg(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client := newTestGitHubClient(t, server.URL)
+
+	calls := 0
+	mux.HandleFunc("/orgs/acme/properties/values", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `[
+			{"repository_id":1,"repository_name":"a","repository_full_name":"acme/a","properties":[{"property_name":"tier","value":"critical"}]},
+			{"repository_id":2,"repository_name":"b","repository_full_name":"acme/b","properties":[{"property_name":"tier","value":"low"}]},
+			{"repository_id":3,"repository_name":"c","repository_full_name":"acme/c","properties":[{"property_name":"tier","value":["low","pci"]}]}
+		]`)
+	})
+	mux.HandleFunc("/orgs/someone/properties/values", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("user accounts have no custom properties to list")
+	})
+
+	repos := []RepositoryRef{
+		{Owner: "acme", Name: "a", Repo: &github.Repository{ID: github.Ptr(int64(1)), Name: github.Ptr("a")}},
+		{Owner: "acme", Name: "b", Repo: &github.Repository{ID: github.Ptr(int64(2)), Name: github.Ptr("b")}},
+		{Owner: "acme", Name: "c", Repo: &github.Repository{ID: github.Ptr(int64(3)), Name: github.Ptr("c")}},
+		{Owner: "acme", Name: "d", Repo: &github.Repository{ID: github.Ptr(int64(4)), Name: github.Ptr("d"), CustomProperties: map[string]any{"tier": "critical"}}},
+		{Owner: "someone", Name: "e", Repo: &github.Repository{ID: github.Ptr(int64(5)), Name: github.Ptr("e"), Owner: &github.User{Login: github.Ptr("someone"), Type: github.Ptr("User")}}},
+	}
+
+	cfg := config.New()
+	cfg.Targeting.Property = []string{"tier=critical", "tier=pci"}
+	filtered, err := FilterReposByProperty(context.Background(), fetcher.NewFetcher(client, fetcher.NewRequestBudget()), repos, cfg)
+	if err != nil {
+		t.Fatalf("FilterReposByProperty returned error: %v", err)
+	}
+	var got []string
+	for _, r := range filtered {
+		got = append(got, r.Name)
+	}
+	if want := []string{"a", "c", "d"}; !slices.Equal(got, want) {
+		t.Fatalf("Expected repos %v, got %v", want, got)
+	}
+	if calls != 1 {
+		t.Fatalf("expected property values to be listed once for acme, got %d calls", calls)
+	}
+}
+
+func TestFilterReposByProperty_ErrorsWhenValuesAreUnreadable(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client := newTestGitHubClient(t, server.URL)
+
+	mux.HandleFunc("/orgs/acme/properties/values", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"Resource not accessible by personal access token"}`)
+	})
+
+	repos := []RepositoryRef{{Owner: "acme", Name: "a", Repo: &github.Repository{ID: github.Ptr(int64(1)), Name: github.Ptr("a")}}}
+	cfg := config.New()
+	cfg.Targeting.Property = []string{"tier=critical"}
+	_, err := FilterReposByProperty(context.Background(), fetcher.NewFetcher(client, fetcher.NewRequestBudget()), repos, cfg)
+	if err == nil || !strings.Contains(err.Error(), "organization_custom_properties:read") || !strings.Contains(err.Error(), "acme") {
+		t.Fatalf("expected an error naming the missing permission, got %v", err)
+	}
+}
+
+func TestWarnUnreadableCustomProperties(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	client := newTestGitHubClient(t, server.URL)
+
+	mux.HandleFunc("/orgs/acme/properties/values", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/orgs/beta/properties/values", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+
+	plan := NewScanPlan()
+	for i, owner := range []string{"acme", "beta"} {
+		ref := RepositoryRef{Owner: owner, Name: "a", Repo: &github.Repository{ID: github.Ptr(int64(i + 1)), Name: github.Ptr("a")}}
+		plan.RepoPlans[int64(i+1)] = &RepoPlan{Repo: ref, Dependencies: map[data.DependencyKey]data.DependencyRequest{
+			data.DepRepoCustomProperties: {Key: data.DepRepoCustomProperties},
+		}}
+	}
+
+	var buf bytes.Buffer
+	warnUnreadableCustomProperties(context.Background(), &buf, fetcher.NewFetcher(client, fetcher.NewRequestBudget()), plan)
+	got := buf.String()
+	if !strings.HasPrefix(got, "Warning: cannot read custom property values in acme (") || !strings.Contains(got, "allow.properties") {
+		t.Fatalf("unexpected warning: %q", got)
+	}
+}
+
+func TestDescribeFilters(t *testing.T) {
+	cfg := config.New()
+	if got := describeFilters(cfg); len(got) != 0 {
+		t.Fatalf("expected no filters for defaults, got %v", got)
+	}
+
+	cfg.Targeting.Language = []string{"go", "python"}
+	cfg.Targeting.PushedWithin = 180 * 24 * time.Hour
+	cfg.Targeting.IsTemplate = "exclude"
+	cfg.Targeting.Property = []string{"tier=critical"}
+	want := []string{"--language=go,python", "--pushed-within=180d", "--is-template=exclude", "--property=tier=critical"}
+	if got := describeFilters(cfg); !slices.Equal(got, want) {
+		t.Fatalf("describeFilters() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/go-github/v81/github"
+
+	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
+	gh "repomedic/internal/github"
+	"repomedic/internal/rules"
+)
+
+// Grant is whether a token holds a fetcher.Permission, as far as can be told
+// without calling the endpoints that need it.
+type Grant int
+
+const (
+	GrantUnknown Grant = iota
+	Granted
+	NotGranted
+)
+
+// OutcomeDegraded marks rules that still run but on reduced data because a
+// fetcher tolerates a missing permission.
+const OutcomeDegraded = "DEGRADED"
+
+// outcomeSeverity orders impact outcomes from mildest to worst.
+var outcomeSeverity = map[string]int{OutcomeDegraded: 0, string(rules.StatusSkipped): 1, string(rules.StatusError): 2}
+
+// MissingPermission is a permission a dependency key needs that the token
+// does not (or may not) hold.
+type MissingPermission struct {
+	Key        data.DependencyKey `json:"key"`
+	Permission fetcher.Permission `json:"permission"`
+	// Verified is false when the grant could not be determined.
+	Verified bool `json:"verified"`
+}
+
+// RuleImpact describes how missing permissions affect a rule.
+type RuleImpact struct {
+	RuleID string `json:"rule_id"`
+	// Outcome is SKIPPED or ERROR when a dependency fetch would fail, or
+	// DEGRADED when fetchers tolerate the gap with reduced data.
+	Outcome string              `json:"outcome"`
+	Missing []MissingPermission `json:"missing"`
+}
+
+// Verified reports whether every missing permission was confirmed missing.
+func (ri RuleImpact) Verified() bool {
+	for _, m := range ri.Missing {
+		if !m.Verified {
+			return false
+		}
+	}
+	return true
+}
+
+// PermissionImpact lists the rules affected by permissions check does not
+// report as Granted, based on the permissions the fetchers of each rule's
+// dependencies (and their upstream keys) declare. Rules are sorted by ID.
+func PermissionImpact(ctx context.Context, ruleList []rules.Rule, check func(data.DependencyKey, fetcher.Permission) Grant) ([]RuleImpact, error) {
+	var out []RuleImpact
+	for _, r := range ruleList {
+		// Rule dependencies are static in practice; an empty repo is enough to list them.
+		deps, err := r.Dependencies(ctx, &github.Repository{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies for rule %s: %w", r.ID(), err)
+		}
+
+		impact := RuleImpact{RuleID: r.ID()}
+		for _, key := range planKeys(deps) {
+			for _, p := range fetcher.Permissions(key) {
+				g := check(key, p)
+				if g == Granted {
+					continue
+				}
+				impact.Missing = append(impact.Missing, MissingPermission{Key: key, Permission: p, Verified: g == NotGranted})
+				if o := missingPermissionOutcome(key, p); impact.Outcome == "" || outcomeSeverity[o] > outcomeSeverity[impact.Outcome] {
+					impact.Outcome = o
+				}
+			}
+		}
+		if len(impact.Missing) > 0 {
+			out = append(out, impact)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RuleID < out[j].RuleID })
+	return out, nil
+}
+
+// planKeys returns deps and their transitive upstream keys, deduplicated and
+// sorted, mirroring what ScanPlan.AddRepo schedules.
+func planKeys(deps []data.DependencyKey) []data.DependencyKey {
+	rp := &RepoPlan{Dependencies: make(map[data.DependencyKey]data.DependencyRequest)}
+	for _, d := range deps {
+		rp.AddDependency(d)
+	}
+	keys := make([]data.DependencyKey, 0, len(rp.Dependencies))
+	for k := range rp.Dependencies {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func missingPermissionOutcome(key data.DependencyKey, p fetcher.Permission) string {
+	switch {
+	case p.Degrades:
+		return OutcomeDegraded
+	case isSkippableForbidden(key):
+		return string(rules.StatusSkipped)
+	default:
+		return string(rules.StatusError)
+	}
+}
+
+// TokenPermissionCheck returns a Grant check for a token of kind with the
+// given OAuth scopes (nil when unknown). Scopes are checked exactly; repository
+// roles and fine-grained permissions cannot be read from the token and are
+// GrantUnknown.
+func TokenPermissionCheck(kind gh.TokenKind, scopes []string) func(data.DependencyKey, fetcher.Permission) Grant {
+	return func(_ data.DependencyKey, p fetcher.Permission) Grant {
+		if kind.HasScopes() && scopes != nil {
+			if !gh.ScopesGrant(scopes, p.Scope) {
+				return NotGranted
+			}
+			if p.Role != "" {
+				return GrantUnknown
+			}
+			return Granted
+		}
+		if p.FineGrained != "" || p.Role != "" {
+			return GrantUnknown
+		}
+		return Granted
+	}
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"repomedic/internal/data"
+	gh "repomedic/internal/github"
+	"repomedic/internal/rules"
+)
+
+func TestPermissionImpact_ClassicTokenWithRepoScope(t *testing.T) {
+	ruleList := []rules.Rule{
+		&mockRule{id: "needs-protection", deps: []data.DependencyKey{data.DepRepoDefaultBranchClassicProtection}},
+		&mockRule{id: "needs-baseline", deps: []data.DependencyKey{data.DepOrgMergeBaseline}},
+		&mockRule{id: "needs-metadata", deps: []data.DependencyKey{data.DepRepoMetadata}},
+	}
+
+	impact, err := PermissionImpact(context.Background(), ruleList, TokenPermissionCheck(gh.TokenKindClassic, []string{"repo"}))
+	if err != nil {
+		t.Fatalf("PermissionImpact: %v", err)
+	}
+	if len(impact) != 2 {
+		t.Fatalf("expected 2 impacted rules, got %+v", impact)
+	}
+
+	// Sorted by rule ID.
+	baseline, protection := impact[0], impact[1]
+	if baseline.RuleID != "needs-baseline" || baseline.Outcome != OutcomeDegraded || !baseline.Verified() {
+		t.Fatalf("unexpected baseline impact: %+v", baseline)
+	}
+	if baseline.Missing[0].Permission.Scope != "admin:org" {
+		t.Fatalf("expected admin:org to be missing, got %+v", baseline.Missing)
+	}
+	// "repo" grants the scope, but the admin role cannot be confirmed from the token.
+	if protection.RuleID != "needs-protection" || protection.Outcome != string(rules.StatusSkipped) || protection.Verified() {
+		t.Fatalf("unexpected protection impact: %+v", protection)
+	}
+}
+
+func TestPermissionImpact_ImpliedScopeGrants(t *testing.T) {
+	ruleList := []rules.Rule{
+		&mockRule{id: "needs-baseline", deps: []data.DependencyKey{data.DepOrgMergeBaseline}},
+	}
+	impact, err := PermissionImpact(context.Background(), ruleList, TokenPermissionCheck(gh.TokenKindClassic, []string{"repo", "admin:org"}))
+	if err != nil {
+		t.Fatalf("PermissionImpact: %v", err)
+	}
+	if len(impact) != 0 {
+		t.Fatalf("expected no impact, got %+v", impact)
+	}
+}
+
+func TestPermissionImpact_FineGrainedIsUnverified(t *testing.T) {
+	ruleList := []rules.Rule{
+		&mockRule{id: "needs-baseline", deps: []data.DependencyKey{data.DepOrgMergeBaseline}},
+	}
+	impact, err := PermissionImpact(context.Background(), ruleList, TokenPermissionCheck(gh.TokenKindFineGrained, nil))
+	if err != nil {
+		t.Fatalf("PermissionImpact: %v", err)
+	}
+	if len(impact) != 1 || impact[0].Verified() {
+		t.Fatalf("expected one unverified impact, got %+v", impact)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
 	"repomedic/internal/rules"
 	"sort"
 )
@@ -51,11 +52,7 @@ func (p *ScanPlan) AddRepo(ctx context.Context, repo RepositoryRef, selectedRule
 		}
 
 		for _, d := range deps {
-			// Simple deduplication by key.
-			// TODO: Handle params merging if needed.
-			if _, exists := rp.Dependencies[d]; !exists {
-				rp.Dependencies[d] = data.DependencyRequest{Key: d}
-			}
+			rp.AddDependency(d)
 		}
 	}
 
@@ -63,21 +60,73 @@ func (p *ScanPlan) AddRepo(ctx context.Context, repo RepositoryRef, selectedRule
 	return nil
 }
 
+// AddDependency plans key for this repo along with the upstream keys its
+// fetcher declares, so they are prioritized ahead of the keys that need them.
+func (rp *RepoPlan) AddDependency(key data.DependencyKey) {
+	// Simple deduplication by key.
+	// TODO: Handle params merging if needed.
+	for _, k := range append([]data.DependencyKey{key}, fetcher.TransitiveUpstream(key)...) {
+		if _, exists := rp.Dependencies[k]; !exists {
+			rp.Dependencies[k] = data.DependencyRequest{Key: k}
+		}
+	}
+}
+
 // SortedDependencies returns the list of dependency keys sorted by priority (P0 first).
+// Within a priority, upstream keys come before the keys that depend on them.
 func (rp *RepoPlan) SortedDependencies() []data.DependencyKey {
 	keys := make([]data.DependencyKey, 0, len(rp.Dependencies))
 	for k := range rp.Dependencies {
 		keys = append(keys, k)
 	}
 
+	priority := make(map[data.DependencyKey]int, len(keys))
+	depth := make(map[data.DependencyKey]int, len(keys))
+	for _, k := range keys {
+		priority[k] = rp.DependencyPriority(k)
+		depth[k] = upstreamDepth(k)
+	}
+
 	sort.Slice(keys, func(i, j int) bool {
-		p1 := data.Priority(keys[i])
-		p2 := data.Priority(keys[j])
+		p1 := priority[keys[i]]
+		p2 := priority[keys[j]]
 		if p1 != p2 {
 			return p1 < p2
 		}
+		if depth[keys[i]] != depth[keys[j]] {
+			return depth[keys[i]] < depth[keys[j]]
+		}
 		return keys[i] < keys[j] // Stable sort for same priority
 	})
 
 	return keys
 }
+
+// DependencyPriority returns the scheduling priority of key in this plan:
+// data.Priority(key), raised to the priority of any planned key that
+// (transitively) depends on it, so upstream keys never wait behind their
+// dependents.
+func (rp *RepoPlan) DependencyPriority(key data.DependencyKey) int {
+	p := data.Priority(key)
+	for k := range rp.Dependencies {
+		if k == key || data.Priority(k) >= p {
+			continue
+		}
+		for _, up := range fetcher.TransitiveUpstream(k) {
+			if up == key {
+				p = data.Priority(k)
+				break
+			}
+		}
+	}
+	return p
+}
+
+// upstreamDepth is the length of the longest declared upstream chain below key.
+func upstreamDepth(key data.DependencyKey) int {
+	depth := 0
+	for _, up := range fetcher.UpstreamKeys(key) {
+		depth = max(depth, upstreamDepth(up)+1)
+	}
+	return depth
+}
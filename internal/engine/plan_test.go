@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"repomedic/internal/config"
 	"repomedic/internal/data"
 	"repomedic/internal/rules"
 	"testing"
@@ -110,3 +111,50 @@ func TestScanPlan_AddRepo_FailsFastOnNilInputs(t *testing.T) {
 		}
 	})
 }
+
+func TestScanPlan_AddRepo_IncludesTransitiveUpstreamAndPrioritizesIt(t *testing.T) {
+	// DepRepoEffectiveMergeMethods (P2) declares DepRepoMetadata (P0) and
+	// DepRepoAllRulesets (P1) upstream.
+	r := &mockRule{id: "merge", deps: []data.DependencyKey{data.DepRepoEffectiveMergeMethods}}
+	repo := RepositoryRef{ID: 1, Name: "repo", Repo: &github.Repository{ID: github.Ptr(int64(1))}}
+
+	plan := NewScanPlan()
+	if err := plan.AddRepo(context.Background(), repo, []rules.Rule{r}); err != nil {
+		t.Fatalf("AddRepo failed: %v", err)
+	}
+	rp := plan.RepoPlans[1]
+
+	for _, k := range []data.DependencyKey{data.DepRepoEffectiveMergeMethods, data.DepRepoMetadata, data.DepRepoAllRulesets} {
+		if _, ok := rp.Dependencies[k]; !ok {
+			t.Fatalf("expected %s in plan, got %v", k, rp.Dependencies)
+		}
+	}
+
+	got := rp.SortedDependencies()
+	want := []data.DependencyKey{data.DepRepoMetadata, data.DepRepoAllRulesets, data.DepRepoEffectiveMergeMethods}
+	if len(got) != len(want) {
+		t.Fatalf("SortedDependencies() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SortedDependencies() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestBuildPlanForRepos_ReportGroupByPlansCustomProperties(t *testing.T) {
+	cfg := config.New()
+	cfg.Output.NoConsole = true
+	cfg.Output.ReportGroupBy = "tier"
+	repo := RepositoryRef{ID: 1, Owner: "acme", Name: "repo", Repo: &github.Repository{ID: github.Ptr(int64(1))}}
+
+	plan, ok := buildPlanForRepos(context.Background(), cfg, []RepositoryRef{repo}, []rules.Rule{&mockRule{id: "r1"}})
+	if !ok {
+		t.Fatal("buildPlanForRepos failed")
+	}
+	for _, k := range []data.DependencyKey{data.DepRepoCustomProperties, data.DepOrgCustomProperties} {
+		if _, ok := plan.RepoPlans[1].Dependencies[k]; !ok {
+			t.Fatalf("expected %s in plan, got %v", k, plan.RepoPlans[1].Dependencies)
+		}
+	}
+}
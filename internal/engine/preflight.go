@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v81/github"
+
+	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
+	"repomedic/internal/output"
+	"repomedic/internal/rules"
+)
+
+// preflightConcurrency bounds the owners probed at once.
+const preflightConcurrency = 8
+
+// preflightProbe is one repository per owner, standing in for the owner's
+// other repositories.
+type preflightProbe struct {
+	owner string
+	plan  *RepoPlan
+}
+
+// preflightProbes picks the first repository (by name) of each owner in plan.
+func preflightProbes(plan *ScanPlan) []preflightProbe {
+	byOwner := make(map[string]*RepoPlan)
+	for _, rp := range plan.RepoPlans {
+		owner := strings.ToLower(rp.Repo.Owner)
+		if cur, ok := byOwner[owner]; !ok || rp.Repo.Name < cur.Repo.Name {
+			byOwner[owner] = rp
+		}
+	}
+	probes := make([]preflightProbe, 0, len(byOwner))
+	for owner, rp := range byOwner {
+		probes = append(probes, preflightProbe{owner: owner, plan: rp})
+	}
+	sort.Slice(probes, func(i, j int) bool { return probes[i].owner < probes[j].owner })
+	return probes
+}
+
+// isPermissionDenied reports whether err is a 403 or 404 from the GitHub API,
+// which is how missing permissions surface.
+func isPermissionDenied(err error) bool {
+	var er *github.ErrorResponse
+	if !errors.As(err, &er) || er.Response == nil {
+		return false
+	}
+	return er.Response.StatusCode == http.StatusForbidden || er.Response.StatusCode == http.StatusNotFound
+}
+
+// runPreflight fetches every planned dependency whose fetcher declares a
+// permission that cannot be tolerated, for one repository per owner. Fetches
+// go through f, so successful results are cached for the scan itself.
+//
+// It returns the selected rules expected to be SKIPPED or ERROR, with the
+// owners whose probe was denied.
+func runPreflight(ctx context.Context, f *fetcher.Fetcher, plan *ScanPlan, selectedRules []rules.Rule) ([]output.PreflightRule, error) {
+	probes := preflightProbes(plan)
+
+	// denied[i] holds the keys denied for probes[i].
+	denied := make([]map[data.DependencyKey]bool, len(probes))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, preflightConcurrency)
+	for i, p := range probes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			denied[i] = probeDeniedKeys(ctx, f, p.plan)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	byRule := make(map[string]*output.PreflightRule)
+	for i, p := range probes {
+		if len(denied[i]) == 0 {
+			continue
+		}
+		check := func(key data.DependencyKey, _ fetcher.Permission) Grant {
+			if denied[i][key] {
+				return NotGranted
+			}
+			return Granted
+		}
+		impact, err := PermissionImpact(ctx, selectedRules, check)
+		if err != nil {
+			return nil, err
+		}
+		for _, ri := range impact {
+			pr, ok := byRule[ri.RuleID]
+			if !ok {
+				pr = &output.PreflightRule{RuleID: ri.RuleID, Outcome: ri.Outcome}
+				byRule[ri.RuleID] = pr
+			}
+			if outcomeSeverity[ri.Outcome] > outcomeSeverity[pr.Outcome] {
+				pr.Outcome = ri.Outcome
+			}
+			pr.Owners = append(pr.Owners, p.owner)
+			for _, m := range ri.Missing {
+				desc := fmt.Sprintf("%s (%s)", m.Key, m.Permission)
+				if !slices.Contains(pr.Missing, desc) {
+					pr.Missing = append(pr.Missing, desc)
+				}
+			}
+		}
+	}
+
+	out := make([]output.PreflightRule, 0, len(byRule))
+	for _, pr := range byRule {
+		sort.Strings(pr.Missing)
+		out = append(out, *pr)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].RuleID < out[j].RuleID })
+	return out, nil
+}
+
+// probeDeniedKeys fetches the planned keys of rp that need a permission and
+// returns those the API denied. Degrading fetchers are not probed: they
+// never fail for lack of permission.
+func probeDeniedKeys(ctx context.Context, f *fetcher.Fetcher, rp *RepoPlan) map[data.DependencyKey]bool {
+	denied := make(map[data.DependencyKey]bool)
+	for _, key := range rp.SortedDependencies() {
+		if !needsProbe(fetcher.Permissions(key)) {
+			continue
+		}
+		if _, err := f.Fetch(ctx, rp.Repo.Repo, key, rp.Dependencies[key].Params); err != nil && isPermissionDenied(err) {
+			denied[key] = true
+		}
+	}
+	return denied
+}
+
+func needsProbe(perms []fetcher.Permission) bool {
+	for _, p := range perms {
+		if !p.Degrades {
+			return true
+		}
+	}
+	return false
+}
+
+// printPreflight writes the preflight findings for the console.
+func printPreflight(w io.Writer, impact []output.PreflightRule) {
+	fmt.Fprintf(w, "Preflight: %d rule(s) will be SKIPPED or ERROR for lack of permissions:\n", len(impact))
+	for _, pr := range impact {
+		fmt.Fprintf(w, "  %-8s %s (owners: %s)\n", pr.Outcome, pr.RuleID, strings.Join(pr.Owners, ", "))
+		for _, m := range pr.Missing {
+			fmt.Fprintf(w, "           needs %s\n", m)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-github/v81/github"
+
+	"repomedic/internal/config"
+	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
+	"repomedic/internal/output"
+	"repomedic/internal/rules"
+)
+
+func testPreflightRepo(id int64, owner, name string) RepositoryRef {
+	return RepositoryRef{
+		ID:    id,
+		Owner: owner,
+		Name:  name,
+		Repo: &github.Repository{
+			ID:            github.Ptr(id),
+			Name:          github.Ptr(name),
+			Owner:         &github.User{Login: github.Ptr(owner)},
+			DefaultBranch: github.Ptr("main"),
+		},
+	}
+}
+
+func TestRunPreflight_ProbesOneRepoPerOwner(t *testing.T) {
+	var probes atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/", func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Must have admin rights to Repository."}`))
+	})
+	mux.HandleFunc("/repos/octo/", func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	f := fetcher.NewFetcher(newTestGitHubClient(t, server.URL), fetcher.NewRequestBudget())
+	selected := []rules.Rule{
+		&mockRule{id: "needs-protection", deps: []data.DependencyKey{data.DepRepoDefaultBranchClassicProtection}},
+		&mockRule{id: "no-permissions", deps: []data.DependencyKey{data.DepRepoMetadata}},
+	}
+	plan := NewScanPlan()
+	for _, repo := range []RepositoryRef{
+		testPreflightRepo(1, "acme", "a"),
+		testPreflightRepo(2, "acme", "b"),
+		testPreflightRepo(3, "octo", "c"),
+	} {
+		if err := plan.AddRepo(context.Background(), repo, selected); err != nil {
+			t.Fatalf("AddRepo: %v", err)
+		}
+	}
+
+	impact, err := runPreflight(context.Background(), f, plan, selected)
+	if err != nil {
+		t.Fatalf("runPreflight: %v", err)
+	}
+	if got := probes.Load(); got != 2 {
+		t.Fatalf("expected one probe per owner, got %d requests", got)
+	}
+	if len(impact) != 1 {
+		t.Fatalf("expected one impacted rule, got %+v", impact)
+	}
+	pr := impact[0]
+	if pr.RuleID != "needs-protection" || pr.Outcome != string(rules.StatusSkipped) {
+		t.Fatalf("unexpected impact: %+v", pr)
+	}
+	if len(pr.Owners) != 1 || pr.Owners[0] != "acme" {
+		t.Fatalf("expected only acme to be denied, got %v", pr.Owners)
+	}
+	if len(pr.Missing) != 1 || pr.Missing[0] != "repo.default_branch_protection (scope repo, fine-grained administration:read, repo role admin)" {
+		t.Fatalf("unexpected missing permissions: %v", pr.Missing)
+	}
+
+	// The successful probe is cached for the scan.
+	if _, err := f.Fetch(context.Background(), plan.RepoPlans[3].Repo.Repo, data.DepRepoDefaultBranchClassicProtection, nil); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if got := probes.Load(); got != 2 {
+		t.Fatalf("expected the probe result to be reused, got %d requests", got)
+	}
+}
+
+func TestEngine_Run_RequireFullCoverageAborts(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/repo1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":1, "name":"repo1", "full_name":"acme/repo1", "default_branch":"main", "owner":{"login":"acme"}, "visibility":"public"}`)
+	})
+	mux.HandleFunc("/repos/acme/repo1/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message":"Must have admin rights to Repository."}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ruleID := "test-preflight-protection-rule"
+	rule := &recordingRule{mockRule: mockRule{id: ruleID, deps: []data.DependencyKey{data.DepRepoDefaultBranchClassicProtection}}}
+	func() {
+		defer func() { _ = recover() }()
+		rules.Register(rule)
+	}()
+
+	outPath := filepath.Join(t.TempDir(), "events.ndjson")
+	cfg := config.New()
+	cfg.Targeting.Repos = []string{"acme/repo1"}
+	cfg.Rules.Selector = ruleID
+	cfg.Output.NoConsole = true
+	cfg.Output.Out = outPath
+	cfg.Runtime.Concurrency = 1
+	cfg.Runtime.RequireFullCoverage = true
+
+	exitCode := NewEngine(newTestGitHubClient(t, server.URL)).Run(context.Background(), cfg)
+	if exitCode != 3 {
+		t.Fatalf("expected exit code 3, got %d", exitCode)
+	}
+	if rule.evaluated.Load() != 0 {
+		t.Fatalf("expected no rule evaluation after aborting")
+	}
+
+	b, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read events: %v", err)
+	}
+	events := string(b)
+	for _, want := range []string{`"type":"` + output.EventRunPreflight + `"`, `"rule_id":"` + ruleID + `"`, `"outcome":"SKIPPED"`, `"type":"run.finished"`} {
+		if !strings.Contains(events, want) {
+			t.Fatalf("expected %s in events:\n%s", want, events)
+		}
+	}
+}
+
+type recordingRule struct {
+	mockRule
+	evaluated atomic.Int32
+}
+
+func (r *recordingRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
+	r.evaluated.Add(1)
+	return rules.Result{Status: rules.StatusPass}, nil
+}
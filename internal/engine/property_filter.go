@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"repomedic/internal/config"
+	"repomedic/internal/data"
+	"repomedic/internal/data/models"
+	"repomedic/internal/fetcher"
+	"repomedic/internal/flags"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v81/github"
+)
+
+// FilterReposByProperty keeps repositories whose org custom properties match
+// every --property key (any of the values given for that key).
+//
+// Values already present on the discovered repository (custom_properties) are
+// used as-is; otherwise the org-scoped DepOrgCustomProperties listing is
+// fetched, once per org, through f so rules needing properties later reuse it.
+// Repositories without custom properties (e.g. user-owned) never match.
+//
+// An org whose listing the token cannot read is an error: every repository
+// in it would silently fail to match.
+func FilterReposByProperty(ctx context.Context, f *fetcher.Fetcher, repos []RepositoryRef, cfg *config.Config) ([]RepositoryRef, error) {
+	want, err := config.ParsePropertyFilters(cfg.Targeting.Property)
+	if err != nil {
+		return nil, err
+	}
+	if len(want) == 0 {
+		return repos, nil
+	}
+
+	filtered := make([]RepositoryRef, 0, len(repos))
+	for _, r := range repos {
+		props, err := repoCustomProperties(ctx, f, r)
+		if err != nil {
+			return nil, err
+		}
+		if props.Matches(want) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
+func repoCustomProperties(ctx context.Context, f *fetcher.Fetcher, r RepositoryRef) (models.CustomProperties, error) {
+	if r.Repo.CustomProperties != nil {
+		return models.CustomPropertiesFromAny(r.Repo.CustomProperties), nil
+	}
+	org, err := orgCustomProperties(ctx, f, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom property values for %s: %w", r.Owner, err)
+	}
+	if org.Denied {
+		return nil, fmt.Errorf("cannot read custom property values in %s (needs %s); --%s would match none of its repositories", r.Owner, customPropertiesPermission(), flags.FlagProperty)
+	}
+	return org.ForRepo(r.Repo.GetID()), nil
+}
+
+// orgCustomProperties fetches the property listing of r's owner through f.
+func orgCustomProperties(ctx context.Context, f *fetcher.Fetcher, r RepositoryRef) (*models.OrgCustomProperties, error) {
+	repo := r.Repo
+	if repo.GetOwner().GetLogin() == "" {
+		// Org-scoped fetches are keyed by owner login.
+		cp := *repo
+		cp.Owner = &github.User{Login: github.Ptr(r.Owner)}
+		repo = &cp
+	}
+	v, err := f.Fetch(ctx, repo, data.DepOrgCustomProperties, nil)
+	if err != nil {
+		return nil, err
+	}
+	org, _ := v.(*models.OrgCustomProperties)
+	if org == nil {
+		org = &models.OrgCustomProperties{Org: r.Owner}
+	}
+	return org, nil
+}
+
+// warnUnreadableCustomProperties warns about each org whose property listing
+// the token cannot read while the plan needs repository properties, because
+// allow.properties waivers and --report-group-by would then see none. The
+// listing is fetched through f, so the scan reuses it.
+func warnUnreadableCustomProperties(ctx context.Context, w io.Writer, f *fetcher.Fetcher, plan *ScanPlan) {
+	probes := make(map[string]RepositoryRef)
+	for _, rp := range plan.RepoPlans {
+		if _, ok := rp.Dependencies[data.DepRepoCustomProperties]; !ok || rp.Repo.Repo.CustomProperties != nil {
+			continue
+		}
+		owner := strings.ToLower(rp.Repo.Owner)
+		if cur, ok := probes[owner]; !ok || rp.Repo.Name < cur.Name {
+			probes[owner] = rp.Repo
+		}
+	}
+
+	owners := make([]string, 0, len(probes))
+	for owner := range probes {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+
+	var denied []string
+	for _, owner := range owners {
+		// Fetch errors other than a denied listing are reported by the scan.
+		if org, err := orgCustomProperties(ctx, f, probes[owner]); err == nil && org.Denied {
+			denied = append(denied, probes[owner].Owner)
+		}
+	}
+	if len(denied) > 0 {
+		fmt.Fprintf(w, "Warning: cannot read custom property values in %s (needs %s); allow.properties waivers and --%s will treat their repositories as having no properties.\n", strings.Join(denied, ", "), customPropertiesPermission(), flags.FlagReportGroupBy)
+	}
+}
+
+// customPropertiesPermission describes the permission the property listing needs.
+func customPropertiesPermission() string {
+	perms := fetcher.Permissions(data.DepOrgCustomProperties)
+	out := make([]string, 0, len(perms))
+	for _, p := range perms {
+		out = append(out, p.String())
+	}
+	return strings.Join(out, "; ")
+}
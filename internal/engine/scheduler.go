@@ -6,13 +6,26 @@ import (
 	"fmt"
 	"repomedic/internal/data"
 	"repomedic/internal/fetcher"
+	"repomedic/internal/output"
 	"sort"
 	"sync"
+	"time"
 )
 
 type Scheduler struct {
 	fetcher     *fetcher.Fetcher
 	concurrency int
+
+	// fetchConcurrency bounds dependency fetches in flight across all repos.
+	fetchConcurrency int
+
+	// adaptive, when set, resizes the fetch pool during Execute (--concurrency auto).
+	// statsMu guards adaptive; latencyMu guards the fetch latency window.
+	adaptive     *adaptiveConcurrency
+	statsMu      sync.Mutex
+	latencyMu    sync.Mutex
+	latencySum   time.Duration
+	latencyCount int
 }
 
 func NewScheduler(f *fetcher.Fetcher, concurrency int) (*Scheduler, error) {
@@ -22,7 +35,61 @@ func NewScheduler(f *fetcher.Fetcher, concurrency int) (*Scheduler, error) {
 	if concurrency <= 0 {
 		return nil, fmt.Errorf("concurrency must be >= 1, got %d", concurrency)
 	}
-	return &Scheduler{fetcher: f, concurrency: concurrency}, nil
+	return &Scheduler{fetcher: f, concurrency: concurrency, fetchConcurrency: concurrency}, nil
+}
+
+// SetFetchConcurrency sets the size of the global fetch worker pool.
+// It defaults to the repo concurrency passed to NewScheduler.
+func (s *Scheduler) SetFetchConcurrency(n int) error {
+	if n <= 0 {
+		return fmt.Errorf("fetch concurrency must be >= 1, got %d", n)
+	}
+	s.fetchConcurrency = n
+	return nil
+}
+
+// EnableAdaptiveConcurrency switches the scheduler to --concurrency auto: the
+// fetch pool starts small and is resized between 1 and maxWorkers based on
+// rate-limit headroom, fetch latency and throttling/5xx responses. Up to
+// maxWorkers repos may be in flight so the pool, not the repo limit, is the
+// bottleneck.
+func (s *Scheduler) EnableAdaptiveConcurrency(maxWorkers int) error {
+	if maxWorkers <= 0 {
+		return fmt.Errorf("adaptive concurrency max must be >= 1, got %d", maxWorkers)
+	}
+	s.concurrency = maxWorkers
+	s.fetchConcurrency = maxWorkers
+	s.adaptive = newAdaptiveConcurrency(autoConcurrencyInitial, autoConcurrencyMin, maxWorkers)
+	return nil
+}
+
+// ConcurrencyStats reports the fetch concurrency used by the last Execute.
+func (s *Scheduler) ConcurrencyStats() *output.ConcurrencyStats {
+	if s.adaptive == nil {
+		return &output.ConcurrencyStats{
+			Mode:    "fixed",
+			Initial: s.fetchConcurrency,
+			Min:     s.fetchConcurrency,
+			Max:     s.fetchConcurrency,
+			Final:   s.fetchConcurrency,
+		}
+	}
+
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+	a := s.adaptive
+	stats := &output.ConcurrencyStats{
+		Mode:    "auto",
+		Initial: a.initial,
+		Final:   a.current,
+		Changes: append([]output.ConcurrencyChange(nil), a.changes...),
+	}
+	stats.Min, stats.Max = stats.Initial, stats.Initial
+	for _, c := range a.changes {
+		stats.Min = min(stats.Min, c.Workers)
+		stats.Max = max(stats.Max, c.Workers)
+	}
+	return stats
 }
 
 // Execute streams per-repo dependency fetch completion results.
@@ -33,6 +100,11 @@ func NewScheduler(f *fetcher.Fetcher, concurrency int) (*Scheduler, error) {
 //   - The results channel and error channel are both closed reliably.
 //   - The error channel is used for fatal errors / cancellation signals; per-dependency
 //     fetch failures are recorded on RepoExecutionResult.DepErrs.
+//
+// Dependencies of a repo are fetched in parallel on a fetch worker pool shared by all
+// in-flight repos (see SetFetchConcurrency). The pool dispatches by
+// RepoPlan.DependencyPriority, so P0/P1 dependencies (and their upstream keys) of every
+// active repo are fetched before P2 work.
 func (s *Scheduler) Execute(ctx context.Context, plan *ScanPlan) (<-chan RepoExecutionResult, <-chan error) {
 	resultsCh := make(chan RepoExecutionResult)
 	errCh := make(chan error, 1)
@@ -75,6 +147,10 @@ func (s *Scheduler) Execute(ctx context.Context, plan *ScanPlan) (<-chan RepoExe
 			trySendErr(fmt.Errorf("scheduler concurrency must be >= 1, got %d", s.concurrency))
 			return
 		}
+		if s.fetchConcurrency <= 0 {
+			trySendErr(fmt.Errorf("scheduler fetch concurrency must be >= 1, got %d", s.fetchConcurrency))
+			return
+		}
 
 		runCtx, cancel := context.WithCancel(ctx)
 		defer cancel()
@@ -83,6 +159,26 @@ func (s *Scheduler) Execute(ctx context.Context, plan *ScanPlan) (<-chan RepoExe
 		sem := make(chan struct{}, s.concurrency)
 		var wg sync.WaitGroup
 
+		pool := newFetchPool(s.fetchConcurrency)
+		defer pool.Close()
+
+		if s.adaptive != nil {
+			s.statsMu.Lock()
+			pool.SetLimit(s.adaptive.current)
+			s.statsMu.Unlock()
+
+			stopAdaptive := make(chan struct{})
+			adaptiveDone := make(chan struct{})
+			go func() {
+				defer close(adaptiveDone)
+				s.runAdaptiveConcurrency(runCtx, pool, stopAdaptive)
+			}()
+			defer func() {
+				close(stopAdaptive)
+				<-adaptiveDone
+			}()
+		}
+
 		repoIDs := make([]int64, 0, len(plan.RepoPlans))
 		for id := range plan.RepoPlans {
 			repoIDs = append(repoIDs, id)
@@ -117,20 +213,31 @@ func (s *Scheduler) Execute(ctx context.Context, plan *ScanPlan) (<-chan RepoExe
 
 				dataMap := make(map[data.DependencyKey]any)
 				depErrs := make(map[data.DependencyKey]error)
+				var mu sync.Mutex
+				var depWG sync.WaitGroup
 
-				deps := rp.SortedDependencies()
-				for _, key := range deps {
-					if runCtx.Err() != nil {
-						return
-					}
+				for _, key := range rp.SortedDependencies() {
 					req := rp.Dependencies[key]
-					val, err := s.fetcher.Fetch(runCtx, rp.Repo.Repo, req.Key, req.Params)
-					if err != nil {
-						depErrs[req.Key] = err
-						continue
-					}
-					dataMap[req.Key] = val
+					depWG.Add(1)
+					pool.Submit(rp.DependencyPriority(key), func() {
+						defer depWG.Done()
+						if runCtx.Err() != nil {
+							return
+						}
+						start := time.Now()
+						val, err := s.fetcher.Fetch(runCtx, rp.Repo.Repo, req.Key, req.Params)
+						s.observeFetchLatency(time.Since(start))
+
+						mu.Lock()
+						defer mu.Unlock()
+						if err != nil {
+							depErrs[req.Key] = err
+							return
+						}
+						dataMap[req.Key] = val
+					})
 				}
+				depWG.Wait()
 
 				if runCtx.Err() != nil {
 					return
@@ -143,6 +250,9 @@ func (s *Scheduler) Execute(ctx context.Context, plan *ScanPlan) (<-chan RepoExe
 				}
 				select {
 				case resultsCh <- res:
+					// The result now owns the fetched values; drop the repo's
+					// cache entries so memory does not grow with the scan.
+					s.fetcher.ReleaseRepo(rp.Repo.Repo)
 				case <-runCtx.Done():
 					return
 				}
@@ -159,3 +269,58 @@ func (s *Scheduler) Execute(ctx context.Context, plan *ScanPlan) (<-chan RepoExe
 
 	return resultsCh, errCh
 }
+
+// runAdaptiveConcurrency periodically feeds budget and latency signals to the
+// adaptive controller and applies the resulting worker count to the pool.
+func (s *Scheduler) runAdaptiveConcurrency(ctx context.Context, pool *fetchPool, stop <-chan struct{}) {
+	ticker := time.NewTicker(autoConcurrencyInterval)
+	defer ticker.Stop()
+
+	prev := s.fetcher.BudgetSnapshot()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			snap := s.fetcher.BudgetSnapshot()
+			signals := concurrencySignals{
+				remaining:    snap.Remaining,
+				resetIn:      snap.Reset.Sub(now),
+				throttled:    snap.Throttled - prev.Throttled,
+				serverErrors: snap.ServerErrors - prev.ServerErrors,
+				avgLatency:   s.takeAverageFetchLatency(),
+				backlog:      pool.Backlog(),
+			}
+			prev = snap
+
+			s.statsMu.Lock()
+			workers := s.adaptive.adjust(now, signals)
+			s.statsMu.Unlock()
+			pool.SetLimit(workers)
+		}
+	}
+}
+
+func (s *Scheduler) observeFetchLatency(d time.Duration) {
+	if s.adaptive == nil {
+		return
+	}
+	s.latencyMu.Lock()
+	defer s.latencyMu.Unlock()
+	s.latencySum += d
+	s.latencyCount++
+}
+
+// takeAverageFetchLatency returns the mean latency since the previous call and resets the window.
+func (s *Scheduler) takeAverageFetchLatency() time.Duration {
+	s.latencyMu.Lock()
+	defer s.latencyMu.Unlock()
+	if s.latencyCount == 0 {
+		return 0
+	}
+	avg := s.latencySum / time.Duration(s.latencyCount)
+	s.latencySum, s.latencyCount = 0, 0
+	return avg
+}
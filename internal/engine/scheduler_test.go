@@ -10,7 +10,9 @@ import (
 	"repomedic/internal/fetcher"
 	gh "repomedic/internal/github"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-github/v81/github"
 )
@@ -449,3 +451,132 @@ func TestScheduler_Execute_Stream_FatalNilRepoPlanDoesNotPanic(t *testing.T) {
 		t.Fatalf("expected nil repo plan error, got %v", gotErr)
 	}
 }
+
+func TestScheduler_Execute_Stream_FetchesRepoDependenciesInParallel(t *testing.T) {
+	// Both handlers block until the other one has been reached, so the repo only
+	// completes without errors if its dependencies are fetched concurrently.
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	bothArrived := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(bothArrived)
+	}()
+	waitForPeer := func(w http.ResponseWriter) bool {
+		arrived.Done()
+		select {
+		case <-bothArrived:
+			return true
+		case <-time.After(2 * time.Second):
+			w.WriteHeader(http.StatusGatewayTimeout)
+			fmt.Fprint(w, `{"message":"dependency fetched sequentially"}`)
+			return false
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		if waitForPeer(w) {
+			fmt.Fprint(w, `{"id":1, "name":"repo", "full_name":"owner/repo", "default_branch":"main"}`)
+		}
+	})
+	mux.HandleFunc("/repos/owner/repo/rulesets", func(w http.ResponseWriter, r *http.Request) {
+		if waitForPeer(w) {
+			fmt.Fprint(w, `[]`)
+		}
+	})
+
+	scheduler := newTestScheduler(t, mux, 1)
+	if err := scheduler.SetFetchConcurrency(2); err != nil {
+		t.Fatalf("SetFetchConcurrency: %v", err)
+	}
+
+	plan := NewScanPlan()
+	plan.RepoPlans[1] = &RepoPlan{
+		Repo: RepositoryRef{
+			ID:   1,
+			Name: "repo",
+			Repo: &github.Repository{
+				ID:            github.Ptr(int64(1)),
+				Name:          github.Ptr("repo"),
+				Owner:         &github.User{Login: github.Ptr("owner")},
+				FullName:      github.Ptr("owner/repo"),
+				DefaultBranch: github.Ptr("main"),
+			},
+		},
+		Dependencies: map[data.DependencyKey]data.DependencyRequest{
+			data.DepRepoMetadata:    {Key: data.DepRepoMetadata},
+			data.DepRepoAllRulesets: {Key: data.DepRepoAllRulesets},
+		},
+	}
+
+	resCh, errCh := scheduler.Execute(context.Background(), plan)
+	count := 0
+	for res := range resCh {
+		count++
+		if len(res.DepErrs) != 0 {
+			t.Fatalf("expected no dependency errors, got %v", res.DepErrs)
+		}
+	}
+	if count != 1 {
+		t.Fatalf("expected exactly 1 streamed result, got %d", count)
+	}
+	for err := range errCh {
+		if err != nil {
+			t.Fatalf("expected no fatal scheduler error, got %v", err)
+		}
+	}
+}
+
+func TestScheduler_SetFetchConcurrency_RejectsNonPositive(t *testing.T) {
+	scheduler := newTestScheduler(t, http.NewServeMux(), 1)
+	if err := scheduler.SetFetchConcurrency(0); err == nil {
+		t.Fatal("expected error for fetch concurrency 0")
+	}
+}
+
+func TestScheduler_Execute_Stream_ReleasesRepoCacheAfterEmit(t *testing.T) {
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"id":1, "name":"repo", "full_name":"owner/repo", "default_branch":"main"}`)
+	})
+
+	scheduler := newTestScheduler(t, mux, 1)
+	repo := &github.Repository{
+		ID:       github.Ptr(int64(1)),
+		Name:     github.Ptr("repo"),
+		Owner:    &github.User{Login: github.Ptr("owner")},
+		FullName: github.Ptr("owner/repo"),
+	}
+	plan := NewScanPlan()
+	plan.RepoPlans[1] = &RepoPlan{
+		Repo: RepositoryRef{ID: 1, Name: "repo", Repo: repo},
+		Dependencies: map[data.DependencyKey]data.DependencyRequest{
+			data.DepRepoMetadata: {Key: data.DepRepoMetadata},
+		},
+	}
+
+	resCh, errCh := scheduler.Execute(context.Background(), plan)
+	for res := range resCh {
+		if _, ok := res.Data.Get(data.DepRepoMetadata); !ok {
+			t.Fatal("expected metadata in the emitted result")
+		}
+	}
+	for err := range errCh {
+		if err != nil {
+			t.Fatalf("expected no fatal scheduler error, got %v", err)
+		}
+	}
+
+	// The repo-scoped cache entry was released after emit, so fetching again
+	// goes back to the API. LRU caching is disabled to observe that directly.
+	scheduler.fetcher.SetMaxCacheBytes(0)
+	if _, err := scheduler.fetcher.Fetch(context.Background(), repo, data.DepRepoMetadata, nil); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the released entry to be refetched (2 calls), got %d", calls)
+	}
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v81/github"
+
+	"repomedic/internal/fetcher"
+)
+
+// checkPoolVisibility refuses to mix tokens unless every token can see every
+// repository being scanned in each org. Org-scoped results are fetched once
+// per org by whichever token has headroom, so they must not depend on that
+// choice.
+//
+// Discovery ran on the first token, so repos is its view; each other token
+// lists the org once (one request per 100 repositories). Listings are taken
+// minutes apart on large orgs, so before refusing, both the token and the
+// first token list the org again: repositories deleted or transferred since
+// discovery are not held against the pool, nor are ones a listing skipped
+// while pages shifted.
+func checkPoolVisibility(ctx context.Context, pool *fetcher.ClientPool, repos []RepositoryRef) error {
+	if pool == nil || pool.Len() < 2 {
+		return nil
+	}
+
+	scanned := make(map[string][]RepositoryRef)
+	for _, r := range repos {
+		// User accounts list only public repositories to other users.
+		if r.Repo.GetOwner().GetType() == "User" || r.ID == 0 {
+			continue
+		}
+		org := strings.ToLower(r.Owner)
+		if org != "" {
+			scanned[org] = append(scanned[org], r)
+		}
+	}
+	orgs := make([]string, 0, len(scanned))
+	for org := range scanned {
+		orgs = append(orgs, org)
+	}
+	sort.Strings(orgs)
+
+	members := pool.Members()
+	for _, org := range orgs {
+		var first []int64 // the first token's fresh listing, once needed
+		for i := 1; i < len(members); i++ {
+			missing, err := reposNotVisible(ctx, members[i], org, scanned[org])
+			if err != nil {
+				return fmt.Errorf("token %d: failed to list repositories in %s: %w", i+1, org, err)
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			if missing, err = reposNotVisible(ctx, members[i], org, missing); err != nil {
+				return fmt.Errorf("token %d: failed to list repositories in %s: %w", i+1, org, err)
+			}
+			if len(missing) > 0 && first == nil {
+				if first, err = visibleOrgRepoIDs(ctx, members[0], org); err != nil {
+					return fmt.Errorf("token 1: failed to list repositories in %s: %w", org, err)
+				}
+			}
+			missing = slices.DeleteFunc(missing, func(r RepositoryRef) bool {
+				_, found := slices.BinarySearch(first, r.ID)
+				return !found
+			})
+			if len(missing) > 0 {
+				return fmt.Errorf("token %d cannot see %d of the %d repositories scanned in %s (e.g. %s); refusing to mix tokens with different visibility", i+1, len(missing), len(scanned[org]), org, missing[0].Owner+"/"+missing[0].Name)
+			}
+		}
+	}
+	return nil
+}
+
+// reposNotVisible returns the repos that m cannot list in org.
+func reposNotVisible(ctx context.Context, m fetcher.PoolMember, org string, repos []RepositoryRef) ([]RepositoryRef, error) {
+	ids, err := visibleOrgRepoIDs(ctx, m, org)
+	if err != nil {
+		return nil, err
+	}
+	var missing []RepositoryRef
+	for _, r := range repos {
+		if _, found := slices.BinarySearch(ids, r.ID); !found {
+			missing = append(missing, r)
+		}
+	}
+	return missing, nil
+}
+
+// visibleOrgRepoIDs returns the sorted IDs of the repositories m can list in org.
+func visibleOrgRepoIDs(ctx context.Context, m fetcher.PoolMember, org string) ([]int64, error) {
+	opts := &github.RepositoryListByOrgOptions{Type: "all", ListOptions: github.ListOptions{PerPage: discoveryPageSize}}
+	d, err := listRepoRefsPaged(0, func(page int) ([]*github.Repository, *github.Response, error) {
+		if err := m.Budget.Acquire(ctx, 1); err != nil {
+			return nil, nil, err
+		}
+		opts.Page = page
+		repos, resp, err := m.Client.Client.Repositories.ListByOrg(ctx, org, opts)
+		if resp != nil {
+			m.Budget.UpdateFromResponse(resp.Response)
+		}
+		// Not an org after all; every token sees the same public user listing.
+		if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil, nil
+		}
+		return repos, resp, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(d.Refs))
+	for _, r := range d.Refs {
+		ids = append(ids, r.ID)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"repomedic/internal/fetcher"
+)
+
+// orgReposServer serves org repo listings: the n-th request gets listings[n],
+// and later requests get the last one.
+type orgReposServer struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+func newOrgReposServer(t *testing.T, listings ...[]int64) *orgReposServer {
+	t.Helper()
+	s := &orgReposServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		n := int(s.requests.Add(1)) - 1
+		ids := listings[min(n, len(listings)-1)]
+		repos := make([]string, 0, len(ids))
+		for _, id := range ids {
+			repos = append(repos, fmt.Sprintf(`{"id":%d,"name":"r%d"}`, id, id))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(repos, ","))
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestPoolOver(t *testing.T, servers ...*orgReposServer) *fetcher.ClientPool {
+	t.Helper()
+	members := make([]fetcher.PoolMember, 0, len(servers))
+	for _, s := range servers {
+		members = append(members, fetcher.PoolMember{Client: newTestGitHubClient(t, s.URL), Budget: fetcher.NewRequestBudget()})
+	}
+	pool, err := fetcher.NewClientPool(members...)
+	if err != nil {
+		t.Fatalf("NewClientPool: %v", err)
+	}
+	return pool
+}
+
+func TestCheckPoolVisibility(t *testing.T) {
+	repos := []RepositoryRef{testPreflightRepo(1, "acme", "a"), testPreflightRepo(2, "acme", "b")}
+
+	t.Run("first token reuses discovery", func(t *testing.T) {
+		first := newOrgReposServer(t, []int64{1, 2, 3})
+		pool := newTestPoolOver(t, first, newOrgReposServer(t, []int64{3, 2, 1}))
+		if err := checkPoolVisibility(context.Background(), pool, repos); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n := first.requests.Load(); n != 0 {
+			t.Fatalf("expected discovery to stand in for the first token, got %d listings", n)
+		}
+	})
+
+	t.Run("repositories outside the scan are ignored", func(t *testing.T) {
+		pool := newTestPoolOver(t, newOrgReposServer(t, []int64{1, 2, 3}), newOrgReposServer(t, []int64{1, 2, 4}))
+		if err := checkPoolVisibility(context.Background(), pool, repos); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("scanned repository hidden from a token is refused", func(t *testing.T) {
+		pool := newTestPoolOver(t, newOrgReposServer(t, []int64{1, 2, 3}), newOrgReposServer(t, []int64{1, 3}))
+		err := checkPoolVisibility(context.Background(), pool, repos)
+		if err == nil || !strings.Contains(err.Error(), "token 2 cannot see 1 of the 2 repositories scanned in acme (e.g. acme/b)") {
+			t.Fatalf("expected a visibility mismatch error, got %v", err)
+		}
+	})
+
+	t.Run("repository deleted since discovery is not held against the pool", func(t *testing.T) {
+		pool := newTestPoolOver(t, newOrgReposServer(t, []int64{1}), newOrgReposServer(t, []int64{1}))
+		if err := checkPoolVisibility(context.Background(), pool, repos); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("listing that skipped a repository is re-checked", func(t *testing.T) {
+		pool := newTestPoolOver(t, newOrgReposServer(t, []int64{1, 2}), newOrgReposServer(t, []int64{1}, []int64{1, 2}))
+		if err := checkPoolVisibility(context.Background(), pool, repos); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("single token is not checked", func(t *testing.T) {
+		only := newOrgReposServer(t, []int64{1})
+		if err := checkPoolVisibility(context.Background(), newTestPoolOver(t, only), repos); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n := only.requests.Load(); n != 0 {
+			t.Fatalf("expected no listings for a single token, got %d", n)
+		}
+	})
+}
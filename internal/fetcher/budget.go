@@ -17,6 +17,22 @@ type RequestBudget struct {
 	probed    bool
 	cooldown  time.Time
 	notifyCh  chan struct{}
+
+	// throttled and serverErrors count secondary-rate-limit and 5xx responses
+	// observed so far; adaptive concurrency backs off when they grow.
+	throttled    int
+	serverErrors int
+}
+
+// BudgetSnapshot is a point-in-time view of the budget and the response health
+// signals observed by UpdateFromResponse.
+type BudgetSnapshot struct {
+	Remaining int
+	Reset     time.Time
+	// Throttled counts secondary rate limit responses (429, or 403 with Retry-After).
+	Throttled int
+	// ServerErrors counts 5xx responses.
+	ServerErrors int
 }
 
 func NewRequestBudget() *RequestBudget {
@@ -29,12 +45,57 @@ func NewRequestBudget() *RequestBudget {
 	return b
 }
 
+// searchRequestsPerMinute is the Search API allowance for authenticated
+// requests. GitHub tracks it separately from the core REST limit.
+const searchRequestsPerMinute = 30
+
+// NewSearchBudget returns a budget for Search API calls. Search responses carry
+// their own X-RateLimit-* headers, so keeping a separate budget lets search
+// pacing follow the search bucket without draining or blocking core calls.
+func NewSearchBudget() *RequestBudget {
+	b := NewRequestBudget()
+	b.remaining = searchRequestsPerMinute
+	b.reset = b.now().Add(time.Minute)
+	return b
+}
+
 func (b *RequestBudget) Remaining() int {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	return b.remaining
 }
 
+// Snapshot returns the current budget state and cumulative health counters.
+func (b *RequestBudget) Snapshot() BudgetSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return BudgetSnapshot{
+		Remaining:    b.remaining,
+		Reset:        b.reset,
+		Throttled:    b.throttled,
+		ServerErrors: b.serverErrors,
+	}
+}
+
+// headroom returns how many requests can be made now (0 during a cooldown)
+// and when the budget next frees up.
+func (b *RequestBudget) headroom() (int, time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := b.now()
+	switch {
+	case now.Before(b.cooldown):
+		return 0, b.cooldown
+	case b.remaining > 0:
+		return b.remaining, now
+	case !now.Before(b.reset) && !b.probed:
+		// The reset probe is available.
+		return 1, now
+	default:
+		return 0, b.reset
+	}
+}
+
 func (b *RequestBudget) Acquire(ctx context.Context, n int) error {
 	if ctx == nil {
 		return fmt.Errorf("Acquire: nil context")
@@ -57,6 +118,7 @@ func (b *RequestBudget) Acquire(ctx context.Context, n int) error {
 			return err
 		}
 	}
+	countRequests(ctx, n)
 	return nil
 }
 
@@ -167,6 +229,14 @@ func (b *RequestBudget) UpdateFromResponse(resp *http.Response) {
 
 	changed := false
 
+	switch {
+	case resp.StatusCode >= 500:
+		b.serverErrors++
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("Retry-After") != "":
+		b.throttled++
+	}
+
 	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
 		if seconds, err := strconv.Atoi(retryAfter); err == nil {
 			if seconds > 0 {
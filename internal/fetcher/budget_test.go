@@ -202,3 +202,34 @@ func TestRequestBudget(t *testing.T) {
 		}
 	})
 }
+
+func TestRequestBudget_SnapshotCountsThrottlingAndServerErrors(t *testing.T) {
+	b := NewRequestBudget()
+
+	respond := func(status int, headers map[string]string) {
+		resp := &http.Response{StatusCode: status, Header: make(http.Header)}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		b.UpdateFromResponse(resp)
+	}
+
+	respond(http.StatusOK, map[string]string{"X-RateLimit-Remaining": "42"})
+	respond(http.StatusBadGateway, nil)
+	respond(http.StatusServiceUnavailable, nil)
+	respond(http.StatusTooManyRequests, nil)
+	respond(http.StatusForbidden, map[string]string{"Retry-After": "1"})
+	// A plain 403 is a permission problem, not throttling.
+	respond(http.StatusForbidden, nil)
+
+	snap := b.Snapshot()
+	if snap.Remaining != 42 {
+		t.Fatalf("Remaining = %d, want 42", snap.Remaining)
+	}
+	if snap.ServerErrors != 2 {
+		t.Fatalf("ServerErrors = %d, want 2", snap.ServerErrors)
+	}
+	if snap.Throttled != 2 {
+		t.Fatalf("Throttled = %d, want 2", snap.Throttled)
+	}
+}
@@ -1,19 +1,255 @@
 package fetcher
 
-import "sync"
+import (
+	"container/list"
+	"reflect"
+	"sync"
+	"time"
+)
 
+// DefaultCacheMaxBytes bounds the LRU portion of a Cache created by NewCache.
+const DefaultCacheMaxBytes int64 = 256 << 20
+
+// releasedWindow is how many recently evicted repos the cache remembers, so
+// a write arriving shortly after a repo's eviction goes to the LRU instead of
+// being held for a repo that will not be evicted again.
+const releasedWindow = 1024
+
+// Cache holds fetched dependency values for the duration of a run.
+//
+// Entries are kept in one of three classes:
+//   - org-scoped entries are kept for the whole run (they are shared by every repo of the owner);
+//   - repo-scoped entries are grouped by repo and dropped together by EvictRepo once the
+//     repo's results have been emitted, so they are bounded by the repos in flight;
+//   - everything else goes into a size-aware LRU bounded by SetMaxBytes. Every fetcher is
+//     org- or repo-scoped today, so in practice the LRU holds only repo-scoped values
+//     written after their repo's eviction (e.g. fetches outside the scheduler).
+//
+// Memory therefore grows with the number of orgs and in-flight repos, not with the
+// number of repos scanned; the LRU bound covers only its own class.
 type Cache struct {
-	data sync.Map
+	mu sync.Mutex
+
+	org    map[string]any
+	repos  map[string]map[string]any
+	repoOf map[string]string
+
+	// released holds the last releasedWindow evicted repos; releasedRing
+	// lists them in eviction order and releasedNext is the next slot.
+	released     map[string]struct{}
+	releasedRing []string
+	releasedNext int
+
+	lru      *list.List
+	lruIndex map[string]*list.Element
+	lruBytes int64
+	maxBytes int64
+}
+
+type lruEntry struct {
+	key   string
+	value any
+	size  int64
 }
 
 func NewCache() *Cache {
-	return &Cache{}
+	return &Cache{
+		org:      make(map[string]any),
+		repos:    make(map[string]map[string]any),
+		repoOf:   make(map[string]string),
+		released: make(map[string]struct{}, releasedWindow),
+		lru:      list.New(),
+		lruIndex: make(map[string]*list.Element),
+		maxBytes: DefaultCacheMaxBytes,
+	}
+}
+
+// SetMaxBytes bounds the LRU portion of the cache. Values <= 0 disable the LRU
+// (such entries are not cached at all).
+func (c *Cache) SetMaxBytes(n int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxBytes = n
+	c.trimLocked()
 }
 
 func (c *Cache) Get(key string) (any, bool) {
-	return c.data.Load(key)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.org[key]; ok {
+		return v, true
+	}
+	if repo, ok := c.repoOf[key]; ok {
+		v, ok := c.repos[repo][key]
+		return v, ok
+	}
+	if el, ok := c.lruIndex[key]; ok {
+		c.lru.MoveToFront(el)
+		return el.Value.(*lruEntry).value, true
+	}
+	return nil, false
 }
 
+// Set stores an entry in the size-aware LRU.
 func (c *Cache) Set(key string, value any) {
-	c.data.Store(key, value)
+	// Estimate before locking: walking a large payload must not stall
+	// concurrent lookups.
+	size := estimateSize(key, value)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.setLRULocked(key, value, size)
+}
+
+// SetOrgScoped stores an entry that is kept for the whole run.
+func (c *Cache) SetOrgScoped(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.org[key] = value
+}
+
+// SetRepoScoped stores an entry owned by repo. It is dropped by EvictRepo(repo);
+// if repo was recently evicted, the entry goes into the LRU instead.
+func (c *Cache) SetRepoScoped(repo, key string, value any) {
+	c.mu.Lock()
+	if _, done := c.released[repo]; done {
+		c.mu.Unlock()
+		c.Set(key, value)
+		return
+	}
+	defer c.mu.Unlock()
+
+	entries := c.repos[repo]
+	if entries == nil {
+		entries = make(map[string]any)
+		c.repos[repo] = entries
+	}
+	entries[key] = value
+	c.repoOf[key] = repo
+}
+
+// EvictRepo drops all repo-scoped entries owned by repo and returns how many
+// were removed. SetRepoScoped calls for repo use the LRU until releasedWindow
+// more repos have been evicted.
+func (c *Cache) EvictRepo(repo string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := c.repos[repo]
+	for key := range entries {
+		delete(c.repoOf, key)
+	}
+	delete(c.repos, repo)
+	c.markReleasedLocked(repo)
+	return len(entries)
+}
+
+func (c *Cache) markReleasedLocked(repo string) {
+	if _, ok := c.released[repo]; ok {
+		return
+	}
+	if len(c.releasedRing) < releasedWindow {
+		c.releasedRing = append(c.releasedRing, repo)
+	} else {
+		delete(c.released, c.releasedRing[c.releasedNext])
+		c.releasedRing[c.releasedNext] = repo
+		c.releasedNext = (c.releasedNext + 1) % releasedWindow
+	}
+	c.released[repo] = struct{}{}
+}
+
+// LRUBytes returns the estimated size of the entries currently held in the LRU.
+func (c *Cache) LRUBytes() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lruBytes
+}
+
+func (c *Cache) setLRULocked(key string, value any, size int64) {
+	if c.maxBytes <= 0 {
+		return
+	}
+	if size > c.maxBytes {
+		return
+	}
+	if el, ok := c.lruIndex[key]; ok {
+		e := el.Value.(*lruEntry)
+		c.lruBytes += size - e.size
+		e.value, e.size = value, size
+		c.lru.MoveToFront(el)
+	} else {
+		c.lruIndex[key] = c.lru.PushFront(&lruEntry{key: key, value: value, size: size})
+		c.lruBytes += size
+	}
+	c.trimLocked()
+}
+
+func (c *Cache) trimLocked() {
+	for c.lruBytes > max(c.maxBytes, 0) {
+		el := c.lru.Back()
+		if el == nil {
+			return
+		}
+		e := el.Value.(*lruEntry)
+		c.lru.Remove(el)
+		delete(c.lruIndex, e.key)
+		c.lruBytes -= e.size
+	}
+}
+
+// estimateSize approximates the retained size of a cached value by walking
+// it: strings and byte slices count their length, other scalars their width,
+// and pointers, slices, maps, structs and interfaces are followed. It does not
+// allocate, and sharing or cycles below maxSizeDepth are not detected; that is
+// close enough for the tree-shaped GitHub API models the cache holds.
+func estimateSize(key string, value any) int64 {
+	return int64(len(key)) + sizeOf(reflect.ValueOf(value), 0)
+}
+
+// maxSizeDepth stops sizeOf on pathological or cyclic values.
+const maxSizeDepth = 32
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func sizeOf(v reflect.Value, depth int) int64 {
+	if !v.IsValid() || depth > maxSizeDepth {
+		return 0
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return int64(v.Len())
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		return sizeOf(v.Elem(), depth+1)
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return int64(v.Len())
+		}
+		var n int64
+		for i := 0; i < v.Len(); i++ {
+			n += sizeOf(v.Index(i), depth+1)
+		}
+		return n
+	case reflect.Map:
+		var n int64
+		iter := v.MapRange()
+		for iter.Next() {
+			n += sizeOf(iter.Key(), depth+1) + sizeOf(iter.Value(), depth+1)
+		}
+		return n
+	case reflect.Struct:
+		if v.Type() == timeType {
+			// Its *Location is shared; do not charge its zone tables per value.
+			return int64(v.Type().Size())
+		}
+		var n int64
+		for i := 0; i < v.NumField(); i++ {
+			n += sizeOf(v.Field(i), depth+1)
+		}
+		return n
+	default:
+		return int64(v.Type().Size())
+	}
 }
@@ -0,0 +1,132 @@
+package fetcher
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v81/github"
+)
+
+func TestCache_EvictRepoDropsOnlyThatRepo(t *testing.T) {
+	c := NewCache()
+	c.SetOrgScoped("acme:org.key:", "org-value")
+	c.SetRepoScoped("acme/a", "acme/a:repo.key:", "a-value")
+	c.SetRepoScoped("acme/b", "acme/b:repo.key:", "b-value")
+
+	if n := c.EvictRepo("acme/a"); n != 1 {
+		t.Fatalf("EvictRepo removed %d entries, want 1", n)
+	}
+
+	if _, ok := c.Get("acme/a:repo.key:"); ok {
+		t.Fatal("expected evicted repo entry to be gone")
+	}
+	if v, ok := c.Get("acme/b:repo.key:"); !ok || v != "b-value" {
+		t.Fatalf("expected other repo entry to remain, got %v %v", v, ok)
+	}
+	if v, ok := c.Get("acme:org.key:"); !ok || v != "org-value" {
+		t.Fatalf("expected org entry to remain, got %v %v", v, ok)
+	}
+}
+
+func TestCache_RepoScopedAfterEvictionUsesLRU(t *testing.T) {
+	c := NewCache()
+	c.EvictRepo("acme/a")
+
+	c.SetRepoScoped("acme/a", "acme/a:repo.key:", "late-value")
+	if v, ok := c.Get("acme/a:repo.key:"); !ok || v != "late-value" {
+		t.Fatalf("expected late entry to be cached in the LRU, got %v %v", v, ok)
+	}
+	if c.LRUBytes() == 0 {
+		t.Fatal("expected late entry to be accounted in the LRU")
+	}
+	if n := c.EvictRepo("acme/a"); n != 0 {
+		t.Fatalf("expected no repo-owned entries after eviction, got %d", n)
+	}
+}
+
+func TestCache_LRUEvictsLeastRecentlyUsedBySize(t *testing.T) {
+	c := NewCache()
+	payload := strings.Repeat("x", 100)
+	entrySize := estimateSize("k1", payload)
+	c.SetMaxBytes(2 * entrySize)
+
+	c.Set("k1", payload)
+	c.Set("k2", payload)
+	// Touch k1 so k2 becomes least recently used.
+	if _, ok := c.Get("k1"); !ok {
+		t.Fatal("expected k1 to be cached")
+	}
+	c.Set("k3", payload)
+
+	if _, ok := c.Get("k2"); ok {
+		t.Fatal("expected k2 to be evicted")
+	}
+	for _, k := range []string{"k1", "k3"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("expected %s to be cached", k)
+		}
+	}
+	if got := c.LRUBytes(); got > 2*entrySize {
+		t.Fatalf("LRU holds %d bytes, bound is %d", got, 2*entrySize)
+	}
+}
+
+func TestCache_LRUDisabledAndOversizedEntries(t *testing.T) {
+	c := NewCache()
+	c.SetMaxBytes(0)
+	c.Set("k", "v")
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("expected LRU entries to be dropped when the LRU is disabled")
+	}
+
+	c.SetMaxBytes(8)
+	c.Set("big", strings.Repeat("x", 64))
+	if _, ok := c.Get("big"); ok {
+		t.Fatal("expected entry larger than the bound not to be cached")
+	}
+
+	// Org- and repo-scoped entries are not subject to the LRU bound.
+	c.SetOrgScoped("acme:org.key:", strings.Repeat("x", 64))
+	if _, ok := c.Get("acme:org.key:"); !ok {
+		t.Fatal("expected org entry to be cached regardless of the LRU bound")
+	}
+}
+
+func TestCache_ReleasedReposAreBounded(t *testing.T) {
+	c := NewCache()
+	for i := 0; i < releasedWindow+10; i++ {
+		c.EvictRepo(fmt.Sprintf("acme/r%d", i))
+	}
+	if len(c.released) != releasedWindow || len(c.releasedRing) != releasedWindow {
+		t.Fatalf("expected %d remembered evictions, got %d (ring %d)", releasedWindow, len(c.released), len(c.releasedRing))
+	}
+	if _, ok := c.released["acme/r0"]; ok {
+		t.Fatal("expected the oldest eviction to be forgotten")
+	}
+	if _, ok := c.released[fmt.Sprintf("acme/r%d", releasedWindow+9)]; !ok {
+		t.Fatal("expected the latest eviction to be remembered")
+	}
+
+	// A recently evicted repo's late write goes to the LRU.
+	c.SetRepoScoped(fmt.Sprintf("acme/r%d", releasedWindow+9), "late", "v")
+	if len(c.repos) != 0 || c.LRUBytes() == 0 {
+		t.Fatalf("expected the late write in the LRU, got %d repo maps and %d LRU bytes", len(c.repos), c.LRUBytes())
+	}
+}
+
+func TestEstimateSize_WalksValues(t *testing.T) {
+	repo := &github.Repository{
+		Name:      github.Ptr(strings.Repeat("n", 100)),
+		Topics:    []string{strings.Repeat("t", 50), strings.Repeat("t", 50)},
+		CreatedAt: &github.Timestamp{Time: time.Now()},
+	}
+	got := estimateSize("key", repo)
+	if got < 203 || got > 2000 {
+		t.Fatalf("expected roughly the payload size, got %d", got)
+	}
+	if n := estimateSize("k", map[string][]byte{"a": make([]byte, 1000)}); n < 1000 || n > 1100 {
+		t.Fatalf("expected byte slices to count their length, got %d", n)
+	}
+}
@@ -3,8 +3,10 @@ package fetcher
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"repomedic/internal/data"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/google/go-github/v81/github"
@@ -16,6 +18,37 @@ type DataFetcher interface {
 	Fetch(ctx context.Context, repo *github.Repository, params map[string]string, f *Fetcher) (any, error)
 }
 
+// UpstreamDeclarer is optionally implemented by DataFetchers that call
+// f.Fetch for other keys. Declared upstream keys are added to scan plans
+// (so they are prioritized ahead of their dependents) and checked for cycles
+// when the fetcher is registered.
+type UpstreamDeclarer interface {
+	Upstream() []data.DependencyKey
+}
+
+// InvalidValueError reports a fetcher that returned a value whose type does
+// not match the type registered for its key (see data.NewKey).
+type InvalidValueError struct {
+	Fetcher string
+	Key     data.DependencyKey
+	Got     string
+	Want    string
+}
+
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("fetcher %s returned %s for %s (want %s)", e.Fetcher, e.Got, e.Key, e.Want)
+}
+
+// Name returns the fetcher's Go type name (e.g. allRulesetsFetcher), used to
+// identify fetchers in errors and dependency graphs.
+func Name(df DataFetcher) string {
+	t := reflect.TypeOf(df)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
 var (
 	dataFetcherRegistry = make(map[data.DependencyKey]DataFetcher)
 	dataFetcherMu       sync.RWMutex
@@ -36,6 +69,96 @@ func RegisterDataFetcher(df DataFetcher) {
 		panic(fmt.Sprintf("data fetcher %s already registered", k))
 	}
 	dataFetcherRegistry[k] = df
+	if cycle := findUpstreamCycleLocked(k); cycle != nil {
+		delete(dataFetcherRegistry, k)
+		panic(fmt.Sprintf("data fetcher %s: upstream dependency cycle: %s", k, joinKeys(cycle, " -> ")))
+	}
+}
+
+// UpstreamKeys returns the keys the fetcher for key declares as upstream
+// (nil if it declares none or is not registered).
+func UpstreamKeys(key data.DependencyKey) []data.DependencyKey {
+	dataFetcherMu.RLock()
+	defer dataFetcherMu.RUnlock()
+	return upstreamLocked(key)
+}
+
+// TransitiveUpstream returns every key reachable from key through declared
+// upstream dependencies, sorted, excluding key itself.
+func TransitiveUpstream(key data.DependencyKey) []data.DependencyKey {
+	dataFetcherMu.RLock()
+	defer dataFetcherMu.RUnlock()
+
+	seen := make(map[data.DependencyKey]bool)
+	var visit func(k data.DependencyKey)
+	visit = func(k data.DependencyKey) {
+		for _, up := range upstreamLocked(k) {
+			if up == key || seen[up] {
+				continue
+			}
+			seen[up] = true
+			visit(up)
+		}
+	}
+	visit(key)
+
+	out := make([]data.DependencyKey, 0, len(seen))
+	for k := range seen {
+		out = append(out, k)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func upstreamLocked(key data.DependencyKey) []data.DependencyKey {
+	df, ok := dataFetcherRegistry[key]
+	if !ok {
+		return nil
+	}
+	ud, ok := df.(UpstreamDeclarer)
+	if !ok {
+		return nil
+	}
+	return ud.Upstream()
+}
+
+// findUpstreamCycleLocked returns the path of an upstream cycle through start,
+// or nil. Keys whose fetchers are not registered yet end the walk, so a cycle
+// is reported when its last member registers.
+func findUpstreamCycleLocked(start data.DependencyKey) []data.DependencyKey {
+	visited := make(map[data.DependencyKey]bool)
+	var path []data.DependencyKey
+	var walk func(k data.DependencyKey) bool
+	walk = func(k data.DependencyKey) bool {
+		path = append(path, k)
+		for _, up := range upstreamLocked(k) {
+			if up == start {
+				path = append(path, up)
+				return true
+			}
+			if visited[up] {
+				continue
+			}
+			visited[up] = true
+			if walk(up) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if walk(start) {
+		return path
+	}
+	return nil
+}
+
+func joinKeys(keys []data.DependencyKey, sep string) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = string(k)
+	}
+	return strings.Join(parts, sep)
 }
 
 func ResolveDataFetcher(key data.DependencyKey) (DataFetcher, bool) {
@@ -0,0 +1,81 @@
+package fetcher_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
+
+	"github.com/google/go-github/v81/github"
+)
+
+type testUpstreamFetcher struct {
+	key      data.DependencyKey
+	upstream []data.DependencyKey
+}
+
+func (t *testUpstreamFetcher) Key() data.DependencyKey { return t.key }
+
+func (t *testUpstreamFetcher) Scope() data.FetchScope { return data.ScopeRepo }
+
+func (t *testUpstreamFetcher) Upstream() []data.DependencyKey { return t.upstream }
+
+func (t *testUpstreamFetcher) Fetch(_ context.Context, _ *github.Repository, _ map[string]string, _ *fetcher.Fetcher) (any, error) {
+	return "ok", nil
+}
+
+func TestRegisterDataFetcher_RejectsUpstreamCycle(t *testing.T) {
+	const (
+		aKey data.DependencyKey = "test.upstream.cycle.a"
+		bKey data.DependencyKey = "test.upstream.cycle.b"
+		cKey data.DependencyKey = "test.upstream.cycle.c"
+	)
+	fetcher.RegisterDataFetcher(&testUpstreamFetcher{key: aKey, upstream: []data.DependencyKey{bKey}})
+	fetcher.RegisterDataFetcher(&testUpstreamFetcher{key: bKey, upstream: []data.DependencyKey{cKey}})
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected registration of the closing fetcher to panic")
+		}
+		msg := r.(string)
+		if !strings.Contains(msg, "upstream dependency cycle") || !strings.Contains(msg, "test.upstream.cycle.c -> test.upstream.cycle.a -> test.upstream.cycle.b -> test.upstream.cycle.c") {
+			t.Fatalf("unexpected panic message: %s", msg)
+		}
+		if _, ok := fetcher.ResolveDataFetcher(cKey); ok {
+			t.Fatal("expected the rejected fetcher not to stay registered")
+		}
+	}()
+	fetcher.RegisterDataFetcher(&testUpstreamFetcher{key: cKey, upstream: []data.DependencyKey{aKey}})
+}
+
+func TestRegisterDataFetcher_RejectsSelfUpstream(t *testing.T) {
+	const selfKey data.DependencyKey = "test.upstream.self"
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected self-upstream registration to panic")
+		}
+	}()
+	fetcher.RegisterDataFetcher(&testUpstreamFetcher{key: selfKey, upstream: []data.DependencyKey{selfKey}})
+}
+
+func TestTransitiveUpstream(t *testing.T) {
+	const (
+		rootKey data.DependencyKey = "test.upstream.chain.root"
+		midKey  data.DependencyKey = "test.upstream.chain.mid"
+		leafKey data.DependencyKey = "test.upstream.chain.leaf"
+	)
+	fetcher.RegisterDataFetcher(&testUpstreamFetcher{key: leafKey})
+	fetcher.RegisterDataFetcher(&testUpstreamFetcher{key: midKey, upstream: []data.DependencyKey{leafKey}})
+	fetcher.RegisterDataFetcher(&testUpstreamFetcher{key: rootKey, upstream: []data.DependencyKey{midKey, leafKey}})
+
+	got := fetcher.TransitiveUpstream(rootKey)
+	if len(got) != 2 || got[0] != leafKey || got[1] != midKey {
+		t.Fatalf("TransitiveUpstream(root) = %v, want [%s %s]", got, leafKey, midKey)
+	}
+	if got := fetcher.TransitiveUpstream(leafKey); len(got) != 0 {
+		t.Fatalf("TransitiveUpstream(leaf) = %v, want none", got)
+	}
+}
@@ -4,31 +4,63 @@ import (
 	"context"
 	"fmt"
 	"repomedic/internal/data"
+	"repomedic/internal/data/models"
 	gh "repomedic/internal/github"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/google/go-github/v81/github"
 )
 
 type Fetcher struct {
-	client       *gh.Client
-	budget       *RequestBudget
-	group        Group
+	client *gh.Client
+	budget *RequestBudget
+	// pool, when set, supplies the client and budget of each DataFetcher call.
+	pool         *ClientPool
+	group        *Group
 	cache        *Cache
-	scannedRepos []*github.Repository
+	scannedRepos []*models.ScannedRepo
+	requests     *requestCounter
 }
 
 type fetchChainKey struct{}
 
 func NewFetcher(client *gh.Client, budget *RequestBudget) *Fetcher {
 	return &Fetcher{
-		client: client,
-		budget: budget,
-		cache:  NewCache(),
+		client:   client,
+		budget:   budget,
+		group:    &Group{},
+		cache:    NewCache(),
+		requests: &requestCounter{counts: make(map[data.DependencyKey]int)},
 	}
 }
 
+// NewPooledFetcher returns a Fetcher whose DataFetcher calls each run on the
+// pool member with the most headroom. Budget and Client outside a fetch
+// return the first member.
+func NewPooledFetcher(pool *ClientPool) *Fetcher {
+	first := pool.Members()[0]
+	f := NewFetcher(first.Client, first.Budget)
+	f.pool = pool
+	return f
+}
+
+// BudgetSnapshot returns the budget state, aggregated across the pool when
+// the fetcher has one.
+func (f *Fetcher) BudgetSnapshot() BudgetSnapshot {
+	if f.pool != nil {
+		return f.pool.Snapshot()
+	}
+	return f.budget.Snapshot()
+}
+
+// RequestCounts returns how many API requests each dependency has made so
+// far. Requests made while fetching a nested dependency count toward it.
+func (f *Fetcher) RequestCounts() map[data.DependencyKey]int {
+	return f.requests.snapshot()
+}
+
 func (f *Fetcher) Budget() *RequestBudget {
 	return f.budget
 }
@@ -41,13 +73,16 @@ func (f *Fetcher) Client() *gh.Client {
 // This must be called by the engine after discovery but before rule evaluation begins.
 // It enables org-scoped fetchers (like DepReposScanned) to access the discovered list
 // without making additional GitHub API calls.
-func (f *Fetcher) SetScannedRepos(repos []*github.Repository) {
+//
+// Only a slim projection of each repository is kept so the scanned set stays
+// small for very large orgs.
+func (f *Fetcher) SetScannedRepos(repos []*models.ScannedRepo) {
 	f.scannedRepos = repos
 }
 
 // ScannedRepos returns the list of repositories discovered for the current scan.
 // Returns nil if SetScannedRepos has not been called.
-func (f *Fetcher) ScannedRepos() []*github.Repository {
+func (f *Fetcher) ScannedRepos() []*models.ScannedRepo {
 	return f.scannedRepos
 }
 
@@ -103,14 +138,82 @@ func (f *Fetcher) Fetch(ctx context.Context, repo *github.Repository, key data.D
 		// Fetch
 		return f.doFetch(ctx, repo, key, params)
 	})
+	if err == nil {
+		if mismatch, ok := data.CheckValue(key, val).(*data.TypeMismatchError); ok {
+			val, err = nil, &InvalidValueError{Fetcher: Name(fetchImpl), Key: key, Got: mismatch.Got, Want: mismatch.Want}
+		}
+	}
 
 	if err == nil {
-		f.cache.Set(flightKey, val)
+		switch fetchImpl.Scope() {
+		case data.ScopeOrg:
+			f.cache.SetOrgScoped(flightKey, val)
+		case data.ScopeRepo:
+			f.cache.SetRepoScoped(repoCacheKey(repo), flightKey, val)
+		default:
+			f.cache.Set(flightKey, val)
+		}
 	}
 
 	return val, err
 }
 
+// SetMaxCacheBytes bounds the LRU portion of the fetch cache, which holds
+// values fetched for a repo after ReleaseRepo (see Cache).
+func (f *Fetcher) SetMaxCacheBytes(n int64) {
+	f.cache.SetMaxBytes(n)
+}
+
+// ReleaseRepo drops cached repo-scoped values for repo. The scheduler calls it
+// once the repo's results have been emitted so memory stays flat across large
+// scans; org-scoped values are kept for the rest of the run.
+func (f *Fetcher) ReleaseRepo(repo *github.Repository) {
+	if f == nil || f.cache == nil || repo == nil {
+		return
+	}
+	if key := repoCacheKey(repo); key != "" {
+		f.cache.EvictRepo(key)
+	}
+}
+
+// requestCounter tallies budget acquisitions per dependency. doFetch tags
+// the context with the dependency being fetched and RequestBudget.Acquire
+// reports to the counter it finds there.
+type requestCounter struct {
+	mu     sync.Mutex
+	counts map[data.DependencyKey]int
+}
+
+type requestAttributionKey struct{}
+
+type requestAttribution struct {
+	counter *requestCounter
+	key     data.DependencyKey
+}
+
+func countRequests(ctx context.Context, n int) {
+	a, ok := ctx.Value(requestAttributionKey{}).(requestAttribution)
+	if !ok || a.counter == nil {
+		return
+	}
+	a.counter.mu.Lock()
+	a.counter.counts[a.key] += n
+	a.counter.mu.Unlock()
+}
+
+func (c *requestCounter) snapshot() map[data.DependencyKey]int {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[data.DependencyKey]int, len(c.counts))
+	for k, v := range c.counts {
+		out[k] = v
+	}
+	return out
+}
+
 func withFetchChain(ctx context.Context, flightKey string) (context.Context, error) {
 	chain := getFetchChain(ctx)
 	for _, existing := range chain {
@@ -142,7 +245,18 @@ func (f *Fetcher) doFetch(ctx context.Context, repo *github.Repository, key data
 	if !ok {
 		return nil, fmt.Errorf("unsupported dependency key: %s", key)
 	}
-	return fetchImpl.Fetch(ctx, repo, params, f)
+	if f.requests != nil {
+		ctx = context.WithValue(ctx, requestAttributionKey{}, requestAttribution{counter: f.requests, key: key})
+	}
+	if f.pool == nil {
+		return fetchImpl.Fetch(ctx, repo, params, f)
+	}
+	// One member serves the whole call so paged listings stay on one token.
+	// The view shares the cache and single-flight group with f.
+	m := f.pool.Pick()
+	view := *f
+	view.client, view.budget = m.Client, m.Budget
+	return fetchImpl.Fetch(ctx, repo, params, &view)
 }
 
 func makeFlightKey(repo *github.Repository, scope data.FetchScope, key data.DependencyKey, params map[string]string) (string, error) {
@@ -155,16 +269,10 @@ func makeFlightKey(repo *github.Repository, scope data.FetchScope, key data.Depe
 		}
 		prefix = owner
 	case data.ScopeRepo:
-		repoID := repo.GetFullName()
-		if repoID == "" {
-			owner := strings.ToLower(repo.GetOwner().GetLogin())
-			name := strings.ToLower(repo.GetName())
-			if owner == "" || name == "" {
-				return "", fmt.Errorf("Fetch: repo owner/name is required for repo-scoped dependency: %s", key)
-			}
-			repoID = owner + "/" + name
+		prefix = repoCacheKey(repo)
+		if prefix == "" {
+			return "", fmt.Errorf("Fetch: repo owner/name is required for repo-scoped dependency: %s", key)
 		}
-		prefix = strings.ToLower(repoID)
 	default:
 		return "", fmt.Errorf("Fetch: unknown fetch scope %q for dependency: %s", scope, key)
 	}
@@ -172,6 +280,21 @@ func makeFlightKey(repo *github.Repository, scope data.FetchScope, key data.Depe
 	return prefix + ":" + string(key) + ":" + stableParamsKey(params), nil
 }
 
+// repoCacheKey returns the lowercase OWNER/NAME used to group repo-scoped
+// cache entries, or "" if the repo cannot be identified.
+func repoCacheKey(repo *github.Repository) string {
+	repoID := repo.GetFullName()
+	if repoID == "" {
+		owner := repo.GetOwner().GetLogin()
+		name := repo.GetName()
+		if owner == "" || name == "" {
+			return ""
+		}
+		repoID = owner + "/" + name
+	}
+	return strings.ToLower(repoID)
+}
+
 func stableParamsKey(params map[string]string) string {
 	if len(params) == 0 {
 		return ""
@@ -2,6 +2,7 @@ package fetcher_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -138,6 +139,15 @@ func TestFetcher_Fetch(t *testing.T) {
 	if rem := budgetRemaining(budget); rem != 4999 {
 		t.Errorf("Expected 4999 remaining, got %d", rem)
 	}
+
+	// The request is attributed to the dependency that made it; a cached
+	// fetch makes none.
+	if _, err := f.Fetch(context.Background(), repo, data.DepRepoMetadata, nil); err != nil {
+		t.Fatalf("cached Fetch failed: %v", err)
+	}
+	if counts := f.RequestCounts(); len(counts) != 1 || counts[data.DepRepoMetadata] != 1 {
+		t.Errorf("Expected 1 request for %s, got %v", data.DepRepoMetadata, counts)
+	}
 }
 
 func TestFetcher_CacheKey_DeterministicParamsOrder(t *testing.T) {
@@ -316,6 +326,35 @@ func TestFetcher_DependencyCycleDetection_MutualCycle(t *testing.T) {
 	}
 }
 
+func TestFetcher_WrongValueType_ReturnsInvalidValueErrorAndSkipsCache(t *testing.T) {
+	key := data.NewKey[int]("test.typed.wrong_value")
+	var calls int32
+	fetcher.RegisterDataFetcher(&testValueFetcher{key: key.ID(), scope: data.ScopeRepo, calls: &calls})
+
+	server := httptest.NewServer(http.NewServeMux())
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	f := fetcher.NewFetcher(client, fetcher.NewRequestBudget())
+
+	repo := &github.Repository{Owner: &github.User{Login: github.Ptr("acme")}, Name: github.Ptr("repo"), FullName: github.Ptr("acme/repo")}
+	for i := 0; i < 2; i++ {
+		val, err := f.Fetch(context.Background(), repo, key.ID(), nil)
+		var invalid *fetcher.InvalidValueError
+		if !errors.As(err, &invalid) {
+			t.Fatalf("expected InvalidValueError, got %v", err)
+		}
+		if val != nil {
+			t.Fatalf("expected nil value, got %v", val)
+		}
+		if invalid.Fetcher != "testValueFetcher" || invalid.Got != "string" || invalid.Want != "int" {
+			t.Fatalf("unexpected error details: %+v", invalid)
+		}
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected invalid value not to be cached (2 calls), got %d", got)
+	}
+}
+
 func TestFetcher_FetchScope_Org_DedupesAcrossReposSameOrg(t *testing.T) {
 	ensureTestScopeFetchersRegistered()
 	atomic.StoreInt32(&testOrgScopeCalls, 0)
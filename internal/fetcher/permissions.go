@@ -0,0 +1,60 @@
+package fetcher
+
+import (
+	"repomedic/internal/data"
+	"strings"
+)
+
+// Permission is an access requirement of a DataFetcher, stated for both token
+// kinds GitHub issues.
+type Permission struct {
+	// Scope is the classic token (PAT or OAuth) scope, e.g. "repo" or
+	// "read:org". Empty when no scope is needed beyond read access to the repo
+	// (public repos need none; private repos need "repo").
+	Scope string `json:"scope,omitempty"`
+	// FineGrained is the fine-grained token / GitHub App permission as
+	// "name:level", e.g. "administration:read".
+	FineGrained string `json:"fine_grained,omitempty"`
+	// Role is the minimum repository role the token's user must hold
+	// (e.g. "admin"), independent of the token's scopes. Empty when any
+	// read access suffices.
+	Role string `json:"role,omitempty"`
+	// Degrades is true when the fetcher tolerates the permission being
+	// missing and returns reduced data instead of failing.
+	Degrades bool `json:"degrades,omitempty"`
+}
+
+func (p Permission) String() string {
+	var parts []string
+	if p.Scope != "" {
+		parts = append(parts, "scope "+p.Scope)
+	}
+	if p.FineGrained != "" {
+		parts = append(parts, "fine-grained "+p.FineGrained)
+	}
+	if p.Role != "" {
+		parts = append(parts, "repo role "+p.Role)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// PermissionDeclarer is optionally implemented by DataFetchers that need more
+// than read access to the repository. Permissions of upstream keys are not
+// repeated; they are declared by the upstream fetchers.
+type PermissionDeclarer interface {
+	Permissions() []Permission
+}
+
+// Permissions returns the permissions the fetcher for key declares (nil if it
+// declares none or is not registered).
+func Permissions(key data.DependencyKey) []Permission {
+	df, ok := ResolveDataFetcher(key)
+	if !ok {
+		return nil
+	}
+	pd, ok := df.(PermissionDeclarer)
+	if !ok {
+		return nil
+	}
+	return pd.Permissions()
+}
@@ -0,0 +1,88 @@
+package fetcher
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	gh "repomedic/internal/github"
+)
+
+// PoolMember is one credential of a ClientPool with its own request budget.
+type PoolMember struct {
+	Client *gh.Client
+	Budget *RequestBudget
+}
+
+// ClientPool spreads fetches across several credentials so one token running
+// low does not block the scan until its reset.
+type ClientPool struct {
+	members []PoolMember
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewClientPool returns a pool over members. It needs at least one member.
+func NewClientPool(members ...PoolMember) (*ClientPool, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("client pool: no members")
+	}
+	for i, m := range members {
+		if m.Client == nil || m.Budget == nil {
+			return nil, fmt.Errorf("client pool: member %d has no client or budget", i+1)
+		}
+	}
+	return &ClientPool{members: members}, nil
+}
+
+// Members returns the pool's members in the order they were given.
+func (p *ClientPool) Members() []PoolMember {
+	return p.members
+}
+
+// Len returns the number of credentials in the pool.
+func (p *ClientPool) Len() int {
+	return len(p.members)
+}
+
+// Pick returns the member with the most requests available now. When every
+// member is exhausted it returns the one that frees up first. Ties rotate so
+// a fresh pool spreads work from the start.
+func (p *ClientPool) Pick() PoolMember {
+	p.mu.Lock()
+	start := p.next
+	p.next = (p.next + 1) % len(p.members)
+	p.mu.Unlock()
+
+	best := -1
+	bestAvail := 0
+	var bestReady time.Time
+	for i := range p.members {
+		idx := (start + i) % len(p.members)
+		avail, ready := p.members[idx].Budget.headroom()
+		switch {
+		case best < 0,
+			avail > bestAvail,
+			avail == 0 && bestAvail == 0 && ready.Before(bestReady):
+			best, bestAvail, bestReady = idx, avail, ready
+		}
+	}
+	return p.members[best]
+}
+
+// Snapshot aggregates the members' budgets: remaining requests and health
+// counters are summed, and Reset is the latest member reset.
+func (p *ClientPool) Snapshot() BudgetSnapshot {
+	var out BudgetSnapshot
+	for _, m := range p.members {
+		s := m.Budget.Snapshot()
+		out.Remaining += s.Remaining
+		out.Throttled += s.Throttled
+		out.ServerErrors += s.ServerErrors
+		if s.Reset.After(out.Reset) {
+			out.Reset = s.Reset
+		}
+	}
+	return out
+}
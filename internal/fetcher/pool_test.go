@@ -0,0 +1,117 @@
+package fetcher_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"repomedic/internal/data"
+	"repomedic/internal/fetcher"
+	gh "repomedic/internal/github"
+
+	"github.com/google/go-github/v81/github"
+)
+
+func budgetWith(remaining int, reset time.Time) *fetcher.RequestBudget {
+	b := fetcher.NewRequestBudget()
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	b.UpdateFromResponse(&http.Response{StatusCode: http.StatusOK, Header: h})
+	return b
+}
+
+func newTestPool(t *testing.T, budgets ...*fetcher.RequestBudget) *fetcher.ClientPool {
+	t.Helper()
+	members := make([]fetcher.PoolMember, 0, len(budgets))
+	for _, b := range budgets {
+		client, err := gh.NewClient(context.Background(), "dummy-token")
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+		members = append(members, fetcher.PoolMember{Client: client, Budget: b})
+	}
+	pool, err := fetcher.NewClientPool(members...)
+	if err != nil {
+		t.Fatalf("NewClientPool failed: %v", err)
+	}
+	return pool
+}
+
+func TestClientPool_PicksMostHeadroom(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	low, high := budgetWith(10, reset), budgetWith(100, reset)
+	pool := newTestPool(t, low, high)
+
+	for i := 0; i < 4; i++ {
+		if got := pool.Pick(); got.Budget != high {
+			t.Fatalf("pick %d: expected the member with the most remaining requests", i)
+		}
+	}
+}
+
+func TestClientPool_ExhaustedPicksEarliestReset(t *testing.T) {
+	late, soon := budgetWith(0, time.Now().Add(time.Hour)), budgetWith(0, time.Now().Add(time.Minute))
+	pool := newTestPool(t, late, soon)
+
+	if got := pool.Pick(); got.Budget != soon {
+		t.Fatalf("expected the member that resets first")
+	}
+}
+
+func TestClientPool_SnapshotAggregates(t *testing.T) {
+	early, late := time.Now().Add(time.Minute), time.Now().Add(time.Hour)
+	pool := newTestPool(t, budgetWith(10, early), budgetWith(20, late))
+
+	snap := pool.Snapshot()
+	if snap.Remaining != 30 || snap.Reset.Unix() != late.Unix() {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+}
+
+func TestNewClientPool_RequiresMembers(t *testing.T) {
+	if _, err := fetcher.NewClientPool(); err == nil {
+		t.Fatalf("expected error for an empty pool")
+	}
+}
+
+type testClientRecordingFetcher struct {
+	mu      sync.Mutex
+	clients map[*gh.Client]int
+}
+
+func (t *testClientRecordingFetcher) Key() data.DependencyKey { return "test.pool.client" }
+
+func (t *testClientRecordingFetcher) Scope() data.FetchScope { return data.ScopeRepo }
+
+func (t *testClientRecordingFetcher) Fetch(_ context.Context, _ *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.clients[f.Client()]++
+	return "ok", nil
+}
+
+func TestPooledFetcher_SpreadsFetchesAcrossMembers(t *testing.T) {
+	rec := &testClientRecordingFetcher{clients: make(map[*gh.Client]int)}
+	fetcher.RegisterDataFetcher(rec)
+
+	reset := time.Now().Add(time.Hour)
+	pool := newTestPool(t, budgetWith(100, reset), budgetWith(100, reset))
+	f := fetcher.NewPooledFetcher(pool)
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		repo := &github.Repository{Owner: &github.User{Login: github.Ptr("acme")}, Name: github.Ptr(name)}
+		if _, err := f.Fetch(context.Background(), repo, rec.Key(), nil); err != nil {
+			t.Fatalf("Fetch failed: %v", err)
+		}
+	}
+
+	for _, m := range pool.Members() {
+		if rec.clients[m.Client] != 2 {
+			t.Fatalf("expected fetches to alternate between equal members, got %v", rec.clients)
+		}
+	}
+}
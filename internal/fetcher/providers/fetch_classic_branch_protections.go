@@ -23,6 +23,11 @@ func (f *classicBranchProtectionsFetcher) Scope() data.FetchScope {
 	return data.ScopeRepo
 }
 
+// Permissions: branch protection rules are only visible to repository admins.
+func (c *classicBranchProtectionsFetcher) Permissions() []fetcher.Permission {
+	return []fetcher.Permission{{Scope: "repo", FineGrained: "administration:read", Role: "admin"}}
+}
+
 func (f *classicBranchProtectionsFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, fch *fetcher.Fetcher) (any, error) {
 	owner := repo.GetOwner().GetLogin()
 	repoName := repo.GetName()
@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+
+	"repomedic/internal/data"
+	"repomedic/internal/data/models"
+	"repomedic/internal/fetcher"
+
+	"github.com/google/go-github/v81/github"
+)
+
+const customPropertyValuesPageSize = 100
+
+// orgCustomPropertiesFetcher lists the custom property values of every
+// repository in the owner's organization in one paged bulk call, so property
+// lookups cost a handful of requests per org rather than one per repository.
+//
+// User accounts and orgs whose values the token cannot read (404/403) yield an
+// empty, unavailable listing rather than an error; the latter is marked Denied.
+type orgCustomPropertiesFetcher struct{}
+
+func (o *orgCustomPropertiesFetcher) Key() data.DependencyKey { return data.DepOrgCustomProperties }
+
+func (o *orgCustomPropertiesFetcher) Scope() data.FetchScope { return data.ScopeOrg }
+
+// Permissions: unreadable property values yield an empty listing.
+func (o *orgCustomPropertiesFetcher) Permissions() []fetcher.Permission {
+	return []fetcher.Permission{{Scope: "read:org", FineGrained: "organization_custom_properties:read", Degrades: true}}
+}
+
+func (o *orgCustomPropertiesFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
+	org := repo.GetOwner().GetLogin()
+	out := &models.OrgCustomProperties{Org: org, ByRepoID: make(map[int64]models.CustomProperties)}
+	if org == "" || repo.GetOwner().GetType() == "User" {
+		return out, nil
+	}
+
+	opts := &github.ListCustomPropertyValuesOptions{ListOptions: github.ListOptions{PerPage: customPropertyValuesPageSize}}
+	for {
+		if err := f.Budget().Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+		page, resp, err := f.Client().Client.Organizations.ListCustomPropertyValues(ctx, org, opts)
+		if resp != nil {
+			f.Budget().UpdateFromResponse(resp.Response)
+		}
+		if err != nil {
+			if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+				return &models.OrgCustomProperties{Org: org, Denied: true, ByRepoID: map[int64]models.CustomProperties{}}, nil
+			}
+			return nil, err
+		}
+		for _, rv := range page {
+			raw := make(map[string]any, len(rv.Properties))
+			for _, p := range rv.Properties {
+				raw[p.PropertyName] = p.Value
+			}
+			out.ByRepoID[rv.RepositoryID] = models.CustomPropertiesFromAny(raw)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	out.Available = true
+	return out, nil
+}
+
+// repoCustomPropertiesFetcher is the repository's view of the org-wide
+// listing. Values already present on the repository object (search and
+// org listings include custom_properties) are used without an API call.
+type repoCustomPropertiesFetcher struct{}
+
+func (r *repoCustomPropertiesFetcher) Key() data.DependencyKey { return data.DepRepoCustomProperties }
+
+func (r *repoCustomPropertiesFetcher) Scope() data.FetchScope { return data.ScopeRepo }
+
+// Upstream: one bulk listing per org.
+func (r *repoCustomPropertiesFetcher) Upstream() []data.DependencyKey {
+	return []data.DependencyKey{data.DepOrgCustomProperties}
+}
+
+func (r *repoCustomPropertiesFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
+	if repo.CustomProperties != nil {
+		return models.CustomPropertiesFromAny(repo.CustomProperties), nil
+	}
+	orgResult, err := f.Fetch(ctx, repo, data.DepOrgCustomProperties, nil)
+	if err != nil {
+		return nil, err
+	}
+	org, _ := orgResult.(*models.OrgCustomProperties)
+	return org.ForRepo(repo.GetID()), nil
+}
+
+func init() {
+	fetcher.RegisterDataFetcher(&orgCustomPropertiesFetcher{})
+	fetcher.RegisterDataFetcher(&repoCustomPropertiesFetcher{})
+}
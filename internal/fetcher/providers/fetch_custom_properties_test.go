@@ -0,0 +1,64 @@
+package providers_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"repomedic/internal/data"
+	"repomedic/internal/data/models"
+	"repomedic/internal/fetcher"
+
+	"github.com/google/go-github/v81/github"
+)
+
+func TestCustomPropertiesFetchers_Registration(t *testing.T) {
+	org, ok := fetcher.ResolveDataFetcher(data.DepOrgCustomProperties)
+	if !ok || org.Scope() != data.ScopeOrg {
+		t.Fatalf("expected org-scoped fetcher for %s", data.DepOrgCustomProperties)
+	}
+	repo, ok := fetcher.ResolveDataFetcher(data.DepRepoCustomProperties)
+	if !ok || repo.Scope() != data.ScopeRepo {
+		t.Fatalf("expected repo-scoped fetcher for %s", data.DepRepoCustomProperties)
+	}
+	if up := fetcher.UpstreamKeys(data.DepRepoCustomProperties); !slices.Equal(up, []data.DependencyKey{data.DepOrgCustomProperties}) {
+		t.Fatalf("UpstreamKeys(%s) = %v", data.DepRepoCustomProperties, up)
+	}
+}
+
+func TestRepoCustomPropertiesFetcher_UsesValuesOnRepository(t *testing.T) {
+	f := newTestFetcher(t)
+	repo := &github.Repository{
+		ID:               github.Ptr(int64(1)),
+		Owner:            &github.User{Login: github.Ptr("acme")},
+		Name:             github.Ptr("a"),
+		CustomProperties: map[string]any{"tier": "critical"},
+	}
+
+	// No API call is possible with the dummy client, so a result proves the
+	// values came from the repository object.
+	v, err := f.Fetch(context.Background(), repo, data.DepRepoCustomProperties, nil)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	props, ok := v.(models.CustomProperties)
+	if !ok || !props.Has("tier", []string{"critical"}) {
+		t.Fatalf("Fetch returned %#v", v)
+	}
+}
+
+func TestOrgCustomPropertiesFetcher_UserOwnerIsEmpty(t *testing.T) {
+	f := newTestFetcher(t)
+	repo := &github.Repository{
+		Owner: &github.User{Login: github.Ptr("someone"), Type: github.Ptr("User")},
+		Name:  github.Ptr("dotfiles"),
+	}
+	v, err := f.Fetch(context.Background(), repo, data.DepOrgCustomProperties, nil)
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	org, ok := v.(*models.OrgCustomProperties)
+	if !ok || org.Available || len(org.ByRepoID) != 0 {
+		t.Fatalf("Fetch returned %#v, want empty unavailable listing", v)
+	}
+}
@@ -20,6 +20,15 @@ func (d *defaultBranchCodeownersFetcher) Scope() data.FetchScope {
 	return data.ScopeRepo
 }
 
+// Permissions: reads repository file contents.
+func (d *defaultBranchCodeownersFetcher) Permissions() []fetcher.Permission {
+	return []fetcher.Permission{{FineGrained: "contents:read"}}
+}
+
+func (d *defaultBranchCodeownersFetcher) Upstream() []data.DependencyKey {
+	return []data.DependencyKey{data.DepRepoMetadata}
+}
+
 func (d *defaultBranchCodeownersFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
 	branch := repo.GetDefaultBranch()
 	if branch == "" {
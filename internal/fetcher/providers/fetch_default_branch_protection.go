@@ -19,6 +19,15 @@ func (d *defaultBranchProtectionFetcher) Scope() data.FetchScope {
 	return data.ScopeRepo
 }
 
+// Permissions: reading classic protection requires admin on the repository.
+func (d *defaultBranchProtectionFetcher) Permissions() []fetcher.Permission {
+	return []fetcher.Permission{{Scope: "repo", FineGrained: "administration:read", Role: "admin"}}
+}
+
+func (d *defaultBranchProtectionFetcher) Upstream() []data.DependencyKey {
+	return []data.DependencyKey{data.DepRepoMetadata}
+}
+
 func (d *defaultBranchProtectionFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
 	branch := repo.GetDefaultBranch()
 	if branch == "" {
@@ -19,6 +19,10 @@ func (d *defaultBranchRulesFetcher) Scope() data.FetchScope {
 	return data.ScopeRepo
 }
 
+func (d *defaultBranchRulesFetcher) Upstream() []data.DependencyKey {
+	return []data.DependencyKey{data.DepRepoMetadata}
+}
+
 func (d *defaultBranchRulesFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
 	branch := repo.GetDefaultBranch()
 	if branch == "" {
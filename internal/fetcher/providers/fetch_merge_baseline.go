@@ -28,6 +28,11 @@ func (m *mergeBaselineFetcher) Scope() data.FetchScope {
 	return data.ScopeOrg
 }
 
+// Upstream prefers the org baseline and falls back to the sampled convention.
+func (m *mergeBaselineFetcher) Upstream() []data.DependencyKey {
+	return []data.DependencyKey{data.DepOrgMergeBaseline, data.DepReposMergeConvention}
+}
+
 func (m *mergeBaselineFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
 	// Fetch org baseline.
 	orgResult, err := f.Fetch(ctx, repo, data.DepOrgMergeBaseline, nil)
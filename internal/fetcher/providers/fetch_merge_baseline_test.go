@@ -90,7 +90,7 @@ func TestReposMergeConventionFetcher_EmptyRepos(t *testing.T) {
 	f := newTestFetcher(t)
 
 	// Inject empty scanned repos.
-	f.SetScannedRepos([]*github.Repository{})
+	f.SetScannedRepos([]*models.ScannedRepo{})
 
 	repo := &github.Repository{
 		Owner: &github.User{Login: github.Ptr("org")},
@@ -116,30 +116,30 @@ func TestReposMergeConventionFetcher_WithRepos(t *testing.T) {
 	f := newTestFetcher(t)
 
 	// Inject scanned repos with merge method settings.
-	scannedRepos := []*github.Repository{
+	scannedRepos := []*models.ScannedRepo{
 		{
-			FullName:         github.Ptr("org/repo1"),
-			Owner:            &github.User{Login: github.Ptr("org")},
-			Name:             github.Ptr("repo1"),
-			DefaultBranch:    github.Ptr("main"),
+			FullName:         "org/repo1",
+			Owner:            "org",
+			Name:             "repo1",
+			DefaultBranch:    "main",
 			AllowMergeCommit: github.Ptr(false),
 			AllowSquashMerge: github.Ptr(true),
 			AllowRebaseMerge: github.Ptr(false),
 		},
 		{
-			FullName:         github.Ptr("org/repo2"),
-			Owner:            &github.User{Login: github.Ptr("org")},
-			Name:             github.Ptr("repo2"),
-			DefaultBranch:    github.Ptr("main"),
+			FullName:         "org/repo2",
+			Owner:            "org",
+			Name:             "repo2",
+			DefaultBranch:    "main",
 			AllowMergeCommit: github.Ptr(false),
 			AllowSquashMerge: github.Ptr(true),
 			AllowRebaseMerge: github.Ptr(false),
 		},
 		{
-			FullName:         github.Ptr("org/repo3"),
-			Owner:            &github.User{Login: github.Ptr("org")},
-			Name:             github.Ptr("repo3"),
-			DefaultBranch:    github.Ptr("main"),
+			FullName:         "org/repo3",
+			Owner:            "org",
+			Name:             "repo3",
+			DefaultBranch:    "main",
 			AllowMergeCommit: github.Ptr(true),
 			AllowSquashMerge: github.Ptr(true),
 			AllowRebaseMerge: github.Ptr(true),
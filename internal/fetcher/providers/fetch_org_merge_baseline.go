@@ -33,13 +33,23 @@ func (o *orgMergeBaselineFetcher) Scope() data.FetchScope {
 	return data.ScopeOrg
 }
 
+// Permissions: without org ruleset access the baseline is reported as none.
+func (o *orgMergeBaselineFetcher) Permissions() []fetcher.Permission {
+	return []fetcher.Permission{{Scope: "admin:org", FineGrained: "organization_administration:read", Degrades: true}}
+}
+
+// Upstream: the target ref is derived from the scanned repos.
+func (o *orgMergeBaselineFetcher) Upstream() []data.DependencyKey {
+	return []data.DependencyKey{data.DepReposScanned}
+}
+
 func (o *orgMergeBaselineFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
 	// Get scanned repos to determine the most common default branch.
 	scannedResult, err := f.Fetch(ctx, repo, data.DepReposScanned, nil)
 	if err != nil {
 		return nil, err
 	}
-	scannedRepos, ok := scannedResult.([]*github.Repository)
+	scannedRepos, ok := scannedResult.([]*models.ScannedRepo)
 	if !ok {
 		return &models.MergeBaseline{
 			State:    models.BaselineStateNone,
@@ -142,13 +152,13 @@ func (o *orgMergeBaselineFetcher) Fetch(ctx context.Context, repo *github.Reposi
 
 // determineTargetRef finds the most common default branch among scanned repos
 // and returns it as a refs/heads/ ref. Ties are broken lexicographically ascending.
-func determineTargetRef(repos []*github.Repository) string {
+func determineTargetRef(repos []*models.ScannedRepo) string {
 	branchCounts := make(map[string]int)
 	for _, r := range repos {
 		if r == nil {
 			continue
 		}
-		branch := r.GetDefaultBranch()
+		branch := r.DefaultBranch
 		if branch != "" {
 			branchCounts[branch]++
 		}
@@ -11,59 +11,59 @@ import (
 func TestDetermineTargetRef(t *testing.T) {
 	tests := []struct {
 		name  string
-		repos []*github.Repository
+		repos []*models.ScannedRepo
 		want  string
 	}{
 		{
 			name:  "empty repos",
-			repos: []*github.Repository{},
+			repos: []*models.ScannedRepo{},
 			want:  "refs/heads/main",
 		},
 		{
 			name: "single repo with main",
-			repos: []*github.Repository{
-				{DefaultBranch: github.Ptr("main")},
+			repos: []*models.ScannedRepo{
+				{DefaultBranch: "main"},
 			},
 			want: "refs/heads/main",
 		},
 		{
 			name: "single repo with master",
-			repos: []*github.Repository{
-				{DefaultBranch: github.Ptr("master")},
+			repos: []*models.ScannedRepo{
+				{DefaultBranch: "master"},
 			},
 			want: "refs/heads/master",
 		},
 		{
 			name: "main is most common",
-			repos: []*github.Repository{
-				{DefaultBranch: github.Ptr("main")},
-				{DefaultBranch: github.Ptr("main")},
-				{DefaultBranch: github.Ptr("master")},
+			repos: []*models.ScannedRepo{
+				{DefaultBranch: "main"},
+				{DefaultBranch: "main"},
+				{DefaultBranch: "master"},
 			},
 			want: "refs/heads/main",
 		},
 		{
 			name: "tie breaks lexicographically",
-			repos: []*github.Repository{
-				{DefaultBranch: github.Ptr("main")},
-				{DefaultBranch: github.Ptr("develop")},
+			repos: []*models.ScannedRepo{
+				{DefaultBranch: "main"},
+				{DefaultBranch: "develop"},
 			},
 			want: "refs/heads/develop", // 'd' < 'm'
 		},
 		{
 			name: "nil repos are skipped",
-			repos: []*github.Repository{
+			repos: []*models.ScannedRepo{
 				nil,
-				{DefaultBranch: github.Ptr("main")},
+				{DefaultBranch: "main"},
 				nil,
 			},
 			want: "refs/heads/main",
 		},
 		{
 			name: "repos with empty default branch are skipped",
-			repos: []*github.Repository{
-				{DefaultBranch: github.Ptr("")},
-				{DefaultBranch: github.Ptr("main")},
+			repos: []*models.ScannedRepo{
+				{DefaultBranch: ""},
+				{DefaultBranch: "main"},
 			},
 			want: "refs/heads/main",
 		},
@@ -29,6 +29,11 @@ func (p *protectedBranchesDeletionStatusFetcher) Scope() data.FetchScope {
 	return data.ScopeRepo
 }
 
+// Permissions: classic protection rules are only visible to repository admins.
+func (p *protectedBranchesDeletionStatusFetcher) Permissions() []fetcher.Permission {
+	return []fetcher.Permission{{Scope: "repo", FineGrained: "administration:read", Role: "admin"}}
+}
+
 func (p *protectedBranchesDeletionStatusFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
 	owner := repo.GetOwner().GetLogin()
 	repoName := repo.GetName()
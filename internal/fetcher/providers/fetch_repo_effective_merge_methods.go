@@ -29,6 +29,11 @@ func (r *repoEffectiveMergeMethodsFetcher) Scope() data.FetchScope {
 	return data.ScopeRepo
 }
 
+// Upstream: repo merge settings, narrowed by rulesets.
+func (r *repoEffectiveMergeMethodsFetcher) Upstream() []data.DependencyKey {
+	return []data.DependencyKey{data.DepRepoMetadata, data.DepRepoAllRulesets}
+}
+
 func (r *repoEffectiveMergeMethodsFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
 	// Get repo metadata for merge method settings.
 	metaResult, err := f.Fetch(ctx, repo, data.DepRepoMetadata, nil)
@@ -35,13 +35,19 @@ func (r *reposMergeConventionFetcher) Scope() data.FetchScope {
 	return data.ScopeOrg
 }
 
+// Upstream: samples come from the scanned set; metadata is fetched for samples
+// whose merge settings were not captured during discovery.
+func (r *reposMergeConventionFetcher) Upstream() []data.DependencyKey {
+	return []data.DependencyKey{data.DepReposScanned, data.DepRepoMetadata}
+}
+
 func (r *reposMergeConventionFetcher) Fetch(ctx context.Context, repo *github.Repository, _ map[string]string, f *fetcher.Fetcher) (any, error) {
 	// Get scanned repos.
 	scannedResult, err := f.Fetch(ctx, repo, data.DepReposScanned, nil)
 	if err != nil {
 		return nil, err
 	}
-	scannedRepos, ok := scannedResult.([]*github.Repository)
+	scannedRepos, ok := scannedResult.([]*models.ScannedRepo)
 	if !ok {
 		return &models.MergeBaseline{
 			State:    models.BaselineStateNone,
@@ -72,7 +78,7 @@ func (r *reposMergeConventionFetcher) Fetch(ctx context.Context, repo *github.Re
 			continue
 		}
 		maskCounts[mask]++
-		evidence = append(evidence, sampleRepo.GetFullName()+": "+mask.String())
+		evidence = append(evidence, sampleRepo.FullName+": "+mask.String())
 	}
 
 	if len(maskCounts) == 0 {
@@ -105,15 +111,15 @@ func (r *reposMergeConventionFetcher) Fetch(ctx context.Context, repo *github.Re
 
 // sampleReposForConvention returns a deterministic sample of repos.
 // Sorts by lowercase owner/name descending and takes top N.
-func sampleReposForConvention(repos []*github.Repository, n int) []*github.Repository {
+func sampleReposForConvention(repos []*models.ScannedRepo, n int) []*models.ScannedRepo {
 	// Make a copy to avoid mutating the original slice.
-	sorted := make([]*github.Repository, len(repos))
+	sorted := make([]*models.ScannedRepo, len(repos))
 	copy(sorted, repos)
 
 	// Sort by lowercase full name descending for determinism.
 	sort.Slice(sorted, func(i, j int) bool {
-		iName := strings.ToLower(sorted[i].GetFullName())
-		jName := strings.ToLower(sorted[j].GetFullName())
+		iName := strings.ToLower(sorted[i].FullName)
+		jName := strings.ToLower(sorted[j].FullName)
 		return iName > jName // Descending order.
 	})
 
@@ -124,16 +130,22 @@ func sampleReposForConvention(repos []*github.Repository, n int) []*github.Repos
 	return sorted
 }
 
-// getMergeMethodMaskFromRepo extracts the merge method mask from repo metadata.
-// If the repo lacks the necessary fields, it fetches metadata via the fetcher.
-func getMergeMethodMaskFromRepo(ctx context.Context, repo *github.Repository, f *fetcher.Fetcher) models.MergeMethodMask {
+// getMergeMethodMaskFromRepo extracts the merge method mask from the scanned
+// projection. If the projection lacks the necessary fields, it fetches metadata
+// via the fetcher.
+func getMergeMethodMaskFromRepo(ctx context.Context, repo *models.ScannedRepo, f *fetcher.Fetcher) models.MergeMethodMask {
 	// Check if repo already has the merge method booleans.
-	if repo.AllowMergeCommit != nil || repo.AllowSquashMerge != nil || repo.AllowRebaseMerge != nil {
-		return maskFromRepoBools(repo)
+	if repo.HasMergeSettings() {
+		return maskFromMergeBools(repo.AllowMergeCommit, repo.AllowSquashMerge, repo.AllowRebaseMerge)
 	}
 
 	// Fetch full metadata.
-	metaResult, err := f.Fetch(ctx, repo, data.DepRepoMetadata, nil)
+	ref := &github.Repository{
+		Owner:    &github.User{Login: github.Ptr(repo.Owner)},
+		Name:     github.Ptr(repo.Name),
+		FullName: github.Ptr(repo.FullName),
+	}
+	metaResult, err := f.Fetch(ctx, ref, data.DepRepoMetadata, nil)
 	if err != nil {
 		return 0
 	}
@@ -148,15 +160,21 @@ func getMergeMethodMaskFromRepo(ctx context.Context, repo *github.Repository, f
 
 // maskFromRepoBools converts repo boolean settings to a MergeMethodMask.
 func maskFromRepoBools(repo *github.Repository) models.MergeMethodMask {
+	return maskFromMergeBools(repo.AllowMergeCommit, repo.AllowSquashMerge, repo.AllowRebaseMerge)
+}
+
+// maskFromMergeBools converts optional merge-method settings to a MergeMethodMask.
+// Nil settings are treated as disabled.
+func maskFromMergeBools(merge, squash, rebase *bool) models.MergeMethodMask {
 	var mask models.MergeMethodMask
 
-	if repo.GetAllowMergeCommit() {
+	if merge != nil && *merge {
 		mask |= models.MergeMethodMerge
 	}
-	if repo.GetAllowSquashMerge() {
+	if squash != nil && *squash {
 		mask |= models.MergeMethodSquash
 	}
-	if repo.GetAllowRebaseMerge() {
+	if rebase != nil && *rebase {
 		mask |= models.MergeMethodRebase
 	}
 
@@ -11,22 +11,22 @@ import (
 func TestSampleReposForConvention(t *testing.T) {
 	tests := []struct {
 		name     string
-		repos    []*github.Repository
+		repos    []*models.ScannedRepo
 		n        int
 		wantLen  int
 		wantName string // Expected first repo name (descending order).
 	}{
 		{
 			name:    "empty repos",
-			repos:   []*github.Repository{},
+			repos:   []*models.ScannedRepo{},
 			n:       10,
 			wantLen: 0,
 		},
 		{
 			name: "fewer than n repos",
-			repos: []*github.Repository{
-				{FullName: github.Ptr("org/a")},
-				{FullName: github.Ptr("org/b")},
+			repos: []*models.ScannedRepo{
+				{FullName: "org/a"},
+				{FullName: "org/b"},
 			},
 			n:        10,
 			wantLen:  2,
@@ -34,10 +34,10 @@ func TestSampleReposForConvention(t *testing.T) {
 		},
 		{
 			name: "exactly n repos",
-			repos: []*github.Repository{
-				{FullName: github.Ptr("org/a")},
-				{FullName: github.Ptr("org/b")},
-				{FullName: github.Ptr("org/c")},
+			repos: []*models.ScannedRepo{
+				{FullName: "org/a"},
+				{FullName: "org/b"},
+				{FullName: "org/c"},
 			},
 			n:        3,
 			wantLen:  3,
@@ -45,11 +45,11 @@ func TestSampleReposForConvention(t *testing.T) {
 		},
 		{
 			name: "more than n repos",
-			repos: []*github.Repository{
-				{FullName: github.Ptr("org/a")},
-				{FullName: github.Ptr("org/b")},
-				{FullName: github.Ptr("org/c")},
-				{FullName: github.Ptr("org/d")},
+			repos: []*models.ScannedRepo{
+				{FullName: "org/a"},
+				{FullName: "org/b"},
+				{FullName: "org/c"},
+				{FullName: "org/d"},
 			},
 			n:        2,
 			wantLen:  2,
@@ -57,10 +57,10 @@ func TestSampleReposForConvention(t *testing.T) {
 		},
 		{
 			name: "case insensitive sorting",
-			repos: []*github.Repository{
-				{FullName: github.Ptr("org/Abc")},
-				{FullName: github.Ptr("org/xyz")},
-				{FullName: github.Ptr("org/MNO")},
+			repos: []*models.ScannedRepo{
+				{FullName: "org/Abc"},
+				{FullName: "org/xyz"},
+				{FullName: "org/MNO"},
 			},
 			n:        3,
 			wantLen:  3,
@@ -75,8 +75,8 @@ func TestSampleReposForConvention(t *testing.T) {
 				t.Errorf("sampleReposForConvention() returned %d repos, want %d", len(got), tt.wantLen)
 			}
 			if tt.wantName != "" && len(got) > 0 {
-				if got[0].GetFullName() != tt.wantName {
-					t.Errorf("first repo = %q, want %q", got[0].GetFullName(), tt.wantName)
+				if got[0].FullName != tt.wantName {
+					t.Errorf("first repo = %q, want %q", got[0].FullName, tt.wantName)
 				}
 			}
 		})
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"repomedic/internal/data"
+	"repomedic/internal/data/models"
 	"repomedic/internal/fetcher"
 	_ "repomedic/internal/fetcher/providers"
 	gh "repomedic/internal/github"
@@ -41,9 +42,9 @@ func TestReposScannedFetcher_WithInjectedList(t *testing.T) {
 	f := newTestFetcher(t)
 
 	// Inject scanned repos
-	expectedRepos := []*github.Repository{
-		{FullName: github.Ptr("org/repo1")},
-		{FullName: github.Ptr("org/repo2")},
+	expectedRepos := []*models.ScannedRepo{
+		{FullName: "org/repo1"},
+		{FullName: "org/repo2"},
 	}
 	f.SetScannedRepos(expectedRepos)
 
@@ -58,9 +59,9 @@ func TestReposScannedFetcher_WithInjectedList(t *testing.T) {
 		t.Fatalf("Fetch returned error: %v", err)
 	}
 
-	repos, ok := result.([]*github.Repository)
+	repos, ok := result.([]*models.ScannedRepo)
 	if !ok {
-		t.Fatalf("Fetch returned type %T, want []*github.Repository", result)
+		t.Fatalf("Fetch returned type %T, want []*models.ScannedRepo", result)
 	}
 
 	if len(repos) != len(expectedRepos) {
@@ -68,8 +69,8 @@ func TestReposScannedFetcher_WithInjectedList(t *testing.T) {
 	}
 
 	for i, r := range repos {
-		if r.GetFullName() != expectedRepos[i].GetFullName() {
-			t.Errorf("repos[%d].FullName = %q, want %q", i, r.GetFullName(), expectedRepos[i].GetFullName())
+		if r.FullName != expectedRepos[i].FullName {
+			t.Errorf("repos[%d].FullName = %q, want %q", i, r.FullName, expectedRepos[i].FullName)
 		}
 	}
 }
@@ -98,7 +99,7 @@ func TestReposScannedFetcher_WithEmptyList(t *testing.T) {
 	f := newTestFetcher(t)
 
 	// Inject empty list (valid case: no repos discovered)
-	f.SetScannedRepos([]*github.Repository{})
+	f.SetScannedRepos([]*models.ScannedRepo{})
 
 	repo := &github.Repository{
 		Owner: &github.User{Login: github.Ptr("org")},
@@ -110,9 +111,9 @@ func TestReposScannedFetcher_WithEmptyList(t *testing.T) {
 		t.Fatalf("Fetch returned error: %v", err)
 	}
 
-	repos, ok := result.([]*github.Repository)
+	repos, ok := result.([]*models.ScannedRepo)
 	if !ok {
-		t.Fatalf("Fetch returned type %T, want []*github.Repository", result)
+		t.Fatalf("Fetch returned type %T, want []*models.ScannedRepo", result)
 	}
 
 	if len(repos) != 0 {
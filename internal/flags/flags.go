@@ -11,18 +11,30 @@ package flags
 //	arg := "--" + flags.FlagOrg
 const (
 	// Targeting
-	FlagOrg        = "org"
-	FlagUser       = "user"
-	FlagEnterprise = "enterprise"
-	FlagRepos      = "repos"
-	FlagInclude    = "include"
-	FlagExclude    = "exclude"
-	FlagTopic      = "topic"
-	FlagVisibility = "visibility"
-	FlagArchived   = "archived"
-	FlagForks      = "forks"
-	FlagMaxRepos   = "max-repos"
-	FlagDryRun     = "dry-run"
+	FlagOrg           = "org"
+	FlagUser          = "user"
+	FlagEnterprise    = "enterprise"
+	FlagSearch        = "search"
+	FlagTeam          = "team"
+	FlagTeamPerm      = "team-permission"
+	FlagTeamChildren  = "team-children"
+	FlagRepos         = "repos"
+	FlagReposFile     = "repos-file"
+	FlagInclude       = "include"
+	FlagExclude       = "exclude"
+	FlagTopic         = "topic"
+	FlagLanguage      = "language"
+	FlagPushedWithin  = "pushed-within"
+	FlagCreatedBefore = "created-before"
+	FlagMinSize       = "min-size"
+	FlagMaxSize       = "max-size"
+	FlagIsTemplate    = "is-template"
+	FlagProperty      = "property"
+	FlagVisibility    = "visibility"
+	FlagArchived      = "archived"
+	FlagForks         = "forks"
+	FlagMaxRepos      = "max-repos"
+	FlagDryRun        = "dry-run"
 
 	// Rules
 	FlagRules    = "rules"
@@ -33,13 +45,31 @@ const (
 	FlagConsoleFormat       = "console-format"
 	FlagConsoleFilterStatus = "console-filter-status"
 	FlagReport              = "report"
+	FlagReportGroupBy       = "report-group-by"
+	FlagReportTemplate      = "report-template"
 	FlagOut                 = "out"
 	FlagOutFormat           = "out-format"
 	FlagEmit                = "emit"
 	FlagNoConsole           = "no-console"
+	FlagBaseline            = "baseline"
+	FlagUpdateBaseline      = "update-baseline"
+	FlagHistoryDir          = "history-dir"
 
 	// Runtime
-	FlagConcurrency = "concurrency"
-	FlagTimeout     = "timeout"
-	FlagFailFast    = "fail-fast"
+	FlagConcurrency      = "concurrency"
+	FlagFetchConcurrency = "fetch-concurrency"
+	FlagMaxCacheMB       = "max-cache-mb"
+	FlagTimeout          = "timeout"
+	FlagFailFast         = "fail-fast"
+	FlagRequireCoverage  = "require-full-coverage"
+	FlagTokenFile        = "token-file"
+	FlagAppID            = "app-id"
+	FlagAppKeyFile       = "app-key-file"
+	FlagAppInstallation  = "app-installation"
+
+	// Network
+	FlagCAFile     = "ca-file"
+	FlagClientCert = "client-cert"
+	FlagClientKey  = "client-key"
+	FlagProxy      = "proxy"
 )
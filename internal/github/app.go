@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/go-github/v81/github"
+	"golang.org/x/oauth2"
+)
+
+// AppCredentials authenticate as a GitHub App to mint installation tokens.
+type AppCredentials struct {
+	AppID int64
+	Key   *rsa.PrivateKey
+}
+
+// ReadAppPrivateKey reads the App's PEM private key (PKCS#1 as GitHub
+// downloads it, or PKCS#8).
+func ReadAppPrivateKey(path string) (*rsa.PrivateKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read App private key: %w", err)
+	}
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM private key found in %s", path)
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse App private key %s: %w", path, err)
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse App private key %s: %w", path, err)
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("App private key %s is %T, not RSA", path, key)
+		}
+		return rsaKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q in %s", block.Type, path)
+	}
+}
+
+// NewAppInstallationClient creates a client authenticated as one installation
+// of app. Installation tokens expire after an hour, so the client mints a
+// new one shortly before the current one expires; the first token is minted
+// here so bad credentials fail before any scanning starts.
+func NewAppInstallationClient(ctx context.Context, app AppCredentials, installationID int64, opts ...Option) (*Client, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("github client: ctx is nil")
+	}
+	transport, err := newTransport(newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	ts := oauth2.ReuseTokenSource(nil, &installationTokenSource{
+		ctx:            ctx,
+		app:            app,
+		installationID: installationID,
+		api:            github.NewClient(&http.Client{Transport: transport}),
+		now:            time.Now,
+	})
+	if _, err := ts.Token(); err != nil {
+		return nil, err
+	}
+	return newClientWithTokenSource(transport, ts), nil
+}
+
+// installationTokenRefreshMargin is how long before its expiry an
+// installation token is replaced, so no request carries one that expires in
+// flight.
+const installationTokenRefreshMargin = 5 * time.Minute
+
+// installationTokenSource mints installation tokens with a JWT signed by the
+// App's private key.
+type installationTokenSource struct {
+	ctx            context.Context
+	app            AppCredentials
+	installationID int64
+	api            *github.Client
+	now            func() time.Time
+}
+
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := appJWT(s.app, s.now())
+	if err != nil {
+		return nil, err
+	}
+	tok, _, err := s.api.WithAuthToken(jwt).Apps.CreateInstallationToken(s.ctx, s.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("mint token for App installation %d: %w", s.installationID, err)
+	}
+	return &oauth2.Token{
+		AccessToken: tok.GetToken(),
+		TokenType:   "Bearer",
+		Expiry:      tok.GetExpiresAt().Add(-installationTokenRefreshMargin),
+	}, nil
+}
+
+// appJWT returns the RS256 JWT that authenticates as the App. GitHub accepts
+// at most ten minutes of validity; iat is backdated a minute for clock skew.
+func appJWT(app AppCredentials, now time.Time) (string, error) {
+	if app.Key == nil {
+		return "", fmt.Errorf("App %d has no private key", app.AppID)
+	}
+	header, err := json.Marshal(struct {
+		Alg string `json:"alg"`
+		Typ string `json:"typ"`
+	}{"RS256", "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(struct {
+		IssuedAt  int64 `json:"iat"`
+		ExpiresAt int64 `json:"exp"`
+		Issuer    int64 `json:"iss"`
+	}{now.Add(-time.Minute).Unix(), now.Add(9 * time.Minute).Unix(), app.AppID})
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	signed := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	sum := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, app.Key, crypto.SHA256, sum[:])
+	if err != nil {
+		return "", fmt.Errorf("sign App JWT: %w", err)
+	}
+	return signed + "." + enc.EncodeToString(sig), nil
+}
@@ -0,0 +1,169 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v81/github"
+	"golang.org/x/oauth2"
+)
+
+func TestInstallationTokenSource_MintsWithAppJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	now := time.Unix(1760000000, 0)
+	expires := now.Add(time.Hour).UTC()
+
+	mints := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/app/installations/42/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		jwt, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			t.Errorf("expected a bearer JWT, got %q", r.Header.Get("Authorization"))
+		}
+		if iss := verifyTestJWT(t, jwt, &key.PublicKey); iss != 7 {
+			t.Errorf("expected iss 7, got %d", iss)
+		}
+		mints++
+		fmt.Fprintf(w, `{"token":"ghs_minted%d","expires_at":%q}`, mints, expires.Format(time.RFC3339))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	api := github.NewClient(nil)
+	api.BaseURL, _ = url.Parse(server.URL + "/")
+	src := &installationTokenSource{
+		ctx:            context.Background(),
+		app:            AppCredentials{AppID: 7, Key: key},
+		installationID: 42,
+		api:            api,
+		now:            func() time.Time { return now },
+	}
+
+	tok, err := oauth2.ReuseTokenSource(nil, src).Token()
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if tok.AccessToken != "ghs_minted1" {
+		t.Fatalf("expected the minted token, got %q", tok.AccessToken)
+	}
+	if want := expires.Add(-installationTokenRefreshMargin); !tok.Expiry.Equal(want) {
+		t.Fatalf("expected expiry %s, got %s", want, tok.Expiry)
+	}
+}
+
+func TestInstallationTokenSource_ReportsMintFailure(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	}))
+	defer server.Close()
+
+	api := github.NewClient(nil)
+	api.BaseURL, _ = url.Parse(server.URL + "/")
+	src := &installationTokenSource{
+		ctx:            context.Background(),
+		app:            AppCredentials{AppID: 7, Key: key},
+		installationID: 42,
+		api:            api,
+		now:            time.Now,
+	}
+	if _, err := src.Token(); err == nil || !strings.Contains(err.Error(), "App installation 42") {
+		t.Fatalf("expected a mint error naming the installation, got %v", err)
+	}
+}
+
+func TestReadAppPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	dir := t.TempDir()
+	write := func(name, blockType string, der []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		return path
+	}
+
+	for _, path := range []string{
+		write("pkcs1.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key)),
+		write("pkcs8.pem", "PRIVATE KEY", pkcs8),
+	} {
+		got, err := ReadAppPrivateKey(path)
+		if err != nil {
+			t.Fatalf("ReadAppPrivateKey(%s): %v", filepath.Base(path), err)
+		}
+		if !got.Equal(key) {
+			t.Fatalf("ReadAppPrivateKey(%s) returned a different key", filepath.Base(path))
+		}
+	}
+
+	if _, err := ReadAppPrivateKey(write("cert.pem", "CERTIFICATE", []byte("x"))); err == nil {
+		t.Fatal("expected an error for a non-key PEM block")
+	}
+}
+
+// verifyTestJWT checks jwt's RS256 signature and validity window and returns
+// its iss claim.
+func verifyTestJWT(t *testing.T, jwt string, pub *rsa.PublicKey) int64 {
+	t.Helper()
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("malformed JWT %q", jwt)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("decode signature: %v", err)
+	}
+	sum := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, sum[:], sig); err != nil {
+		t.Fatalf("JWT signature does not verify: %v", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("decode claims: %v", err)
+	}
+	var claims struct {
+		IssuedAt  int64 `json:"iat"`
+		ExpiresAt int64 `json:"exp"`
+		Issuer    int64 `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("unmarshal claims: %v", err)
+	}
+	if d := claims.ExpiresAt - claims.IssuedAt; d <= 0 || d > 600 {
+		t.Fatalf("JWT must be valid for at most ten minutes, got %ds", d)
+	}
+	return claims.Issuer
+}
@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v81/github"
+)
+
+// TokenKind identifies the kind of GitHub token from its prefix.
+type TokenKind string
+
+const (
+	TokenKindClassic     TokenKind = "classic PAT"
+	TokenKindFineGrained TokenKind = "fine-grained PAT"
+	TokenKindApp         TokenKind = "GitHub App"
+	TokenKindOAuth       TokenKind = "OAuth"
+	TokenKindGitHubCLI   TokenKind = "gh"
+	TokenKindUnknown     TokenKind = "unknown"
+)
+
+// DetectTokenKind classifies token by its documented prefix. Tokens obtained
+// from the GitHub CLI are reported as TokenKindGitHubCLI regardless of prefix,
+// and minted App installation tokens (never shown to callers) as TokenKindApp.
+func DetectTokenKind(token string, source AuthTokenSource) TokenKind {
+	switch source {
+	case AuthTokenSourceGitHubCL:
+		return TokenKindGitHubCLI
+	case AuthTokenSourceApp:
+		return TokenKindApp
+	}
+	switch {
+	case strings.HasPrefix(token, "ghp_"):
+		return TokenKindClassic
+	case strings.HasPrefix(token, "github_pat_"):
+		return TokenKindFineGrained
+	case strings.HasPrefix(token, "ghs_"), strings.HasPrefix(token, "ghu_"):
+		return TokenKindApp
+	case strings.HasPrefix(token, "gho_"):
+		return TokenKindOAuth
+	case len(token) == 40 && strings.Trim(strings.ToLower(token), "0123456789abcdef") == "":
+		// Legacy classic tokens predate prefixes.
+		return TokenKindClassic
+	default:
+		return TokenKindUnknown
+	}
+}
+
+// HasScopes reports whether tokens of this kind carry OAuth scopes
+// (X-OAuth-Scopes). Fine-grained and App tokens use permissions instead.
+func (k TokenKind) HasScopes() bool {
+	return k == TokenKindClassic || k == TokenKindOAuth || k == TokenKindGitHubCLI
+}
+
+// SSO states for AuthInfo.SSO.
+const (
+	SSOStateOK       = "ok"
+	SSOStateRequired = "required"
+	SSOStatePartial  = "partial"
+	SSOStateError    = "error"
+)
+
+// SSOStatus describes whether the token can access an organization that may
+// enforce SAML single sign-on.
+type SSOStatus struct {
+	Org   string `json:"org"`
+	State string `json:"state"`
+	// URL is where the token can be authorized when State is "required".
+	URL string `json:"url,omitempty"`
+	// Message explains an "error" state.
+	Message string `json:"message,omitempty"`
+}
+
+// AuthInfo is what the API reports about the authenticated token.
+type AuthInfo struct {
+	// Login is empty for tokens not tied to a user (GitHub App installations).
+	Login string `json:"login,omitempty"`
+	// Scopes holds X-OAuth-Scopes; nil when the API did not send the header.
+	Scopes     []string           `json:"scopes"`
+	SSO        *SSOStatus         `json:"sso,omitempty"`
+	RateLimits *github.RateLimits `json:"rate_limits,omitempty"`
+}
+
+// InspectAuth looks up the token's identity, scopes and rate limits. When org
+// is set it also probes the org to report the token's SSO authorization.
+func (c *Client) InspectAuth(ctx context.Context, org string) (*AuthInfo, error) {
+	info := &AuthInfo{}
+
+	user, resp, err := c.Client.Users.Get(ctx, "")
+	if resp != nil {
+		info.Scopes = parseScopesHeader(resp.Header)
+	}
+	switch {
+	case err == nil:
+		info.Login = user.GetLogin()
+	case resp != nil && resp.StatusCode == http.StatusForbidden:
+		// App installation tokens cannot read /user; that is not a failure.
+	default:
+		return nil, fmt.Errorf("failed to look up the authenticated user: %w", err)
+	}
+
+	limits, _, err := c.Client.RateLimit.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limits: %w", err)
+	}
+	info.RateLimits = limits
+
+	if org != "" {
+		info.SSO = c.probeSSO(ctx, org)
+	}
+	return info, nil
+}
+
+func (c *Client) probeSSO(ctx context.Context, org string) *SSOStatus {
+	st := &SSOStatus{Org: org, State: SSOStateOK}
+	_, resp, err := c.Client.Repositories.ListByOrg(ctx, org, &github.RepositoryListByOrgOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	// X-GitHub-SSO: required; url=https://github.com/orgs/acme/sso?authorization_request=...
+	// X-GitHub-SSO: partial-results; organizations=21955855,20582480
+	if resp != nil {
+		if h := resp.Header.Get("X-GitHub-SSO"); h != "" {
+			kind, rest, _ := strings.Cut(h, ";")
+			switch strings.TrimSpace(kind) {
+			case "required":
+				st.State = SSOStateRequired
+				st.URL = strings.TrimPrefix(strings.TrimSpace(rest), "url=")
+				return st
+			case "partial-results":
+				st.State = SSOStatePartial
+			}
+		}
+	}
+	if err != nil {
+		st.State = SSOStateError
+		var er *github.ErrorResponse
+		if errors.As(err, &er) && er.Response != nil {
+			st.Message = fmt.Sprintf("%d %s", er.Response.StatusCode, http.StatusText(er.Response.StatusCode))
+		} else {
+			st.Message = err.Error()
+		}
+	}
+	return st
+}
+
+func parseScopesHeader(h http.Header) []string {
+	if _, ok := h["X-Oauth-Scopes"]; !ok {
+		return nil
+	}
+	scopes := []string{}
+	for _, s := range strings.Split(h.Get("X-OAuth-Scopes"), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// impliedScopes lists the scopes granted by a broader scope.
+var impliedScopes = map[string][]string{
+	"repo":            {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":       {"write:org", "read:org", "manage_runners:org"},
+	"write:org":       {"read:org"},
+	"admin:repo_hook": {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook": {"read:repo_hook"},
+	"user":            {"read:user", "user:email", "user:follow"},
+}
+
+// ScopesGrant reports whether a classic token with scopes grants scope,
+// directly or through a broader scope. An empty scope is always granted.
+func ScopesGrant(scopes []string, scope string) bool {
+	if scope == "" {
+		return true
+	}
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+		if ScopesGrant(impliedScopes[s], scope) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDetectTokenKind(t *testing.T) {
+	tests := []struct {
+		token  string
+		source AuthTokenSource
+		want   TokenKind
+	}{
+		{"ghp_abc", AuthTokenSourceEnv, TokenKindClassic},
+		{"github_pat_abc", AuthTokenSourceEnv, TokenKindFineGrained},
+		{"ghs_abc", AuthTokenSourceEnv, TokenKindApp},
+		{"gho_abc", AuthTokenSourceEnv, TokenKindOAuth},
+		{strings.Repeat("a1", 20), AuthTokenSourceEnv, TokenKindClassic},
+		{"something", AuthTokenSourceEnv, TokenKindUnknown},
+		{"ghp_abc", AuthTokenSourceGitHubCL, TokenKindGitHubCLI},
+	}
+	for _, tt := range tests {
+		if got := DetectTokenKind(tt.token, tt.source); got != tt.want {
+			t.Errorf("DetectTokenKind(%q, %q) = %q, want %q", tt.token, tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestScopesGrant(t *testing.T) {
+	tests := []struct {
+		scopes []string
+		scope  string
+		want   bool
+	}{
+		{nil, "", true},
+		{[]string{"repo"}, "repo", true},
+		{[]string{"repo"}, "public_repo", true},
+		{[]string{"admin:org"}, "read:org", true},
+		{[]string{"read:org"}, "admin:org", false},
+		{[]string{"public_repo"}, "repo", false},
+	}
+	for _, tt := range tests {
+		if got := ScopesGrant(tt.scopes, tt.scope); got != tt.want {
+			t.Errorf("ScopesGrant(%v, %q) = %v, want %v", tt.scopes, tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestInspectAuth_ScopesAndSSO(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", "repo, read:org")
+		_, _ = w.Write([]byte(`{"login":"octocat"}`))
+	})
+	mux.HandleFunc("/rate_limit", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resources":{"core":{"limit":5000,"remaining":4999,"reset":1700000000}}}`))
+	})
+	mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-GitHub-SSO", "required; url=https://github.com/orgs/acme/sso?authorization_request=x")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource protected by organization SAML enforcement."}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(context.Background(), "ghp_test")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	u, _ := url.Parse(server.URL + "/")
+	c.Client.BaseURL = u
+
+	info, err := c.InspectAuth(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("InspectAuth: %v", err)
+	}
+	if info.Login != "octocat" {
+		t.Fatalf("expected login octocat, got %q", info.Login)
+	}
+	if len(info.Scopes) != 2 || info.Scopes[0] != "repo" || info.Scopes[1] != "read:org" {
+		t.Fatalf("unexpected scopes: %v", info.Scopes)
+	}
+	if info.RateLimits == nil || info.RateLimits.Core == nil || info.RateLimits.Core.Remaining != 4999 {
+		t.Fatalf("unexpected rate limits: %+v", info.RateLimits)
+	}
+	if info.SSO == nil || info.SSO.State != SSOStateRequired || !strings.HasPrefix(info.SSO.URL, "https://github.com/orgs/acme/sso") {
+		t.Fatalf("unexpected SSO status: %+v", info.SSO)
+	}
+}
+
+func TestInspectAuth_AppTokenWithoutUser(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+	})
+	mux.HandleFunc("/rate_limit", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"resources":{}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(context.Background(), "ghs_test")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	u, _ := url.Parse(server.URL + "/")
+	c.Client.BaseURL = u
+
+	info, err := c.InspectAuth(context.Background(), "")
+	if err != nil {
+		t.Fatalf("InspectAuth: %v", err)
+	}
+	if info.Login != "" || info.Scopes != nil || info.SSO != nil {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+}
@@ -21,7 +21,8 @@ type options struct {
 	verbose bool
 	// writer controls where verbose HTTP logs are written (typically stderr) so
 	// structured output on stdout (e.g. NDJSON) stays clean and tests can capture logs.
-	writer io.Writer
+	writer  io.Writer
+	network NetworkOptions
 }
 
 type Option func(*options)
@@ -62,6 +63,18 @@ func NewClient(ctx context.Context, token string, opts ...Option) (*Client, erro
 		return nil, fmt.Errorf("github client: ctx is nil")
 	}
 
+	transport, err := newTransport(newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	var ts oauth2.TokenSource
+	if token != "" {
+		ts = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	}
+	return newClientWithTokenSource(transport, ts), nil
+}
+
+func newOptions(opts []Option) *options {
 	o := &options{}
 	for _, apply := range opts {
 		if apply != nil {
@@ -71,13 +84,32 @@ func NewClient(ctx context.Context, token string, opts ...Option) (*Client, erro
 	if o.verbose && o.writer == nil {
 		o.writer = os.Stderr
 	}
+	return o
+}
 
+// newTransport builds the unauthenticated transport for o.
+func newTransport(o *options) (http.RoundTripper, error) {
 	transport := http.DefaultTransport
+	// Verbose runs build the transport too so TLS and proxy decisions are logged.
+	if !o.network.isZero() || o.verbose {
+		var log io.Writer
+		if o.verbose {
+			log = o.writer
+		}
+		t, err := newNetworkTransport(o.network, log)
+		if err != nil {
+			return nil, fmt.Errorf("github client: %w", err)
+		}
+		transport = t
+	}
 	if o.verbose {
 		transport = &loggingRoundTripper{base: transport, w: o.writer}
 	}
-	if token != "" {
-		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return transport, nil
+}
+
+func newClientWithTokenSource(transport http.RoundTripper, ts oauth2.TokenSource) *Client {
+	if ts != nil {
 		transport = &oauth2.Transport{Source: ts, Base: transport}
 	}
 	// Always provide an http.Client so verbose logging works even without a token.
@@ -86,5 +118,5 @@ func NewClient(ctx context.Context, token string, opts ...Option) (*Client, erro
 	return &Client{
 		Client: github.NewClient(tc),
 		HTTP:   tc,
-	}, nil
+	}
 }
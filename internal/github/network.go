@@ -0,0 +1,277 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// NetworkOptions configures TLS trust, client certificates and proxying for
+// GitHub API traffic (REST and GraphQL share the transport).
+type NetworkOptions struct {
+	// CAFile is a PEM bundle trusted in addition to the system roots.
+	CAFile string
+	// ClientCert and ClientKey are a PEM certificate and key presented to
+	// mTLS gateways. Both or neither must be set.
+	ClientCert string
+	ClientKey  string
+	// Proxy is the proxy URL for all requests. Empty keeps the standard
+	// environment handling of http.ProxyFromEnvironment.
+	Proxy string
+	// NoProxy lists hosts Proxy does not apply to (comma-separated, NO_PROXY
+	// syntax). Empty falls back to the NO_PROXY environment variable.
+	NoProxy string
+}
+
+func (n NetworkOptions) isZero() bool {
+	return n == NetworkOptions{}
+}
+
+// WithNetwork applies TLS and proxy settings to the client's transport.
+func WithNetwork(n NetworkOptions) Option {
+	return func(o *options) {
+		o.network = n
+	}
+}
+
+// newNetworkTransport clones http.DefaultTransport and applies n. When log is
+// non-nil, TLS settings are logged once and each host's proxy decision is
+// logged the first time it is made.
+func newNetworkTransport(n NetworkOptions, log io.Writer) (*http.Transport, error) {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("network: default transport is %T, not *http.Transport", http.DefaultTransport)
+	}
+	t := base.Clone()
+	logf := func(format string, args ...any) {
+		if log != nil {
+			_, _ = fmt.Fprintf(log, "[verbose] "+format+"\n", args...)
+		}
+	}
+
+	if n.CAFile != "" || n.ClientCert != "" || n.ClientKey != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if t.TLSClientConfig != nil {
+			tlsConfig = t.TLSClientConfig.Clone()
+		}
+		if n.CAFile != "" {
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			pem, err := os.ReadFile(n.CAFile)
+			if err != nil {
+				return nil, fmt.Errorf("network: read CA file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("network: no PEM certificates found in CA file %s", n.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+			logf("tls: trusting CA bundle %s in addition to system roots", n.CAFile)
+		}
+		if (n.ClientCert == "") != (n.ClientKey == "") {
+			return nil, fmt.Errorf("network: client certificate and key must be set together")
+		}
+		if n.ClientCert != "" {
+			cert, err := tls.LoadX509KeyPair(n.ClientCert, n.ClientKey)
+			if err != nil {
+				return nil, fmt.Errorf("network: load client certificate: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+			logf("tls: presenting client certificate %s", n.ClientCert)
+		}
+		t.TLSClientConfig = tlsConfig
+	}
+
+	if n.Proxy == "" {
+		// Keep the standard library's environment handling (HTTPS_PROXY for
+		// https URLs, HTTP_PROXY for http URLs, NO_PROXY); only log it.
+		if log != nil {
+			t.Proxy = logEnvironmentProxy(proxyFromEnvironment, logf)
+		}
+		return t, nil
+	}
+	pu, err := parseProxyURL(n.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	noProxy := n.NoProxy
+	if noProxy == "" {
+		noProxy = firstEnv("NO_PROXY", "no_proxy")
+	}
+	matcher := parseNoProxy(noProxy)
+	var logged sync.Map
+	t.Proxy = func(req *http.Request) (*url.URL, error) {
+		port := req.URL.Port()
+		if port == "" {
+			port = "80"
+			if req.URL.Scheme == "https" {
+				port = "443"
+			}
+		}
+		direct, why := matcher.bypass(req.URL.Hostname(), port)
+		if log != nil {
+			if _, seen := logged.LoadOrStore(req.URL.Host, true); !seen {
+				if direct {
+					logf("proxy: %s direct (%s)", req.URL.Host, why)
+				} else {
+					logf("proxy: %s via %s", req.URL.Host, redactProxyURL(pu))
+				}
+			}
+		}
+		if direct {
+			return nil, nil
+		}
+		return pu, nil
+	}
+	return t, nil
+}
+
+// proxyFromEnvironment is the proxy function used without --proxy; tests
+// replace it because http.ProxyFromEnvironment reads the environment once.
+var proxyFromEnvironment = http.ProxyFromEnvironment
+
+// logEnvironmentProxy wraps proxy and logs its decision the first time each
+// host is seen.
+func logEnvironmentProxy(proxy func(*http.Request) (*url.URL, error), logf func(string, ...any)) func(*http.Request) (*url.URL, error) {
+	var logged sync.Map
+	return func(req *http.Request) (*url.URL, error) {
+		u, err := proxy(req)
+		if err != nil {
+			return nil, err
+		}
+		if _, seen := logged.LoadOrStore(req.URL.Host, true); !seen {
+			if u == nil {
+				logf("proxy: %s direct (no proxy from environment)", req.URL.Host)
+			} else {
+				logf("proxy: %s via %s (from environment)", req.URL.Host, redactProxyURL(u))
+			}
+		}
+		return u, nil
+	}
+}
+
+func firstEnv(names ...string) string {
+	for _, n := range names {
+		if v := strings.TrimSpace(os.Getenv(n)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseProxyURL accepts http, https and socks5 proxies; a bare host:port is
+// treated as http.
+func parseProxyURL(raw string) (*url.URL, error) {
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("network: invalid proxy URL %q", raw)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	default:
+		return nil, fmt.Errorf("network: unsupported proxy scheme %q (must be one of: http, https, socks5)", u.Scheme)
+	}
+}
+
+// redactProxyURL drops credentials from u for logging.
+func redactProxyURL(u *url.URL) string {
+	c := *u
+	c.User = nil
+	return c.String()
+}
+
+// noProxyMatcher implements the common NO_PROXY semantics: "*" bypasses every
+// host; IPs and CIDRs match addresses; "example.com" and ".example.com" match
+// the domain and its subdomains; an entry may be limited to a port with
+// ":port". Loopback hosts are always reached directly.
+type noProxyMatcher struct {
+	all     bool
+	entries []noProxyEntry
+}
+
+type noProxyEntry struct {
+	raw    string
+	domain string
+	ip     net.IP
+	cidr   *net.IPNet
+	port   string
+}
+
+func parseNoProxy(s string) noProxyMatcher {
+	var m noProxyMatcher
+	for _, raw := range strings.Split(s, ",") {
+		raw = strings.ToLower(strings.TrimSpace(raw))
+		if raw == "" {
+			continue
+		}
+		if raw == "*" {
+			m.all = true
+			continue
+		}
+		e := noProxyEntry{raw: raw}
+		if _, cidr, err := net.ParseCIDR(raw); err == nil {
+			e.cidr = cidr
+			m.entries = append(m.entries, e)
+			continue
+		}
+		host := raw
+		if h, p, err := net.SplitHostPort(raw); err == nil {
+			host, e.port = h, p
+		}
+		if ip := net.ParseIP(host); ip != nil {
+			e.ip = ip
+		} else {
+			e.domain = strings.TrimPrefix(host, "*")
+			e.domain = strings.TrimPrefix(e.domain, ".")
+		}
+		m.entries = append(m.entries, e)
+	}
+	return m
+}
+
+// bypass reports whether host:port should skip the proxy, and why.
+func (m noProxyMatcher) bypass(host, port string) (bool, string) {
+	host = strings.ToLower(host)
+	if host == "localhost" {
+		return true, "loopback"
+	}
+	ip := net.ParseIP(host)
+	if ip != nil && ip.IsLoopback() {
+		return true, "loopback"
+	}
+	if m.all {
+		return true, "NO_PROXY=*"
+	}
+	for _, e := range m.entries {
+		if e.port != "" && e.port != port {
+			continue
+		}
+		switch {
+		case e.cidr != nil:
+			if ip != nil && e.cidr.Contains(ip) {
+				return true, "matches NO_PROXY entry " + e.raw
+			}
+		case e.ip != nil:
+			if ip != nil && e.ip.Equal(ip) {
+				return true, "matches NO_PROXY entry " + e.raw
+			}
+		case e.domain != "":
+			if host == e.domain || strings.HasSuffix(host, "."+e.domain) {
+				return true, "matches NO_PROXY entry " + e.raw
+			}
+		}
+	}
+	return false, ""
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNoProxyMatcher(t *testing.T) {
+	m := parseNoProxy("corp.example, .internal, 10.0.0.0/8, 192.168.1.5, ghes.example:8443")
+	tests := []struct {
+		host, port string
+		want       bool
+	}{
+		{"corp.example", "443", true},
+		{"api.corp.example", "443", true},
+		{"notcorp.example", "443", false},
+		{"git.internal", "443", true},
+		{"10.1.2.3", "443", true},
+		{"11.1.2.3", "443", false},
+		{"192.168.1.5", "80", true},
+		{"ghes.example", "8443", true},
+		{"ghes.example", "443", false},
+		{"localhost", "443", true},
+		{"127.0.0.1", "443", true},
+		{"api.github.com", "443", false},
+	}
+	for _, tt := range tests {
+		if got, _ := m.bypass(tt.host, tt.port); got != tt.want {
+			t.Errorf("bypass(%q, %q) = %v, want %v", tt.host, tt.port, got, tt.want)
+		}
+	}
+
+	if got, _ := parseNoProxy("*").bypass("api.github.com", "443"); !got {
+		t.Errorf("expected * to bypass every host")
+	}
+}
+
+func TestNewClient_ProxyAndVerboseDecision(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Plain HTTP proxies receive the absolute target URL.
+		proxied = append(proxied, r.URL.String())
+		_, _ = w.Write([]byte("{}"))
+	}))
+	t.Cleanup(proxy.Close)
+
+	var log bytes.Buffer
+	c, err := NewClient(context.Background(), "test-token",
+		WithVerbose(true, &log),
+		WithNetwork(NetworkOptions{Proxy: proxy.URL, NoProxy: "direct.example"}),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	base, _ := url.Parse("http://ghes.example/api/v3/")
+	c.Client.BaseURL = base
+
+	req, err := c.Client.NewRequest("GET", "rate_limit", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if _, err := c.Client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://ghes.example/api/v3/rate_limit" {
+		t.Fatalf("expected the request to go through the proxy, got %v", proxied)
+	}
+	if !strings.Contains(log.String(), "[verbose] proxy: ghes.example via "+proxy.URL) {
+		t.Fatalf("expected the proxy decision to be logged, got:\n%s", log.String())
+	}
+}
+
+func TestNewClient_VerboseKeepsEnvironmentProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		_, _ = w.Write([]byte("{}"))
+	}))
+	t.Cleanup(proxy.Close)
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	// Without --proxy the decision is the standard library's, not a
+	// HTTPS_PROXY/HTTP_PROXY fallback of our own.
+	t.Setenv("HTTPS_PROXY", "http://unused.invalid:1")
+	var asked []string
+	orig := proxyFromEnvironment
+	proxyFromEnvironment = func(req *http.Request) (*url.URL, error) {
+		asked = append(asked, req.URL.Host)
+		return proxyURL, nil
+	}
+	t.Cleanup(func() { proxyFromEnvironment = orig })
+
+	var log bytes.Buffer
+	c, err := NewClient(context.Background(), "test-token", WithVerbose(true, &log))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	base, _ := url.Parse("http://ghes.example/api/v3/")
+	c.Client.BaseURL = base
+
+	req, err := c.Client.NewRequest("GET", "rate_limit", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if _, err := c.Client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if len(asked) == 0 || asked[0] != "ghes.example" {
+		t.Fatalf("expected the environment proxy function to decide, got %v", asked)
+	}
+	if len(proxied) != 1 {
+		t.Fatalf("expected the request to go through the environment's proxy, got %v", proxied)
+	}
+	if !strings.Contains(log.String(), "[verbose] proxy: ghes.example via "+proxy.URL+" (from environment)") {
+		t.Fatalf("expected the environment proxy decision to be logged, got:\n%s", log.String())
+	}
+}
+
+func TestNewClient_CAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("{}"))
+	}))
+	t.Cleanup(server.Close)
+	base, _ := url.Parse(server.URL + "/")
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	get := func(opts NetworkOptions) error {
+		c, err := NewClient(context.Background(), "", WithNetwork(opts))
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		c.Client.BaseURL = base
+		req, err := c.Client.NewRequest("GET", "rate_limit", nil)
+		if err != nil {
+			t.Fatalf("NewRequest: %v", err)
+		}
+		_, err = c.Client.Do(context.Background(), req, nil)
+		return err
+	}
+
+	// NoProxy keeps any proxy from the environment out of the way.
+	if err := get(NetworkOptions{NoProxy: "*"}); err == nil {
+		t.Fatalf("expected an unknown-authority error without --ca-file")
+	}
+	if err := get(NetworkOptions{CAFile: caFile, NoProxy: "*"}); err != nil {
+		t.Fatalf("expected the CA bundle to be trusted: %v", err)
+	}
+}
+
+func TestNewClient_NetworkOptionErrors(t *testing.T) {
+	dir := t.TempDir()
+	empty := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a cert"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	for name, opts := range map[string]NetworkOptions{
+		"missing CA file":     {CAFile: filepath.Join(dir, "missing.pem")},
+		"CA file without PEM": {CAFile: empty},
+		"cert without key":    {ClientCert: empty},
+		"bad key pair":        {ClientCert: empty, ClientKey: empty},
+		"bad proxy scheme":    {Proxy: "ftp://proxy.example"},
+	} {
+		if _, err := NewClient(context.Background(), "", WithNetwork(opts)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
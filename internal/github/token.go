@@ -3,10 +3,12 @@ package github
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 	"time"
+	"unicode"
 )
 
 type AuthTokenSource string
@@ -15,6 +17,9 @@ const (
 	AuthTokenSourceExplicit AuthTokenSource = "explicit"
 	AuthTokenSourceEnv      AuthTokenSource = "env:GITHUB_TOKEN"
 	AuthTokenSourceGitHubCL AuthTokenSource = "gh"
+	AuthTokenSourceFile     AuthTokenSource = "file"
+	AuthTokenSourceEnvPool  AuthTokenSource = "env:GITHUB_TOKENS"
+	AuthTokenSourceApp      AuthTokenSource = "app"
 )
 
 // ResolveAuthToken resolves a GitHub access token.
@@ -44,6 +49,62 @@ func ResolveAuthToken(ctx context.Context, provided string) (token string, sourc
 	return "", "", nil
 }
 
+// ResolveAuthTokens resolves the tokens of a scan's client pool.
+//
+// Precedence:
+//  1. tokenFile (if non-empty): one token per line; blank lines and # comments are ignored
+//  2. GITHUB_TOKENS env var: tokens separated by commas or whitespace
+//  3. the single token from ResolveAuthToken
+//
+// Duplicate tokens are dropped. It never prints the tokens.
+func ResolveAuthTokens(ctx context.Context, tokenFile string) (tokens []string, source AuthTokenSource, err error) {
+	if tokenFile != "" {
+		b, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("read token file: %w", err)
+		}
+		var fields []string
+		for _, line := range strings.Split(string(b), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			fields = append(fields, line)
+		}
+		tokens = dedupeTokens(fields)
+		if len(tokens) == 0 {
+			return nil, "", fmt.Errorf("token file %s contains no tokens", tokenFile)
+		}
+		return tokens, AuthTokenSourceFile, nil
+	}
+
+	if env := os.Getenv("GITHUB_TOKENS"); strings.TrimSpace(env) != "" {
+		fields := strings.FieldsFunc(env, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		return dedupeTokens(fields), AuthTokenSourceEnvPool, nil
+	}
+
+	tok, source, err := ResolveAuthToken(ctx, "")
+	if err != nil || tok == "" {
+		return nil, source, err
+	}
+	return []string{tok}, source, nil
+}
+
+func dedupeTokens(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	out := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
 func tokenFromGitHubCLI(ctx context.Context) (token string, ok bool, err error) {
 	_, lookErr := exec.LookPath("gh")
 	if lookErr != nil {
@@ -130,3 +130,58 @@ func TestResolveAuthToken(t *testing.T) {
 		}
 	})
 }
+
+func TestResolveAuthTokens(t *testing.T) {
+	t.Run("token file wins", func(t *testing.T) {
+		t.Setenv("GITHUB_TOKENS", "env-a,env-b")
+		path := filepath.Join(t.TempDir(), "tokens")
+		if err := os.WriteFile(path, []byte("# pool\nfile-a\n\nfile-b\nfile-a\n"), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+
+		toks, src, err := ResolveAuthTokens(context.Background(), path)
+		if err != nil {
+			t.Fatalf("ResolveAuthTokens error: %v", err)
+		}
+		if src != AuthTokenSourceFile || len(toks) != 2 || toks[0] != "file-a" || toks[1] != "file-b" {
+			t.Fatalf("unexpected tokens %v from %q", toks, src)
+		}
+	})
+
+	t.Run("empty token file is an error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tokens")
+		if err := os.WriteFile(path, []byte("# none\n"), 0o600); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if _, _, err := ResolveAuthTokens(context.Background(), path); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+
+	t.Run("GITHUB_TOKENS split on commas and whitespace", func(t *testing.T) {
+		t.Setenv("GITHUB_TOKENS", " a, b\nc  a ")
+		t.Setenv("GITHUB_TOKEN", "single")
+
+		toks, src, err := ResolveAuthTokens(context.Background(), "")
+		if err != nil {
+			t.Fatalf("ResolveAuthTokens error: %v", err)
+		}
+		if src != AuthTokenSourceEnvPool || len(toks) != 3 || toks[0] != "a" || toks[1] != "b" || toks[2] != "c" {
+			t.Fatalf("unexpected tokens %v from %q", toks, src)
+		}
+	})
+
+	t.Run("falls back to a single token", func(t *testing.T) {
+		t.Setenv("GITHUB_TOKENS", "")
+		t.Setenv("GITHUB_TOKEN", "single")
+		t.Setenv("PATH", t.TempDir())
+
+		toks, src, err := ResolveAuthTokens(context.Background(), "")
+		if err != nil {
+			t.Fatalf("ResolveAuthTokens error: %v", err)
+		}
+		if src != AuthTokenSourceEnv || len(toks) != 1 || toks[0] != "single" {
+			t.Fatalf("unexpected tokens %v from %q", toks, src)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"repomedic/internal/rules"
+	"sort"
+)
+
+// Baseline is the set of failures accepted before enforcement started (see
+// --baseline). A FAIL result is baselined when the baseline holds the same
+// finding (rule, repo and artifact).
+//
+// ERROR results are never baselined: they mean the rule could not be
+// evaluated (an API or permission failure), not an accepted finding, so they
+// must keep surfacing as exit code 2. ERROR entries in an older baseline file
+// are ignored.
+type Baseline struct {
+	findings map[string]struct{}
+}
+
+// NewBaseline builds a baseline from the FAIL results of a scan.
+func NewBaseline(results []rules.Result) *Baseline {
+	b := &Baseline{findings: make(map[string]struct{})}
+	for _, r := range results {
+		if r.Status == rules.StatusFail {
+			b.findings[findingIdentity(r)] = struct{}{}
+		}
+	}
+	return b
+}
+
+// LoadBaseline reads a baseline from a JSON or NDJSON scan output, such as a
+// previous run's --out file or one written by WriteBaseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open baseline: %w", err)
+	}
+	defer f.Close()
+	scan, err := ReadScanOutput(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	return NewBaseline(scan.Results), nil
+}
+
+// Len returns the number of findings in the baseline.
+func (b *Baseline) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.findings)
+}
+
+// Mark returns r with Baselined set if r is a FAIL recorded in the
+// baseline. A nil baseline marks nothing.
+func (b *Baseline) Mark(r rules.Result) rules.Result {
+	if b == nil || r.Status != rules.StatusFail {
+		return r
+	}
+	if _, ok := b.findings[findingIdentity(r)]; ok {
+		r.Baselined = true
+	}
+	return r
+}
+
+// WriteBaseline replaces the baseline at path with the FAIL results, as a
+// JSON array sorted by repo, rule and artifact.
+func WriteBaseline(path string, results []rules.Result) error {
+	findings := make([]rules.Result, 0, len(results))
+	for _, r := range results {
+		if r.Status == rules.StatusFail {
+			r.Baselined = false
+			findings = append(findings, r)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Artifact < b.Artifact
+	})
+	return writeFileAtomic(path, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(findings)
+	})
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"path/filepath"
+	"repomedic/internal/rules"
+	"strings"
+	"testing"
+)
+
+func TestBaseline_Mark(t *testing.T) {
+	b := NewBaseline([]rules.Result{
+		{Repo: "Acme/A", RuleID: "readme-root-exists", Status: rules.StatusFail, Message: "3 files"},
+		{Repo: "acme/a", RuleID: "codeowners-exists", Status: rules.StatusError},
+		{Repo: "acme/a", RuleID: "workflow-pinned", Artifact: ".github/workflows/ci.yml", Status: rules.StatusFail},
+		{Repo: "acme/a", RuleID: "license-exists", Status: rules.StatusPass},
+	})
+	if b.Len() != 2 {
+		t.Fatalf("expected 2 baselined findings, got %d", b.Len())
+	}
+
+	tests := []struct {
+		name string
+		r    rules.Result
+		want bool
+	}{
+		{"same finding, repo case and message differ", rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail, Message: "4 files"}, true},
+		{"error became fail", rules.Result{Repo: "acme/a", RuleID: "codeowners-exists", Status: rules.StatusFail}, false},
+		{"error is never baselined", rules.Result{Repo: "acme/a", RuleID: "codeowners-exists", Status: rules.StatusError}, false},
+		{"fail became error", rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusError}, false},
+		{"same artifact", rules.Result{Repo: "acme/a", RuleID: "workflow-pinned", Artifact: ".github/workflows/ci.yml", Status: rules.StatusFail}, true},
+		{"other artifact", rules.Result{Repo: "acme/a", RuleID: "workflow-pinned", Artifact: ".github/workflows/release.yml", Status: rules.StatusFail}, false},
+		{"pass is never baselined", rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusPass}, false},
+		{"previously passing", rules.Result{Repo: "acme/a", RuleID: "license-exists", Status: rules.StatusFail}, false},
+		{"other repo", rules.Result{Repo: "acme/b", RuleID: "readme-root-exists", Status: rules.StatusFail}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Mark(tt.r).Baselined; got != tt.want {
+				t.Fatalf("Baselined = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var nilBaseline *Baseline
+	if nilBaseline.Mark(rules.Result{RuleID: "r", Status: rules.StatusFail}).Baselined {
+		t.Fatalf("expected a nil baseline to mark nothing")
+	}
+}
+
+func TestBaseline_WriteAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	results := []rules.Result{
+		{Repo: "acme/b", RuleID: "readme-root-exists", Status: rules.StatusFail, Baselined: true},
+		{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusPass},
+		{Repo: "acme/a", RuleID: "codeowners-exists", Status: rules.StatusError},
+	}
+	if err := WriteBaseline(path, results); err != nil {
+		t.Fatalf("WriteBaseline failed: %v", err)
+	}
+
+	b, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatalf("LoadBaseline failed: %v", err)
+	}
+	if b.Len() != 1 {
+		t.Fatalf("expected only the FAIL to be written, got %d findings", b.Len())
+	}
+	if !b.Mark(results[0]).Baselined || b.Mark(results[2]).Baselined {
+		t.Fatalf("expected the written FAIL, and not the ERROR, to be baselined")
+	}
+}
+
+func TestLoadBaseline_MissingFile(t *testing.T) {
+	_, err := LoadBaseline(filepath.Join(t.TempDir(), "missing.json"))
+	if err == nil || !strings.Contains(err.Error(), "failed to open baseline") {
+		t.Fatalf("expected open error, got %v", err)
+	}
+}
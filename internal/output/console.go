@@ -95,14 +95,33 @@ func (s *ConsoleSink) writeLocked(v any) error {
 			return nil
 		}
 	case "text":
+		if e, ok := v.(Event); ok {
+			if e.Type == EventRunInterrupted {
+				if err := printf("Scan interrupted (%s): partial results for %d of %d repositories.\n", e.Reason, e.Evaluated, e.Repos); err != nil {
+					return err
+				}
+			}
+			// The run's final event carries the baseline split when --baseline is used.
+			if e.Baseline != nil {
+				if err := printf("Findings: %d new, %d baselined.\n", e.Baseline.New, e.Baseline.Baselined); err != nil {
+					return err
+				}
+			}
+			return flushIfPossible(s.writer)
+		}
 		r, ok := v.(rules.Result)
 		if !ok {
-			// Ignore events in text mode.
+			// Ignore other events in text mode.
 			return nil
 		}
 		if err := printf("[%s] %s: %s", r.Status, r.Repo, r.RuleID); err != nil {
 			return err
 		}
+		if r.Baselined {
+			if err := printf(" (baselined)"); err != nil {
+				return err
+			}
+		}
 		if r.Message != "" {
 			if err := printf(" - %s", r.Message); err != nil {
 				return err
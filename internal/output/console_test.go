@@ -165,3 +165,39 @@ func TestConsoleSink_Filtering_NDJSON(t *testing.T) {
 		t.Errorf("expected output for FAIL, got: %s", buf.String())
 	}
 }
+
+func TestConsoleSink_Text_PrintsRunInterrupted(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewConsoleSink(&buf, "text", nil)
+
+	if err := s.Write(Event{Type: "run.finished"}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected other events to be ignored in text mode, got %q", buf.String())
+	}
+
+	if err := s.Write(Event{Type: EventRunInterrupted, Repos: 5, Evaluated: 2, Reason: "interrupt signal received"}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	want := "Scan interrupted (interrupt signal received): partial results for 2 of 5 repositories.\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestConsoleSink_Text_MarksBaselinedFindings(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewConsoleSink(&buf, "text", nil)
+
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail, Baselined: true})
+	_ = s.Write(Event{Type: "run.finished", Baseline: &BaselineSummary{New: 1, Baselined: 4}})
+
+	out := buf.String()
+	if !strings.Contains(out, "readme-root-exists (baselined)") {
+		t.Fatalf("expected baselined marker, got %q", out)
+	}
+	if !strings.HasSuffix(out, "Findings: 1 new, 4 baselined.\n") {
+		t.Fatalf("expected baseline summary, got %q", out)
+	}
+}
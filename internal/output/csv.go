@@ -0,0 +1,283 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CSVSink writes results as delimited text on Close, for spreadsheets.
+//
+// Formats:
+//   - csv, tsv: one row per result with the columns repo, rule, status,
+//     severity, category, message and artifact, followed by one
+//     "evidence.<key>" column per evidence key and one "metadata.<path>"
+//     column per flattened metadata path, each group sorted by name.
+//   - csv-matrix, tsv-matrix: one row per repo and one column per rule (sorted),
+//     with the rule's status in each cell; empty when the rule did not run.
+//
+// When repos were targeted with --team, both layouts add a "teams" column
+// after the fixed columns, with the responsible teams joined with "; ".
+//
+// Metadata is flattened by joining nested object keys with "."; lists of
+// scalars become values joined with "; " and any other list is written as
+// JSON. Cells starting with =, +, -, @, tab or carriage return are prefixed
+// with a single quote so spreadsheets do not evaluate them as formulas.
+type CSVSink struct {
+	path    string
+	comma   rune
+	matrix  bool
+	mu      sync.Mutex
+	results []rules.Result
+}
+
+func NewCSVSink(path string, format string) (*CSVSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("output path required")
+	}
+	s := &CSVSink{path: path, comma: ','}
+	switch format {
+	case "csv":
+	case "tsv":
+		s.comma = '\t'
+	case "csv-matrix":
+		s.matrix = true
+	case "tsv-matrix":
+		s.comma, s.matrix = '\t', true
+	default:
+		return nil, fmt.Errorf("unsupported output format: %s", format)
+	}
+
+	dir := filepath.Dir(path)
+	if dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	return s, nil
+}
+
+func (s *CSVSink) Write(v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r, ok := v.(rules.Result); ok {
+		s.results = append(s.results, r)
+	}
+	return nil
+}
+
+func (s *CSVSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var table [][]string
+	if s.matrix {
+		table = resultMatrix(s.results)
+	} else {
+		table = resultRows(s.results)
+	}
+	return writeFileAtomic(s.path, func(w io.Writer) error {
+		cw := csv.NewWriter(w)
+		cw.Comma = s.comma
+		for _, row := range table {
+			for i := range row {
+				row[i] = escapeFormula(row[i])
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	})
+}
+
+func resultRows(results []rules.Result) [][]string {
+	evidenceKeys := make(map[string]struct{})
+	metadata := make([]map[string]string, len(results))
+	metadataKeys := make(map[string]struct{})
+	for i, r := range results {
+		for k := range r.Evidence {
+			evidenceKeys[k] = struct{}{}
+		}
+		metadata[i] = flattenMetadata(r.Metadata)
+		for k := range metadata[i] {
+			metadataKeys[k] = struct{}{}
+		}
+	}
+	evCols := sortedKeys(evidenceKeys)
+	mdCols := sortedKeys(metadataKeys)
+	withTeams := hasTeams(results)
+
+	header := []string{"repo", "rule", "status", "severity", "category", "message", "artifact"}
+	if withTeams {
+		header = append(header, "teams")
+	}
+	for _, k := range evCols {
+		header = append(header, "evidence."+k)
+	}
+	for _, k := range mdCols {
+		header = append(header, "metadata."+k)
+	}
+
+	table := [][]string{header}
+	for i, r := range results {
+		row := []string{r.Repo, r.RuleID, string(r.Status), getSeverity(r.RuleID), getCategory(r.RuleID), r.Message, r.Artifact}
+		if withTeams {
+			row = append(row, strings.Join(r.Teams, "; "))
+		}
+		for _, k := range evCols {
+			row = append(row, r.Evidence[k])
+		}
+		for _, k := range mdCols {
+			row = append(row, metadata[i][k])
+		}
+		table = append(table, row)
+	}
+	return table
+}
+
+func resultMatrix(results []rules.Result) [][]string {
+	ruleSet := make(map[string]struct{})
+	cells := make(map[string]map[string]string)
+	teams := make(map[string][]string)
+	for _, r := range results {
+		ruleSet[r.RuleID] = struct{}{}
+		if cells[r.Repo] == nil {
+			cells[r.Repo] = make(map[string]string)
+		}
+		cells[r.Repo][r.RuleID] = string(r.Status)
+		if len(r.Teams) > 0 {
+			teams[r.Repo] = r.Teams
+		}
+	}
+	ruleIDs := sortedKeys(ruleSet)
+	repos := make([]string, 0, len(cells))
+	for repo := range cells {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	header := []string{"repo"}
+	if len(teams) > 0 {
+		header = append(header, "teams")
+	}
+	table := [][]string{append(header, ruleIDs...)}
+	for _, repo := range repos {
+		row := []string{repo}
+		if len(teams) > 0 {
+			row = append(row, strings.Join(teams[repo], "; "))
+		}
+		for _, id := range ruleIDs {
+			row = append(row, cells[repo][id])
+		}
+		table = append(table, row)
+	}
+	return table
+}
+
+func hasTeams(results []rules.Result) bool {
+	for _, r := range results {
+		if len(r.Teams) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenMetadata flattens a result's metadata into dotted paths. Values are
+// normalized through JSON so typed slices and maps flatten like decoded ones;
+// a value JSON cannot encode is written with %v.
+func flattenMetadata(md map[string]any) map[string]string {
+	if len(md) == 0 {
+		return nil
+	}
+	out := make(map[string]string)
+	for k, v := range md {
+		b, err := json.Marshal(v)
+		if err != nil {
+			out[k] = fmt.Sprintf("%v", v)
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		var normalized any
+		if err := dec.Decode(&normalized); err != nil {
+			out[k] = string(b)
+			continue
+		}
+		flattenValue(out, k, normalized)
+	}
+	return out
+}
+
+func flattenValue(out map[string]string, prefix string, v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			flattenValue(out, prefix+"."+k, val)
+		}
+	case []any:
+		parts := make([]string, 0, len(t))
+		for _, e := range t {
+			s, ok := scalarString(e)
+			if !ok {
+				b, _ := json.Marshal(t)
+				out[prefix] = string(b)
+				return
+			}
+			parts = append(parts, s)
+		}
+		out[prefix] = strings.Join(parts, "; ")
+	default:
+		s, _ := scalarString(t)
+		out[prefix] = s
+	}
+}
+
+func scalarString(v any) (string, bool) {
+	switch t := v.(type) {
+	case nil:
+		return "", true
+	case string:
+		return t, true
+	case json.Number:
+		return t.String(), true
+	case bool:
+		if t {
+			return "true", true
+		}
+		return "false", true
+	default:
+		return "", false
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapeFormula neutralizes cells a spreadsheet would evaluate as a formula.
+func escapeFormula(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,111 @@
+package output
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"reflect"
+	"repomedic/internal/rules"
+	"testing"
+)
+
+func writeCSVSink(t *testing.T, format string, results ...rules.Result) [][]string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "out", "results."+format)
+	s, err := NewCSVSink(path, format)
+	if err != nil {
+		t.Fatalf("NewCSVSink: %v", err)
+	}
+	for _, r := range results {
+		_ = s.Write(r)
+	}
+	_ = s.Write(Event{Type: "run.finished"})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	if format == "tsv" || format == "tsv-matrix" {
+		r.Comma = '\t'
+	}
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("output does not parse as %s: %v", format, err)
+	}
+	return rows
+}
+
+func TestCSVSink_RowPerResultWithFlattenedEvidence(t *testing.T) {
+	rows := writeCSVSink(t, "csv",
+		rules.Result{
+			Repo: "org/a", RuleID: "repo-visibility-public", Status: rules.StatusFail,
+			Message:  "public, and\n\"unexpected\"",
+			Evidence: map[string]string{"visibility": "public"},
+			Metadata: map[string]any{"teams": []string{"a", "b"}, "counts": map[string]int{"admins": 2}, "rules": []map[string]string{{"id": "x"}}},
+		},
+		rules.Result{Repo: "org/b", RuleID: "codeowners-exists", Status: rules.StatusPass, Artifact: "CODEOWNERS", Message: "=HYPERLINK(\"x\")"},
+	)
+
+	wantHeader := []string{"repo", "rule", "status", "severity", "category", "message", "artifact",
+		"evidence.visibility", "metadata.counts.admins", "metadata.rules", "metadata.teams"}
+	if !reflect.DeepEqual(rows[0], wantHeader) {
+		t.Fatalf("header = %q, want %q", rows[0], wantHeader)
+	}
+	wantA := []string{"org/a", "repo-visibility-public", "FAIL", SeverityHigh, CategoryExposure, "public, and\n\"unexpected\"", "",
+		"public", "2", `[{"id":"x"}]`, "a; b"}
+	if !reflect.DeepEqual(rows[1], wantA) {
+		t.Fatalf("row = %q, want %q", rows[1], wantA)
+	}
+	if rows[2][5] != "'=HYPERLINK(\"x\")" || rows[2][6] != "CODEOWNERS" || rows[2][7] != "" {
+		t.Fatalf("unexpected row: %q", rows[2])
+	}
+}
+
+func TestCSVSink_Matrix(t *testing.T) {
+	rows := writeCSVSink(t, "tsv-matrix",
+		rules.Result{Repo: "org/b", RuleID: "rule-b", Status: rules.StatusError},
+		rules.Result{Repo: "org/a", RuleID: "rule-b", Status: rules.StatusPass},
+		rules.Result{Repo: "org/a", RuleID: "rule-a", Status: rules.StatusFail},
+	)
+	want := [][]string{
+		{"repo", "rule-a", "rule-b"},
+		{"org/a", "FAIL", "PASS"},
+		{"org/b", "", "ERROR"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("matrix = %q, want %q", rows, want)
+	}
+}
+
+func TestCSVSink_TeamsColumn(t *testing.T) {
+	teams := []string{"org/platform", "org/sre"}
+	results := []rules.Result{
+		{Repo: "org/a", RuleID: "rule-a", Status: rules.StatusFail, Teams: teams},
+		{Repo: "org/b", RuleID: "rule-a", Status: rules.StatusPass},
+	}
+
+	rows := writeCSVSink(t, "csv", results...)
+	if rows[0][7] != "teams" || rows[1][7] != "org/platform; org/sre" || rows[2][7] != "" {
+		t.Fatalf("unexpected teams column: %q", rows)
+	}
+
+	want := [][]string{
+		{"repo", "teams", "rule-a"},
+		{"org/a", "org/platform; org/sre", "FAIL"},
+		{"org/b", "", "PASS"},
+	}
+	if rows := writeCSVSink(t, "csv-matrix", results...); !reflect.DeepEqual(rows, want) {
+		t.Fatalf("matrix = %q, want %q", rows, want)
+	}
+}
+
+func TestNewCSVSink_UnsupportedFormat(t *testing.T) {
+	if _, err := NewCSVSink(filepath.Join(t.TempDir(), "x.csv"), "xlsx"); err == nil {
+		t.Fatalf("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,361 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"repomedic/internal/rules"
+	"sort"
+	"strings"
+)
+
+// findingIdentity identifies a result across runs: rule, repository (GitHub
+// names are case-insensitive) and artifact. The message is deliberately left
+// out because it often embeds counts that change between runs.
+func findingIdentity(r rules.Result) string {
+	return r.RuleID + "\x00" + strings.ToLower(r.Repo) + "\x00" + r.Artifact
+}
+
+// isFinding reports whether a result needs attention (FAIL or ERROR).
+func isFinding(r rules.Result) bool {
+	return r.Status == rules.StatusFail || r.Status == rules.StatusError
+}
+
+// ScanOutput is a scan read back from a --out file.
+type ScanOutput struct {
+	Results []rules.Result
+	// Repos lists every repo seen in results or repo.started events, sorted.
+	Repos []string
+}
+
+// ReadScanOutput reads a JSON or NDJSON file written by FileSink. JSON is an
+// array of results; NDJSON is a stream of events whose rule.result records
+// carry the results.
+func ReadScanOutput(r io.Reader) (*ScanOutput, error) {
+	br := bufio.NewReader(r)
+	first, err := firstNonSpace(br)
+	if err == io.EOF {
+		return nil, errors.New("empty scan output")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make(map[string]struct{})
+	out := &ScanOutput{}
+	if first == '[' || first == 'n' {
+		if err := json.NewDecoder(br).Decode(&out.Results); err != nil {
+			return nil, fmt.Errorf("invalid JSON scan output: %w", err)
+		}
+	} else {
+		dec := json.NewDecoder(br)
+		for line := 1; ; line++ {
+			var e Event
+			if err := dec.Decode(&e); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("invalid NDJSON scan output (record %d): %w", line, err)
+			}
+			switch e.Type {
+			case "repo.started", "repo.finished":
+				if e.Repo != "" {
+					repos[e.Repo] = struct{}{}
+				}
+			case "rule.result":
+				if e.Result == nil {
+					continue
+				}
+				// Event.Repo shadows the embedded result's repo when decoding.
+				res := *e.Result
+				res.Repo = e.Repo
+				out.Results = append(out.Results, res)
+			}
+		}
+	}
+
+	for _, res := range out.Results {
+		if res.Repo != "" {
+			repos[res.Repo] = struct{}{}
+		}
+	}
+	out.Repos = sortedKeys(repos)
+	return out, nil
+}
+
+func firstNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, br.UnreadByte()
+	}
+}
+
+// DiffEntry is one finding compared between two scans. OldStatus or NewStatus
+// is empty when the result is missing from that scan.
+type DiffEntry struct {
+	Repo      string       `json:"repo"`
+	RuleID    string       `json:"rule_id"`
+	Artifact  string       `json:"artifact,omitempty"`
+	OldStatus rules.Status `json:"old_status,omitempty"`
+	NewStatus rules.Status `json:"new_status,omitempty"`
+	// Message is the newer scan's message when it has the result.
+	Message string `json:"message,omitempty"`
+}
+
+// ScanDiff is the difference between two scans' findings.
+type ScanDiff struct {
+	// New findings are FAIL or ERROR now but were not before.
+	New []DiffEntry `json:"new"`
+	// Resolved findings were FAIL or ERROR and now pass, are skipped, or are no
+	// longer reported for a repo that was scanned again.
+	Resolved []DiffEntry `json:"resolved"`
+	// Changed findings moved between FAIL and ERROR.
+	Changed []DiffEntry `json:"changed"`
+	// StillFailing findings have the same FAIL or ERROR status in both scans.
+	StillFailing []DiffEntry `json:"still_failing"`
+
+	AddedRepos   []string `json:"added_repos"`
+	RemovedRepos []string `json:"removed_repos"`
+}
+
+// NewFailures counts findings that fail now but did not before: new FAILs and
+// ERRORs that became FAILs.
+func (d *ScanDiff) NewFailures() int {
+	n := 0
+	for _, e := range d.New {
+		if e.NewStatus == rules.StatusFail {
+			n++
+		}
+	}
+	for _, e := range d.Changed {
+		if e.NewStatus == rules.StatusFail {
+			n++
+		}
+	}
+	return n
+}
+
+// DiffScans compares two scans. Results are matched by rule, repo and
+// artifact; results for repos missing from either scan only show up as added
+// or removed repos (new findings in added repos are still reported as new).
+func DiffScans(oldScan, newScan *ScanOutput) *ScanDiff {
+	d := &ScanDiff{
+		New:          []DiffEntry{},
+		Resolved:     []DiffEntry{},
+		Changed:      []DiffEntry{},
+		StillFailing: []DiffEntry{},
+		AddedRepos:   []string{},
+		RemovedRepos: []string{},
+	}
+
+	oldRepos := lowerSet(oldScan.Repos)
+	newRepos := lowerSet(newScan.Repos)
+	for _, repo := range newScan.Repos {
+		if _, ok := oldRepos[strings.ToLower(repo)]; !ok {
+			d.AddedRepos = append(d.AddedRepos, repo)
+		}
+	}
+	for _, repo := range oldScan.Repos {
+		if _, ok := newRepos[strings.ToLower(repo)]; !ok {
+			d.RemovedRepos = append(d.RemovedRepos, repo)
+		}
+	}
+
+	oldByID := indexResults(oldScan.Results)
+	newByID := indexResults(newScan.Results)
+
+	for id, nr := range newByID {
+		entry := DiffEntry{Repo: nr.Repo, RuleID: nr.RuleID, Artifact: nr.Artifact, NewStatus: nr.Status, Message: nr.Message}
+		or, seen := oldByID[id]
+		if seen {
+			entry.OldStatus = or.Status
+		}
+		switch {
+		case !isFinding(nr):
+			if seen && isFinding(or) {
+				d.Resolved = append(d.Resolved, entry)
+			}
+		case !seen || !isFinding(or):
+			d.New = append(d.New, entry)
+		case or.Status != nr.Status:
+			d.Changed = append(d.Changed, entry)
+		default:
+			d.StillFailing = append(d.StillFailing, entry)
+		}
+	}
+	for id, or := range oldByID {
+		if _, ok := newByID[id]; ok || !isFinding(or) {
+			continue
+		}
+		if _, rescanned := newRepos[strings.ToLower(or.Repo)]; !rescanned {
+			continue
+		}
+		d.Resolved = append(d.Resolved, DiffEntry{Repo: or.Repo, RuleID: or.RuleID, Artifact: or.Artifact, OldStatus: or.Status, Message: or.Message})
+	}
+
+	for _, entries := range [][]DiffEntry{d.New, d.Resolved, d.Changed, d.StillFailing} {
+		sortDiffEntries(entries)
+	}
+	return d
+}
+
+func indexResults(results []rules.Result) map[string]rules.Result {
+	byID := make(map[string]rules.Result, len(results))
+	for _, r := range results {
+		byID[findingIdentity(r)] = r
+	}
+	return byID
+}
+
+func lowerSet(values []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[strings.ToLower(v)] = struct{}{}
+	}
+	return set
+}
+
+func sortDiffEntries(entries []DiffEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Artifact < b.Artifact
+	})
+}
+
+// WriteDiff renders d as text, json or markdown.
+func WriteDiff(w io.Writer, d *ScanDiff, format string) error {
+	switch format {
+	case "text":
+		return writeDiffText(w, d)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case "markdown":
+		return writeDiffMarkdown(w, d)
+	default:
+		return fmt.Errorf("unsupported diff format: %s", format)
+	}
+}
+
+func (d *ScanDiff) summary() string {
+	return fmt.Sprintf("%d new, %d resolved, %d changed, %d still failing; %d repos added, %d removed",
+		len(d.New), len(d.Resolved), len(d.Changed), len(d.StillFailing), len(d.AddedRepos), len(d.RemovedRepos))
+}
+
+// diffSections pairs each entry list with its heading, in display order.
+func (d *ScanDiff) diffSections() []struct {
+	title   string
+	entries []DiffEntry
+} {
+	return []struct {
+		title   string
+		entries []DiffEntry
+	}{
+		{"New findings", d.New},
+		{"Resolved", d.Resolved},
+		{"Changed status", d.Changed},
+		{"Still failing", d.StillFailing},
+	}
+}
+
+// diffTransition describes an entry's status change, e.g. "FAIL -> ERROR".
+func diffTransition(e DiffEntry) string {
+	from, to := string(e.OldStatus), string(e.NewStatus)
+	if from == "" {
+		from = "none"
+	}
+	if to == "" {
+		to = "not reported"
+	}
+	return from + " -> " + to
+}
+
+func diffTarget(e DiffEntry) string {
+	if e.Artifact != "" {
+		return e.Repo + " " + e.RuleID + " (" + e.Artifact + ")"
+	}
+	return e.Repo + " " + e.RuleID
+}
+
+func writeDiffText(w io.Writer, d *ScanDiff) error {
+	var b strings.Builder
+	for _, s := range d.diffSections() {
+		if len(s.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "%s (%d):\n", s.title, len(s.entries))
+		for _, e := range s.entries {
+			fmt.Fprintf(&b, "  %s [%s]", diffTarget(e), diffTransition(e))
+			if e.Message != "" {
+				fmt.Fprintf(&b, ": %s", e.Message)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	if len(d.AddedRepos) > 0 {
+		fmt.Fprintf(&b, "Repos added (%d): %s\n", len(d.AddedRepos), strings.Join(d.AddedRepos, ", "))
+	}
+	if len(d.RemovedRepos) > 0 {
+		fmt.Fprintf(&b, "Repos removed (%d): %s\n", len(d.RemovedRepos), strings.Join(d.RemovedRepos, ", "))
+	}
+	fmt.Fprintf(&b, "Summary: %s\n", d.summary())
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDiffMarkdown(w io.Writer, d *ScanDiff) error {
+	var b strings.Builder
+	b.WriteString("# RepoMedic Scan Diff\n\n")
+	fmt.Fprintf(&b, "%s.\n\n", d.summary())
+	for _, s := range d.diffSections() {
+		fmt.Fprintf(&b, "## %s\n\n", s.title)
+		if len(s.entries) == 0 {
+			b.WriteString("- None\n\n")
+			continue
+		}
+		b.WriteString("| Repo | Rule | Status | Message |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, e := range s.entries {
+			rule := e.RuleID
+			if e.Artifact != "" {
+				rule += " (" + e.Artifact + ")"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", e.Repo, rule, diffTransition(e), markdownCell(e.Message))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("## Repos\n\n")
+	fmt.Fprintf(&b, "- Added: %s\n", noneIfEmpty(d.AddedRepos))
+	fmt.Fprintf(&b, "- Removed: %s\n", noneIfEmpty(d.RemovedRepos))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell keeps a value on one table row.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func noneIfEmpty(values []string) string {
+	if len(values) == 0 {
+		return "none"
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,143 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"repomedic/internal/rules"
+	"strings"
+	"testing"
+)
+
+func TestReadScanOutput_JSONAndNDJSON(t *testing.T) {
+	results := []rules.Result{
+		{Repo: "acme/a", RuleID: "codeowners-exists", Status: rules.StatusFail, Artifact: "CODEOWNERS"},
+		{Repo: "acme/b", RuleID: "readme-root-exists", Status: rules.StatusPass},
+	}
+	b, err := json.Marshal(results)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	fromJSON, err := ReadScanOutput(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("ReadScanOutput(JSON) failed: %v", err)
+	}
+
+	var nd bytes.Buffer
+	enc := json.NewEncoder(&nd)
+	_ = enc.Encode(Event{Type: "run.started", Repos: 3})
+	_ = enc.Encode(Event{Type: "repo.started", Repo: "acme/c"})
+	for _, r := range results {
+		_ = enc.Encode(eventFromResult(r))
+	}
+	_ = enc.Encode(Event{Type: "run.finished", ExitCode: 1})
+	fromNDJSON, err := ReadScanOutput(&nd)
+	if err != nil {
+		t.Fatalf("ReadScanOutput(NDJSON) failed: %v", err)
+	}
+
+	for name, got := range map[string]*ScanOutput{"json": fromJSON, "ndjson": fromNDJSON} {
+		if len(got.Results) != 2 || got.Results[0].Repo != "acme/a" || got.Results[0].Artifact != "CODEOWNERS" {
+			t.Fatalf("%s: unexpected results: %+v", name, got.Results)
+		}
+	}
+	if strings.Join(fromNDJSON.Repos, ",") != "acme/a,acme/b,acme/c" {
+		t.Fatalf("expected repos from events and results, got %v", fromNDJSON.Repos)
+	}
+
+	if _, err := ReadScanOutput(strings.NewReader("  \n")); err == nil {
+		t.Fatalf("expected error for empty input")
+	}
+}
+
+func TestDiffScans(t *testing.T) {
+	oldScan := &ScanOutput{
+		Repos: []string{"acme/a", "acme/b", "acme/gone"},
+		Results: []rules.Result{
+			{Repo: "acme/a", RuleID: "default-branch-protected", Status: rules.StatusFail, Message: "2 checks missing"},
+			{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail},
+			{Repo: "acme/a", RuleID: "secret-scanning-disabled", Status: rules.StatusError},
+			{Repo: "acme/b", RuleID: "rulesets-active", Status: rules.StatusFail},
+			{Repo: "acme/b", RuleID: "codeowners-exists", Status: rules.StatusPass},
+			{Repo: "acme/gone", RuleID: "readme-root-exists", Status: rules.StatusFail},
+		},
+	}
+	newScan := &ScanOutput{
+		Repos: []string{"ACME/a", "acme/b", "acme/new"},
+		Results: []rules.Result{
+			// Same finding, different message and repo case: still failing.
+			{Repo: "ACME/a", RuleID: "default-branch-protected", Status: rules.StatusFail, Message: "3 checks missing"},
+			{Repo: "ACME/a", RuleID: "readme-root-exists", Status: rules.StatusPass},
+			{Repo: "ACME/a", RuleID: "secret-scanning-disabled", Status: rules.StatusFail},
+			{Repo: "acme/b", RuleID: "codeowners-exists", Status: rules.StatusFail, Artifact: "CODEOWNERS"},
+			{Repo: "acme/new", RuleID: "repo-visibility-public", Status: rules.StatusError},
+		},
+	}
+
+	d := DiffScans(oldScan, newScan)
+
+	ids := func(entries []DiffEntry) string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.Repo+"/"+e.RuleID+":"+string(e.OldStatus)+">"+string(e.NewStatus))
+		}
+		return strings.Join(out, " ")
+	}
+	checks := map[string]struct{ got, want string }{
+		"new":           {ids(d.New), "acme/b/codeowners-exists:>FAIL acme/new/repo-visibility-public:>ERROR"},
+		"resolved":      {ids(d.Resolved), "ACME/a/readme-root-exists:FAIL>PASS acme/b/rulesets-active:FAIL>"},
+		"changed":       {ids(d.Changed), "ACME/a/secret-scanning-disabled:ERROR>FAIL"},
+		"still failing": {ids(d.StillFailing), "ACME/a/default-branch-protected:FAIL>FAIL"},
+		"added":         {strings.Join(d.AddedRepos, " "), "acme/new"},
+		"removed":       {strings.Join(d.RemovedRepos, " "), "acme/gone"},
+	}
+	for name, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s: got %q, want %q", name, c.got, c.want)
+		}
+	}
+
+	// The artifact-bearing CODEOWNERS FAIL is a different finding from the
+	// artifact-less PASS, and the ERROR->FAIL change counts as a new failure.
+	if got := d.NewFailures(); got != 2 {
+		t.Fatalf("expected 2 new failures, got %d", got)
+	}
+}
+
+func TestWriteDiff_Formats(t *testing.T) {
+	d := DiffScans(
+		&ScanOutput{Repos: []string{"acme/a"}},
+		&ScanOutput{Repos: []string{"acme/a"}, Results: []rules.Result{
+			{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail, Message: "missing | README"},
+		}},
+	)
+
+	var text bytes.Buffer
+	if err := WriteDiff(&text, d, "text"); err != nil {
+		t.Fatalf("WriteDiff(text) failed: %v", err)
+	}
+	if !strings.Contains(text.String(), "New findings (1):\n  acme/a readme-root-exists [none -> FAIL]: missing | README\n") ||
+		!strings.Contains(text.String(), "Summary: 1 new, 0 resolved, 0 changed, 0 still failing; 0 repos added, 0 removed\n") {
+		t.Fatalf("unexpected text output:\n%s", text.String())
+	}
+
+	var md bytes.Buffer
+	if err := WriteDiff(&md, d, "markdown"); err != nil {
+		t.Fatalf("WriteDiff(markdown) failed: %v", err)
+	}
+	if !strings.Contains(md.String(), "| acme/a | readme-root-exists | none -> FAIL | missing \\| README |\n") ||
+		!strings.Contains(md.String(), "## Resolved\n\n- None\n") {
+		t.Fatalf("unexpected markdown output:\n%s", md.String())
+	}
+
+	var js bytes.Buffer
+	if err := WriteDiff(&js, d, "json"); err != nil {
+		t.Fatalf("WriteDiff(json) failed: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if resolved, ok := decoded["resolved"].([]any); !ok || len(resolved) != 0 {
+		t.Fatalf("expected empty resolved list, got %v", decoded["resolved"])
+	}
+}
@@ -13,21 +13,27 @@ import (
 // Formats:
 //   - json: aggregates rule results and writes a single JSON array on Close
 //   - ndjson: streams Event values (one JSON object per line)
+//   - junit: aggregates results and writes a JUnit XML document on Close
 type EmitSink struct {
 	writer  io.Writer
-	format  string // "json" | "ndjson"
+	format  string // "json" | "ndjson" | "junit"
 	mu      sync.Mutex
 	results []rules.Result
+	junit   *junitReport
 }
 
 func NewEmitSink(w io.Writer, format string) (*EmitSink, error) {
 	if w == nil {
 		return nil, fmt.Errorf("emit sink writer must not be nil")
 	}
-	if format != "json" && format != "ndjson" {
+	switch format {
+	case "json", "ndjson":
+		return &EmitSink{writer: w, format: format}, nil
+	case "junit":
+		return &EmitSink{writer: w, format: format, junit: newJUnitReport()}, nil
+	default:
 		return nil, fmt.Errorf("unsupported emit format: %s", format)
 	}
-	return &EmitSink{writer: w, format: format}, nil
 }
 
 func (s *EmitSink) Write(v any) error {
@@ -43,6 +49,9 @@ func (s *EmitSink) Write(v any) error {
 		}
 		s.results = append(s.results, r)
 		return nil
+	case "junit":
+		s.junit.add(v)
+		return nil
 	case "ndjson":
 		encoder := json.NewEncoder(s.writer)
 		switch t := v.(type) {
@@ -77,5 +86,11 @@ func (s *EmitSink) Close() error {
 		}
 		return flushIfPossible(s.writer)
 	}
+	if s.format == "junit" {
+		if err := s.junit.encode(s.writer); err != nil {
+			return err
+		}
+		return flushIfPossible(s.writer)
+	}
 	return nil
 }
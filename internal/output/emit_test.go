@@ -77,3 +77,24 @@ func TestEmitSink_NilWriter(t *testing.T) {
 		t.Fatalf("expected error, got nil")
 	}
 }
+
+func TestEmitSink_JUnit(t *testing.T) {
+	var buf bytes.Buffer
+	s, err := NewEmitSink(&buf, "junit")
+	if err != nil {
+		t.Fatalf("NewEmitSink returned error: %v", err)
+	}
+
+	_ = s.Write(Event{Type: "repo.started", Repo: "org/r"})
+	_ = s.Write(rules.Result{Repo: "org/r", RuleID: "a", Status: rules.StatusFail, Message: "bad"})
+	_ = s.Write(Event{Type: "repo.finished", Repo: "org/r", DurationMS: 1500})
+	if buf.Len() != 0 {
+		t.Fatalf("expected junit output to be written on Close, got %q", buf.String())
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `<testsuite name="org/r" tests="1" failures="1" errors="0" skipped="0" time="1.5">`) {
+		t.Fatalf("unexpected junit output:\n%s", buf.String())
+	}
+}
@@ -1,26 +1,109 @@
 package output
 
-import "repomedic/internal/rules"
+import (
+	"repomedic/internal/rules"
+	"time"
+)
 
 // Event is a lifecycle record for NDJSON streaming output.
 //
 // In NDJSON mode, sinks emit Events (one JSON object per line), including:
 // - run.started
+// - run.preflight (rules the permission preflight expects to be degraded)
 // - repo.started
 // - rule.result
 // - repo.finished
 // - run.finished
+// - run.interrupted (replaces run.finished when the scan was interrupted)
 //
 // JSON mode remains an aggregate of rules.Result values.
 type Event struct {
 	Type string `json:"type"`
 	Repo string `json:"repo,omitempty"`
+	// Properties holds the repo's org custom property values on repo.started,
+	// when they were fetched for the scan.
+	Properties map[string][]string `json:"properties,omitempty"`
+	// Teams lists the teams (ORG/TEAM-SLUG) responsible for the repo on
+	// repo.started, when it was targeted with --team.
+	Teams []string `json:"teams,omitempty"`
 	*rules.Result
-	Repos    int `json:"repos,omitempty"`
-	Rules    int `json:"rules,omitempty"`
-	ExitCode int `json:"exit_code,omitempty"`
+	Repos int `json:"repos,omitempty"`
+	Rules int `json:"rules,omitempty"`
+	// Evaluated is the number of repos fully evaluated before an interruption.
+	Evaluated int `json:"evaluated,omitempty"`
+	// Reason describes why the run was interrupted (e.g. the received signal).
+	Reason   string `json:"reason,omitempty"`
+	ExitCode int    `json:"exit_code,omitempty"`
+	// DurationMS is how long the repo's rules took to evaluate; set on repo.finished.
+	DurationMS int64 `json:"duration_ms,omitempty"`
+	// Stats describes how the run executed; set on run.finished and run.interrupted.
+	Stats *RunStats `json:"stats,omitempty"`
+	// Preflight lists the rules expected to be SKIPPED or ERROR; set on run.preflight.
+	Preflight []PreflightRule `json:"preflight,omitempty"`
+	// Baseline splits the run's findings into new and baselined; set on
+	// run.finished and run.interrupted when --baseline is used.
+	Baseline *BaselineSummary `json:"baseline,omitempty"`
 }
 
+// BaselineSummary counts a run's FAIL results against the baseline.
+type BaselineSummary struct {
+	New       int `json:"new"`
+	Baselined int `json:"baselined"`
+}
+
+// PreflightRule is a rule the permission preflight expects to be SKIPPED or
+// ERROR because the token lacks a permission one of its dependencies needs.
+type PreflightRule struct {
+	RuleID  string `json:"rule_id"`
+	Outcome string `json:"outcome"`
+	// Owners are the orgs/users whose probe repository was denied.
+	Owners []string `json:"owners"`
+	// Missing describes each denied dependency and the permission it needs.
+	Missing []string `json:"missing"`
+}
+
+// RunStats summarizes execution details of a run.
+type RunStats struct {
+	Concurrency *ConcurrencyStats `json:"concurrency,omitempty"`
+	API         *APIStats         `json:"api,omitempty"`
+}
+
+// APIStats records the GitHub API usage of a run.
+type APIStats struct {
+	// Requests counts API requests by the dependency that made them.
+	Requests map[string]int `json:"requests,omitempty"`
+	// RateLimitRemaining is the core rate limit left when the run ended,
+	// summed across tokens.
+	RateLimitRemaining int `json:"rate_limit_remaining"`
+}
+
+// ConcurrencyStats records the dependency fetch concurrency used by a run.
+// In "fixed" mode Initial, Min, Max and Final are all equal; in "auto" mode
+// Changes lists every adjustment in the order it was made.
+type ConcurrencyStats struct {
+	Mode    string              `json:"mode"`
+	Initial int                 `json:"initial"`
+	Min     int                 `json:"min"`
+	Max     int                 `json:"max"`
+	Final   int                 `json:"final"`
+	Changes []ConcurrencyChange `json:"changes,omitempty"`
+}
+
+// ConcurrencyChange is a single adaptive concurrency adjustment.
+type ConcurrencyChange struct {
+	At      time.Time `json:"at"`
+	Workers int       `json:"workers"`
+	Reason  string    `json:"reason"`
+}
+
+// EventRunInterrupted is the final lifecycle event of a run that was
+// interrupted before all planned repos were evaluated.
+const EventRunInterrupted = "run.interrupted"
+
+// EventRunPreflight follows run.started when the permission preflight found
+// rules that will be degraded.
+const EventRunPreflight = "run.preflight"
+
 func eventFromResult(r rules.Result) Event {
 	return Event{Type: "rule.result", Repo: r.Repo, Result: &r}
 }
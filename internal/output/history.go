@@ -0,0 +1,295 @@
+package output
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HistoryVersion is the version of the run records HistorySink writes.
+// Readers reject records with a newer version.
+const HistoryVersion = 1
+
+// historyExt is the file extension of run records in a history directory.
+const historyExt = ".json.gz"
+
+// HistoryRun is one completed scan recorded in a --history-dir. Records are
+// gzipped JSON named <UTC timestamp>_<target slug>.json.gz and keep only what
+// trends need: each result's identity and status, not messages or evidence.
+type HistoryRun struct {
+	Version int       `json:"version"`
+	At      time.Time `json:"at"`
+	// Target describes what was scanned, e.g. "org:acme" (see HistorySink).
+	Target     string `json:"target"`
+	DurationMS int64  `json:"duration_ms,omitempty"`
+	ExitCode   int    `json:"exit_code"`
+	// Repos and Rules are the repos scanned and the rules evaluated, sorted.
+	// A finding missing from a later run only counts as fixed when that run
+	// scanned its repo with its rule.
+	Repos   []string        `json:"repos"`
+	Rules   []string        `json:"rules"`
+	Results []HistoryResult `json:"results"`
+}
+
+// HistoryResult is a rule result reduced to its identity and status.
+type HistoryResult struct {
+	Repo     string       `json:"repo"`
+	RuleID   string       `json:"rule"`
+	Status   rules.Status `json:"status"`
+	Artifact string       `json:"artifact,omitempty"`
+}
+
+func (h HistoryResult) result() rules.Result {
+	return rules.Result{Repo: h.Repo, RuleID: h.RuleID, Status: h.Status, Artifact: h.Artifact}
+}
+
+// scanOutput converts the run for DiffScans.
+func (h *HistoryRun) scanOutput() *ScanOutput {
+	out := &ScanOutput{Repos: h.Repos}
+	for _, r := range h.Results {
+		out.Results = append(out.Results, r.result())
+	}
+	return out
+}
+
+// HistorySink records a completed scan in a history directory on Close.
+// Interrupted runs are not recorded: a partial run would show unscanned
+// findings as neither open nor fixed.
+type HistorySink struct {
+	dir    string
+	target string
+	mu     sync.Mutex
+
+	run      HistoryRun
+	started  time.Time
+	finished bool
+	repos    map[string]struct{}
+	rules    map[string]struct{}
+
+	now func() time.Time
+}
+
+// NewHistorySink creates the history directory if needed and returns a sink
+// that records the run under target.
+func NewHistorySink(dir, target string) (*HistorySink, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("history directory required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	return &HistorySink{
+		dir:    dir,
+		target: target,
+		repos:  make(map[string]struct{}),
+		rules:  make(map[string]struct{}),
+		now:    time.Now,
+	}, nil
+}
+
+func (s *HistorySink) Write(v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch t := v.(type) {
+	case rules.Result:
+		s.run.Results = append(s.run.Results, HistoryResult{Repo: t.Repo, RuleID: t.RuleID, Status: t.Status, Artifact: t.Artifact})
+		if t.Repo != "" {
+			s.repos[t.Repo] = struct{}{}
+		}
+		if t.RuleID != "" {
+			s.rules[t.RuleID] = struct{}{}
+		}
+	case Event:
+		switch t.Type {
+		case "run.started":
+			s.started = s.now()
+		case "repo.started":
+			if t.Repo != "" {
+				s.repos[t.Repo] = struct{}{}
+			}
+		case "run.finished":
+			s.finished = true
+			s.run.ExitCode = t.ExitCode
+		}
+	}
+	return nil
+}
+
+// Close writes the run record. Nothing is written for interrupted runs or
+// runs that scanned no repos.
+func (s *HistorySink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.finished || len(s.repos) == 0 {
+		return nil
+	}
+	end := s.now()
+	if s.started.IsZero() {
+		s.started = end
+	}
+	run := s.run
+	run.Version = HistoryVersion
+	run.At = s.started.UTC()
+	run.Target = s.target
+	run.DurationMS = end.Sub(s.started).Milliseconds()
+	run.Repos = sortedKeys(s.repos)
+	run.Rules = sortedKeys(s.rules)
+	if run.Results == nil {
+		run.Results = []HistoryResult{}
+	}
+
+	path, err := s.recordPath(run.At)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, func(w io.Writer) error {
+		zw := gzip.NewWriter(w)
+		if err := json.NewEncoder(zw).Encode(run); err != nil {
+			return err
+		}
+		return zw.Close()
+	})
+}
+
+// recordPath names the record for a run at at, adding a counter if another
+// run of the same target started in the same second.
+func (s *HistorySink) recordPath(at time.Time) (string, error) {
+	base := at.Format("20060102T150405Z") + "_" + historySlug(s.target)
+	path := filepath.Join(s.dir, base+historyExt)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			return path, nil
+		} else if err != nil {
+			return "", err
+		}
+		path = filepath.Join(s.dir, fmt.Sprintf("%s.%d%s", base, n, historyExt))
+	}
+}
+
+// historySlug makes target safe for file names. The hash keeps targets apart
+// that differ only in punctuation or beyond the truncated prefix.
+func historySlug(target string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(target) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if len(slug) > 40 {
+		slug = strings.TrimSuffix(slug[:40], "-")
+	}
+	sum := sha256.Sum256([]byte(target))
+	if slug == "" {
+		return hex.EncodeToString(sum[:4])
+	}
+	return slug + "-" + hex.EncodeToString(sum[:4])
+}
+
+// LoadHistory reads every run record in dir, oldest first. With a non-empty
+// target only runs of that target are returned.
+func LoadHistory(dir, target string) ([]*HistoryRun, error) {
+	names, err := historyFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var runs []*HistoryRun
+	for _, name := range names {
+		run, err := readHistoryRun(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if target == "" || run.Target == target {
+			runs = append(runs, run)
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].At.Before(runs[j].At) })
+	return runs, nil
+}
+
+// LatestHistoryRun returns the most recent recorded run of target, or nil if
+// dir holds none (including when dir does not exist yet).
+func LatestHistoryRun(dir, target string) (*HistoryRun, error) {
+	names, err := historyFiles(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	suffix := "_" + historySlug(target)
+	var latest *HistoryRun
+	for _, name := range names {
+		base := strings.TrimSuffix(name, historyExt)
+		if i := strings.LastIndexByte(base, '.'); i > 0 {
+			base = base[:i] // same-second counter
+		}
+		if !strings.HasSuffix(base, suffix) {
+			continue
+		}
+		run, err := readHistoryRun(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if run.Target == target && (latest == nil || !run.At.Before(latest.At)) {
+			latest = run
+		}
+	}
+	return latest, nil
+}
+
+func historyFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), historyExt) {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func readHistoryRun(path string) (*HistoryRun, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid history record %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	var run HistoryRun
+	if err := json.NewDecoder(zr).Decode(&run); err != nil {
+		return nil, fmt.Errorf("invalid history record %s: %w", path, err)
+	}
+	if run.Version < 1 || run.Version > HistoryVersion {
+		return nil, fmt.Errorf("history record %s has unsupported version %d", path, run.Version)
+	}
+	return &run, nil
+}
@@ -0,0 +1,145 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"strings"
+	"testing"
+	"time"
+)
+
+// recordRun writes a completed run through a HistorySink started at at.
+func recordRun(t *testing.T, dir, target string, at time.Time, results ...rules.Result) {
+	t.Helper()
+	s, err := NewHistorySink(dir, target)
+	if err != nil {
+		t.Fatalf("NewHistorySink failed: %v", err)
+	}
+	s.now = func() time.Time { return at }
+
+	_ = s.Write(Event{Type: "run.started"})
+	for _, r := range results {
+		_ = s.Write(Event{Type: "repo.started", Repo: r.Repo})
+		_ = s.Write(r)
+	}
+	_ = s.Write(Event{Type: "run.finished", ExitCode: 1})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+}
+
+func TestHistorySink_RecordsCompletedRuns(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	recordRun(t, dir, "org:acme", at,
+		rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail, Message: "missing", Evidence: map[string]string{"k": "v"}},
+		rules.Result{Repo: "acme/b", RuleID: "readme-root-exists", Status: rules.StatusPass},
+	)
+	// A second run in the same second gets its own record; a run that scanned
+	// nothing is not recorded.
+	recordRun(t, dir, "org:acme", at, rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusPass})
+	recordRun(t, dir, "org:acme", at)
+
+	names, err := historyFiles(dir)
+	if err != nil {
+		t.Fatalf("historyFiles failed: %v", err)
+	}
+	if len(names) != 2 {
+		t.Fatalf("expected 2 records, got %v", names)
+	}
+	for _, name := range names {
+		if !strings.HasPrefix(name, "20261001T120000Z_org-acme-") {
+			t.Fatalf("unexpected record name %q", name)
+		}
+	}
+	if !strings.HasSuffix(names[0], ".2"+historyExt) && !strings.HasSuffix(names[1], ".2"+historyExt) {
+		t.Fatalf("expected a counter on the same-second record, got %v", names)
+	}
+
+	runs, err := LoadHistory(dir, "")
+	if err != nil {
+		t.Fatalf("LoadHistory failed: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(runs))
+	}
+	// Both runs share a timestamp; the first one scanned two repos.
+	run := runs[0]
+	if len(run.Repos) == 1 {
+		run = runs[1]
+	}
+	if run.Version != HistoryVersion || run.Target != "org:acme" || !run.At.Equal(at) || run.ExitCode != 1 {
+		t.Fatalf("unexpected run header: %+v", run)
+	}
+	if strings.Join(run.Repos, ",") != "acme/a,acme/b" || strings.Join(run.Rules, ",") != "readme-root-exists" {
+		t.Fatalf("unexpected repos/rules: %v %v", run.Repos, run.Rules)
+	}
+	want := HistoryResult{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail}
+	if len(run.Results) != 2 || run.Results[0] != want {
+		t.Fatalf("unexpected results: %+v", run.Results)
+	}
+}
+
+func TestHistorySink_SkipsInterruptedRuns(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewHistorySink(dir, "org:acme")
+	if err != nil {
+		t.Fatalf("NewHistorySink failed: %v", err)
+	}
+	_ = s.Write(Event{Type: "run.started"})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "r", Status: rules.StatusFail})
+	_ = s.Write(Event{Type: EventRunInterrupted, Repos: 2, Evaluated: 1})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if names, _ := historyFiles(dir); len(names) != 0 {
+		t.Fatalf("expected no record for an interrupted run, got %v", names)
+	}
+}
+
+func TestLatestHistoryRun(t *testing.T) {
+	dir := t.TempDir()
+	if run, err := LatestHistoryRun(filepath.Join(dir, "missing"), "org:acme"); run != nil || err != nil {
+		t.Fatalf("expected no run for a missing directory, got %v, %v", run, err)
+	}
+
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	pass := rules.Result{Repo: "acme/a", RuleID: "r", Status: rules.StatusPass}
+	recordRun(t, dir, "org:acme", day(1), pass)
+	recordRun(t, dir, "org:acme", day(2), pass)
+	recordRun(t, dir, "org:other", day(3), pass)
+
+	run, err := LatestHistoryRun(dir, "org:acme")
+	if err != nil {
+		t.Fatalf("LatestHistoryRun failed: %v", err)
+	}
+	if run == nil || !run.At.Equal(day(2)) {
+		t.Fatalf("expected the 2026-10-02 run, got %+v", run)
+	}
+
+	runs, err := LoadHistory(dir, "org:other")
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("expected one org:other run, got %d (%v)", len(runs), err)
+	}
+}
+
+func TestLoadHistory_RejectsInvalidRecords(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "20261001T000000Z_x"+historyExt), []byte("not gzip"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := LoadHistory(dir, ""); err == nil || !strings.Contains(err.Error(), "invalid history record") {
+		t.Fatalf("expected invalid record error, got %v", err)
+	}
+}
+
+func TestHistorySlug(t *testing.T) {
+	a := historySlug("search:org:acme language:go")
+	if !strings.HasPrefix(a, "search-org-acme-language-go-") {
+		t.Fatalf("unexpected slug %q", a)
+	}
+	if b := historySlug("search:org:acme language:go "); a == b {
+		t.Fatalf("expected different targets to get different slugs")
+	}
+}
@@ -0,0 +1,521 @@
+package output
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// HTMLReportSink writes a single self-contained HTML report on Close. Results
+// are embedded as JSON and rendered by inline script into sortable, filterable
+// tables with per-repo drilldowns; nothing is loaded from the network, so the
+// file can be attached to tickets and opened offline.
+type HTMLReportSink struct {
+	path string
+	file *os.File
+	mu   sync.Mutex
+	reportState
+
+	groupBy string
+}
+
+// IsHTMLReportPath reports whether a --report path selects the HTML report.
+func IsHTMLReportPath(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		return true
+	}
+	return false
+}
+
+func NewHTMLReportSink(path string) (*HTMLReportSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("report path required")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report file: %w", err)
+	}
+
+	return &HTMLReportSink{
+		path:        path,
+		file:        f,
+		reportState: newReportState(),
+	}, nil
+}
+
+// SetGroupBy adds a filterable column with each repo's values for the named
+// org custom property.
+func (s *HTMLReportSink) SetGroupBy(property string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.groupBy = property
+}
+
+// SetPreviousRun adds a "Since Last Run" section listing new and resolved
+// findings compared with a previously recorded run.
+func (s *HTMLReportSink) SetPreviousRun(run *HistoryRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.previous = run
+}
+
+func (s *HTMLReportSink) Write(v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.record(v)
+	return nil
+}
+
+func (s *HTMLReportSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := htmlReportTemplate.Execute(s.file, s.buildHTMLReport())
+	if closeErr := s.file.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+type htmlReport struct {
+	Partial      string
+	Baseline     *BaselineSummary
+	SinceLastRun *RunDelta
+	Totals       htmlTotals
+	Brief        ReportBrief
+	Categories   []htmlCategory
+	Data         htmlData
+}
+
+type htmlTotals struct {
+	Repos, Rules, Pass, Fail, Error, Skipped int
+}
+
+type htmlCategory struct {
+	Name           string
+	Description    string
+	Severity       string
+	Repos          int
+	Representative string
+}
+
+// htmlData is embedded in the page as JSON and drives the interactive tables.
+type htmlData struct {
+	GroupBy string       `json:"group_by,omitempty"`
+	Teams   bool         `json:"teams"`
+	Repos   []htmlRepo   `json:"repos"`
+	Results []htmlResult `json:"results"`
+}
+
+type htmlRepo struct {
+	Name     string   `json:"name"`
+	Teams    []string `json:"teams,omitempty"`
+	Group    []string `json:"group,omitempty"`
+	Pass     int      `json:"pass"`
+	Fail     int      `json:"fail"`
+	Error    int      `json:"error"`
+	Skipped  int      `json:"skipped"`
+	Risk     int      `json:"risk"`
+	KeyRisks []string `json:"key_risks,omitempty"`
+}
+
+type htmlResult struct {
+	Repo     string            `json:"repo"`
+	Rule     string            `json:"rule"`
+	Category string            `json:"category"`
+	Severity string            `json:"severity"`
+	Status   string            `json:"status"`
+	Message  string            `json:"message,omitempty"`
+	Artifact string            `json:"artifact,omitempty"`
+	Evidence map[string]string `json:"evidence,omitempty"`
+	// Baselined marks findings already in the --baseline file.
+	Baselined bool `json:"baselined,omitempty"`
+}
+
+func (s *HTMLReportSink) buildHTMLReport() htmlReport {
+	repos := s.sortedRepos()
+	perRepo := collectRepoStats(repos, s.results)
+	audit := computeAuditStats(perRepo)
+
+	r := htmlReport{
+		Brief:        computeRiskBrief(s.results, perRepo, audit),
+		Baseline:     s.baseline,
+		SinceLastRun: s.sinceLastRun(),
+		Data:         htmlData{GroupBy: s.groupBy, Teams: len(s.repoTeams) > 0, Repos: []htmlRepo{}, Results: []htmlResult{}},
+	}
+	if s.interrupted {
+		reason, coverage := partialCoverage(s.interruptReason, s.evaluatedRepos, s.plannedRepos)
+		r.Partial = fmt.Sprintf("Partial report: the scan was interrupted (%s) after %s were evaluated. "+
+			"Findings and statistics cover only the evaluated repositories.", reason, coverage)
+	}
+
+	for _, cs := range computeCategoryStats(s.results) {
+		r.Categories = append(r.Categories, htmlCategory{
+			Name:           cs.Name,
+			Description:    CategoryRiskDescription[cs.Name],
+			Severity:       categorySeverity[cs.Name],
+			Repos:          cs.ReposWithFail,
+			Representative: strings.Join(cs.Representative, ", "),
+		})
+	}
+
+	names := make([]string, 0, len(perRepo))
+	for name := range perRepo {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	uniqueRules := make(map[string]struct{})
+	for _, name := range names {
+		rs := perRepo[name]
+		repo := htmlRepo{
+			Name:     name,
+			Teams:    s.repoTeams[name],
+			Pass:     rs.Pass,
+			Fail:     rs.Fail,
+			Error:    rs.Error,
+			Skipped:  rs.Skipped,
+			Risk:     computeRiskScore(rs),
+			KeyRisks: rs.KeyRisks(),
+		}
+		if s.groupBy != "" {
+			repo.Group = s.repoProps[name][s.groupBy]
+			if len(repo.Group) == 0 {
+				repo.Group = []string{unsetPropertyValue}
+			}
+		}
+		r.Data.Repos = append(r.Data.Repos, repo)
+		r.Totals.Pass += rs.Pass
+		r.Totals.Fail += rs.Fail
+		r.Totals.Error += rs.Error
+		r.Totals.Skipped += rs.Skipped
+
+		for _, res := range rs.Results {
+			uniqueRules[res.RuleID] = struct{}{}
+			r.Data.Results = append(r.Data.Results, htmlResult{
+				Repo:      res.Repo,
+				Rule:      res.RuleID,
+				Category:  getCategory(res.RuleID),
+				Severity:  getSeverity(res.RuleID),
+				Status:    string(res.Status),
+				Message:   res.Message,
+				Artifact:  res.Artifact,
+				Evidence:  res.Evidence,
+				Baselined: res.Baselined,
+			})
+		}
+	}
+	r.Totals.Repos = len(names)
+	r.Totals.Rules = len(uniqueRules)
+	return r
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(htmlReportSource))
+
+const htmlReportSource = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>RepoMedic Scan Report</title>
+<style>
+body { font: 14px/1.45 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0 auto; max-width: 1280px; padding: 0 24px 48px; color: #1f2328; }
+h1 { margin-top: 24px; }
+h2 { margin-top: 32px; border-bottom: 1px solid #d0d7de; padding-bottom: 4px; }
+.banner { background: #fff8c5; border: 1px solid #d4a72c; padding: 8px 12px; border-radius: 6px; }
+.totals span { display: inline-block; margin-right: 16px; }
+.brief { display: grid; grid-template-columns: repeat(auto-fit, minmax(280px, 1fr)); gap: 16px; }
+.brief div { background: #f6f8fa; border-radius: 6px; padding: 8px 16px; }
+table { border-collapse: collapse; width: 100%; margin-top: 8px; }
+th, td { border-bottom: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; }
+th[data-key] { cursor: pointer; user-select: none; white-space: nowrap; }
+th[aria-sort="ascending"]::after { content: " \25B2"; }
+th[aria-sort="descending"]::after { content: " \25BC"; }
+td.num, th.num { text-align: right; }
+.filters { display: flex; flex-wrap: wrap; gap: 8px; margin: 12px 0; position: sticky; top: 0; background: #fff; padding: 8px 0; }
+.filters input { min-width: 240px; }
+.status-FAIL { color: #cf222e; font-weight: 600; }
+.status-ERROR { color: #9a6700; font-weight: 600; }
+.status-SKIPPED { color: #57606a; }
+.status-PASS { color: #1a7f37; }
+.sev-high { color: #cf222e; }
+.sev-medium { color: #9a6700; }
+a.repo { color: #0969da; cursor: pointer; text-decoration: underline; }
+#drilldown { border: 1px solid #d0d7de; border-radius: 6px; padding: 8px 16px; margin-top: 16px; }
+#drilldown dl { margin: 4px 0 8px 16px; }
+#drilldown dt { font-weight: 600; }
+#drilldown dd { margin: 0 0 4px 16px; white-space: pre-wrap; }
+.count { color: #57606a; }
+</style>
+</head>
+<body>
+<h1>RepoMedic Scan Report</h1>
+{{if .Partial}}<p class="banner">{{.Partial}}</p>{{end}}
+<p class="totals">
+<span><strong>{{.Totals.Repos}}</strong> repositories</span>
+<span><strong>{{.Totals.Rules}}</strong> rules</span>
+<span class="status-FAIL">{{.Totals.Fail}} FAIL</span>
+<span class="status-ERROR">{{.Totals.Error}} ERROR</span>
+<span class="status-SKIPPED">{{.Totals.Skipped}} SKIPPED</span>
+<span class="status-PASS">{{.Totals.Pass}} PASS</span>
+{{with .Baseline}}<span><strong>{{.New}}</strong> new findings, {{.Baselined}} baselined</span>{{end}}
+</p>
+
+<h2>Executive Risk Brief</h2>
+<div class="brief">
+<div><h3>What RepoMedic found</h3><ul>{{range .Brief.Found}}<li>{{if $.Brief.HasRisks}}<strong>{{.}}</strong>{{else}}{{.}}{{end}}</li>{{end}}</ul></div>
+<div><h3>Why this matters</h3><ul>{{range .Brief.Why}}<li>{{.}}</li>{{end}}</ul></div>
+<div><h3>What to do first</h3><ul>{{range .Brief.Todo}}<li>{{.}}</li>{{end}}</ul></div>
+</div>
+
+{{with .SinceLastRun}}<h2>Since Last Run</h2>
+<p>Compared with the scan of {{.PreviousRunAt.UTC.Format "2006-01-02 15:04 UTC"}}: <strong>{{len .New}}</strong> new findings, <strong>{{len .Resolved}}</strong> resolved, {{len .Changed}} changed status, {{len .StillFailing}} still failing.</p>
+{{if or .New .Resolved}}<table>
+<thead><tr><th>Change</th><th>Repo</th><th>Rule</th><th>Status</th></tr></thead>
+<tbody>{{range .New}}
+<tr><td class="status-FAIL">new</td><td>{{.Repo}}</td><td>{{.RuleID}}{{if .Artifact}} ({{.Artifact}}){{end}}</td><td>{{or .OldStatus "none"}} &rarr; {{.NewStatus}}</td></tr>{{end}}{{range .Resolved}}
+<tr><td class="status-PASS">resolved</td><td>{{.Repo}}</td><td>{{.RuleID}}{{if .Artifact}} ({{.Artifact}}){{end}}</td><td>{{.OldStatus}} &rarr; {{or .NewStatus "not reported"}}</td></tr>{{end}}
+</tbody>
+</table>{{end}}
+{{end}}<h2>Controls Failing Across the Fleet</h2>
+{{if .Categories}}<table>
+<thead><tr><th>Category</th><th>Severity</th><th class="num">Repos</th><th>Representative Rules</th></tr></thead>
+<tbody>{{range .Categories}}
+<tr><td><strong>{{.Name}}</strong><br><em>{{.Description}}</em></td><td class="sev-{{.Severity}}">{{.Severity}}</td><td class="num">{{.Repos}}</td><td>{{.Representative}}</td></tr>{{end}}
+</tbody>
+</table>{{else}}<p>No findings.</p>{{end}}
+
+<div class="filters">
+<input id="f-search" type="search" placeholder="Filter by repo, rule or message">
+<select id="f-status"><option value="">All statuses</option></select>
+<select id="f-severity"><option value="">All severities</option></select>
+<select id="f-category"><option value="">All categories</option></select>
+<select id="f-rule"><option value="">All rules</option></select>
+<select id="f-group" hidden><option value="">All groups</option></select>
+</div>
+
+<h2>Repositories <span class="count" id="repos-count"></span></h2>
+<table id="repos">
+<thead><tr>
+<th data-key="name">Repo</th><th data-key="teams" class="col-teams">Teams</th><th data-key="group" class="col-group"></th>
+<th data-key="risk" class="num">Risk</th><th data-key="fail" class="num">FAIL</th><th data-key="error" class="num">ERROR</th>
+<th data-key="skipped" class="num">SKIPPED</th><th data-key="pass" class="num">PASS</th><th>Key Risks</th>
+</tr></thead>
+<tbody></tbody>
+</table>
+
+<section id="drilldown" hidden></section>
+
+<h2>Results <span class="count" id="results-count"></span></h2>
+<table id="results">
+<thead><tr>
+<th data-key="repo">Repo</th><th data-key="rule">Rule</th><th data-key="category">Category</th>
+<th data-key="severity">Severity</th><th data-key="status">Status</th><th data-key="message">Message</th>
+</tr></thead>
+<tbody></tbody>
+</table>
+
+<script type="application/json" id="report-data">{{.Data}}</script>
+<script>
+(function () {
+  "use strict";
+  var data = JSON.parse(document.getElementById("report-data").textContent);
+  var rank = {
+    severity: {high: 0, medium: 1, low: 2},
+    status: {ERROR: 0, FAIL: 1, SKIPPED: 2, PASS: 3}
+  };
+
+  function el(tag, text, cls) {
+    var e = document.createElement(tag);
+    if (text !== undefined && text !== null) { e.textContent = String(text); }
+    if (cls) { e.className = cls; }
+    return e;
+  }
+  function uniq(values) {
+    return Array.from(new Set(values)).sort();
+  }
+  function fill(id, values) {
+    var sel = document.getElementById(id);
+    values.forEach(function (v) { var o = el("option", v); o.value = v; sel.appendChild(o); });
+    sel.addEventListener("change", renderAll);
+    return sel;
+  }
+
+  var filters = {
+    search: document.getElementById("f-search"),
+    status: fill("f-status", ["FAIL", "ERROR", "SKIPPED", "PASS"]),
+    severity: fill("f-severity", ["high", "medium", "low"]),
+    category: fill("f-category", uniq(data.results.map(function (r) { return r.category; }))),
+    rule: fill("f-rule", uniq(data.results.map(function (r) { return r.rule; }))),
+    group: fill("f-group", uniq([].concat.apply([], data.repos.map(function (r) { return r.group || []; }))))
+  };
+  filters.search.addEventListener("input", renderAll);
+
+  var repoByName = {};
+  data.repos.forEach(function (r) { repoByName[r.name] = r; });
+  var resultsByRepo = {};
+  data.results.forEach(function (r) { (resultsByRepo[r.repo] = resultsByRepo[r.repo] || []).push(r); });
+
+  if (data.group_by) {
+    filters.group.hidden = false;
+    filters.group.options[0].textContent = "All " + data.group_by;
+    document.querySelector("#repos th.col-group").textContent = data.group_by;
+  }
+  document.querySelectorAll("#repos .col-group").forEach(function (c) { c.hidden = !data.group_by; });
+  document.querySelectorAll("#repos .col-teams").forEach(function (c) { c.hidden = !data.teams; });
+
+  function resultFilterActive() {
+    return filters.status.value || filters.severity.value || filters.category.value || filters.rule.value;
+  }
+  function matchesResult(r) {
+    return (!filters.status.value || r.status === filters.status.value) &&
+      (!filters.severity.value || r.severity === filters.severity.value) &&
+      (!filters.category.value || r.category === filters.category.value) &&
+      (!filters.rule.value || r.rule === filters.rule.value);
+  }
+  function matchesGroup(repoName) {
+    var g = filters.group.value;
+    var repo = repoByName[repoName];
+    return !g || (repo && (repo.group || []).indexOf(g) >= 0);
+  }
+  function search() {
+    return filters.search.value.trim().toLowerCase();
+  }
+
+  function repoLink(name) {
+    var a = el("a", name, "repo");
+    a.addEventListener("click", function () { showRepo(name); });
+    return a;
+  }
+
+  function sortable(table, value, render) {
+    var state = {key: null, dir: 1};
+    table.querySelectorAll("th[data-key]").forEach(function (th) {
+      th.addEventListener("click", function () {
+        var key = th.getAttribute("data-key");
+        state.dir = state.key === key ? -state.dir : 1;
+        state.key = key;
+        table.querySelectorAll("th[data-key]").forEach(function (h) { h.removeAttribute("aria-sort"); });
+        th.setAttribute("aria-sort", state.dir > 0 ? "ascending" : "descending");
+        render();
+      });
+    });
+    return function (rows) {
+      if (!state.key) { return rows; }
+      return rows.slice().sort(function (a, b) {
+        var x = value(a, state.key), y = value(b, state.key);
+        return (x < y ? -1 : x > y ? 1 : 0) * state.dir;
+      });
+    };
+  }
+
+  var reposTable = document.getElementById("repos");
+  var sortRepos = sortable(reposTable, function (r, key) {
+    if (key === "teams" || key === "group") { return (r[key] || []).join(", ").toLowerCase(); }
+    if (key === "name") { return r.name.toLowerCase(); }
+    return -r[key];
+  }, renderRepos);
+
+  function renderRepos() {
+    var q = search();
+    var rows = data.repos.filter(function (repo) {
+      if (!matchesGroup(repo.name)) { return false; }
+      var results = resultsByRepo[repo.name] || [];
+      if (resultFilterActive() && !results.some(matchesResult)) { return false; }
+      return !q || repo.name.toLowerCase().indexOf(q) >= 0 ||
+        results.some(function (r) { return r.rule.indexOf(q) >= 0; });
+    });
+    var body = reposTable.tBodies[0];
+    body.textContent = "";
+    sortRepos(rows).forEach(function (repo) {
+      var tr = document.createElement("tr");
+      var name = el("td"); name.appendChild(repoLink(repo.name)); tr.appendChild(name);
+      var teams = el("td", (repo.teams || []).join(", ")); teams.hidden = !data.teams; tr.appendChild(teams);
+      var group = el("td", (repo.group || []).join(", ")); group.hidden = !data.group_by; tr.appendChild(group);
+      tr.appendChild(el("td", repo.risk, "num"));
+      tr.appendChild(el("td", repo.fail, "num" + (repo.fail ? " status-FAIL" : "")));
+      tr.appendChild(el("td", repo.error, "num" + (repo.error ? " status-ERROR" : "")));
+      tr.appendChild(el("td", repo.skipped, "num"));
+      tr.appendChild(el("td", repo.pass, "num"));
+      tr.appendChild(el("td", (repo.key_risks || []).join(", ")));
+      body.appendChild(tr);
+    });
+    document.getElementById("repos-count").textContent = "(" + rows.length + " of " + data.repos.length + ")";
+  }
+
+  var resultsTable = document.getElementById("results");
+  var sortResults = sortable(resultsTable, function (r, key) {
+    if (rank[key]) { return rank[key][r[key]]; }
+    return (r[key] || "").toLowerCase();
+  }, renderResults);
+
+  function renderResults() {
+    var q = search();
+    var rows = data.results.filter(function (r) {
+      return matchesResult(r) && matchesGroup(r.repo) && (!q ||
+        r.repo.toLowerCase().indexOf(q) >= 0 || r.rule.indexOf(q) >= 0 ||
+        (r.message || "").toLowerCase().indexOf(q) >= 0);
+    });
+    var body = resultsTable.tBodies[0];
+    body.textContent = "";
+    sortResults(rows).forEach(function (r) {
+      var tr = document.createElement("tr");
+      var repo = el("td"); repo.appendChild(repoLink(r.repo)); tr.appendChild(repo);
+      tr.appendChild(el("td", r.rule));
+      tr.appendChild(el("td", r.category));
+      tr.appendChild(el("td", r.severity, "sev-" + r.severity));
+      tr.appendChild(el("td", r.status + (r.baselined ? " (baselined)" : ""), "status-" + r.status));
+      tr.appendChild(el("td", r.message));
+      body.appendChild(tr);
+    });
+    document.getElementById("results-count").textContent = "(" + rows.length + " of " + data.results.length + ")";
+  }
+
+  function showRepo(name) {
+    var repo = repoByName[name];
+    var panel = document.getElementById("drilldown");
+    panel.textContent = "";
+    panel.appendChild(el("h2", name));
+    if (repo) {
+      var summary = repo.fail + " FAIL, " + repo.error + " ERROR, " + repo.skipped + " SKIPPED, " + repo.pass + " PASS";
+      if (repo.teams && repo.teams.length) { summary += " — teams: " + repo.teams.join(", "); }
+      panel.appendChild(el("p", summary));
+    }
+    (resultsByRepo[name] || []).slice().sort(function (a, b) {
+      return rank.status[a.status] - rank.status[b.status] || (a.rule < b.rule ? -1 : 1);
+    }).forEach(function (r) {
+      var d = document.createElement("details");
+      d.open = r.status === "FAIL" || r.status === "ERROR";
+      var s = document.createElement("summary");
+      s.appendChild(el("span", r.status, "status-" + r.status));
+      s.appendChild(document.createTextNode((r.baselined ? " (baselined) " : " ") + r.rule + (r.message ? ": " + r.message : "")));
+      d.appendChild(s);
+      var dl = document.createElement("dl");
+      if (r.artifact) { dl.appendChild(el("dt", "file")); dl.appendChild(el("dd", r.artifact)); }
+      Object.keys(r.evidence || {}).sort().forEach(function (k) {
+        dl.appendChild(el("dt", k));
+        dl.appendChild(el("dd", r.evidence[k]));
+      });
+      if (!dl.childNodes.length) { dl.appendChild(el("dd", "No evidence recorded.")); }
+      d.appendChild(dl);
+      panel.appendChild(d);
+    });
+    panel.hidden = false;
+    panel.scrollIntoView();
+  }
+
+  function renderAll() {
+    renderRepos();
+    renderResults();
+  }
+  renderAll();
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"strings"
+	"testing"
+	"time"
+)
+
+func renderHTMLReport(t *testing.T, groupBy string, writes ...any) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.html")
+	s, err := NewHTMLReportSink(path)
+	if err != nil {
+		t.Fatalf("NewHTMLReportSink: %v", err)
+	}
+	s.SetGroupBy(groupBy)
+	for _, w := range writes {
+		if err := s.Write(w); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	return string(b)
+}
+
+func embeddedHTMLData(t *testing.T, html string) htmlData {
+	t.Helper()
+	const open = `<script type="application/json" id="report-data">`
+	start := strings.Index(html, open)
+	if start < 0 {
+		t.Fatalf("embedded data not found")
+	}
+	rest := html[start+len(open):]
+	end := strings.Index(rest, "</script>")
+	var d htmlData
+	if err := json.Unmarshal([]byte(rest[:end]), &d); err != nil {
+		t.Fatalf("embedded data is not JSON: %v", err)
+	}
+	return d
+}
+
+func TestHTMLReportSink_SelfContainedWithEmbeddedData(t *testing.T) {
+	html := renderHTMLReport(t, "tier",
+		Event{Type: "run.started", Repos: 2},
+		Event{Type: "repo.started", Repo: "org/a", Properties: map[string][]string{"tier": {"gold"}}},
+		rules.Result{Repo: "org/a", RuleID: "repo-visibility-public", Status: rules.StatusFail, Message: "public </script><b>x</b>", Evidence: map[string]string{"visibility": "public"}},
+		rules.Result{Repo: "org/a", RuleID: "codeowners-exists", Status: rules.StatusFail, Artifact: "CODEOWNERS"},
+		Event{Type: "repo.started", Repo: "org/b"},
+		rules.Result{Repo: "org/b", RuleID: "codeowners-exists", Status: rules.StatusPass},
+		Event{Type: "run.finished", ExitCode: 1},
+	)
+
+	for _, external := range []string{"<script src", "<link", "@import", "http://", "https://"} {
+		if strings.Contains(html, external) {
+			t.Errorf("report must not reference external assets, found %q", external)
+		}
+	}
+	if strings.Contains(html, "<b>x</b>") {
+		t.Errorf("result messages must be escaped")
+	}
+	for _, want := range []string{
+		"Executive Risk Brief",
+		"1 repos are publicly visible and not allow-listed.",
+		CategoryExposure,
+		CategoryRiskDescription[CategoryRepoHygiene],
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+
+	d := embeddedHTMLData(t, html)
+	if d.GroupBy != "tier" || len(d.Repos) != 2 || len(d.Results) != 3 {
+		t.Fatalf("unexpected embedded data: %+v", d)
+	}
+	if got := d.Repos[0].Group; len(got) != 1 || got[0] != "gold" {
+		t.Fatalf("expected org/a in group gold, got %v", got)
+	}
+	if got := d.Repos[1].Group; len(got) != 1 || got[0] != unsetPropertyValue {
+		t.Fatalf("expected org/b to be unset, got %v", got)
+	}
+	pub := d.Results[0]
+	if pub.Severity != SeverityHigh || pub.Category != CategoryExposure || pub.Evidence["visibility"] != "public" {
+		t.Fatalf("unexpected result row: %+v", pub)
+	}
+	if d.Results[1].Artifact != "CODEOWNERS" || d.Results[1].Severity != SeverityLow {
+		t.Fatalf("unexpected result row: %+v", d.Results[1])
+	}
+}
+
+func TestHTMLReportSink_InterruptedRunHasPartialBanner(t *testing.T) {
+	html := renderHTMLReport(t, "",
+		Event{Type: "run.started", Repos: 10},
+		rules.Result{Repo: "org/a", RuleID: "codeowners-exists", Status: rules.StatusPass},
+		Event{Type: EventRunInterrupted, Reason: "signal: interrupt", Evaluated: 1, ExitCode: 4},
+	)
+	if !strings.Contains(html, "interrupted (signal: interrupt) after 1 of 10 planned repositories") {
+		t.Fatalf("expected partial banner, got:\n%s", html)
+	}
+}
+
+func TestHTMLReportSink_SinceLastRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html")
+	s, err := NewHTMLReportSink(path)
+	if err != nil {
+		t.Fatalf("NewHTMLReportSink: %v", err)
+	}
+	s.SetPreviousRun(&HistoryRun{
+		At:      time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+		Repos:   []string{"org/a"},
+		Results: []HistoryResult{{Repo: "org/a", RuleID: "codeowners-exists", Status: rules.StatusFail}},
+	})
+	_ = s.Write(rules.Result{Repo: "org/a", RuleID: "codeowners-exists", Status: rules.StatusPass})
+	_ = s.Write(Event{Type: "run.finished"})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	html := string(b)
+	for _, want := range []string{"<h2>Since Last Run</h2>", "2026-10-01 09:30 UTC", `<td class="status-PASS">resolved</td><td>org/a</td><td>codeowners-exists</td>`} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("expected %q in report", want)
+		}
+	}
+}
+
+func TestIsHTMLReportPath(t *testing.T) {
+	for path, want := range map[string]bool{
+		"report.html": true,
+		"out/R.HTM":   true,
+		"report.md":   false,
+		"report":      false,
+	} {
+		if got := IsHTMLReportPath(path); got != want {
+			t.Errorf("IsHTMLReportPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// junitReport accumulates results into JUnit test suites: one <testsuite> per
+// repo and one <testcase> per rule result, in the order they were received.
+type junitReport struct {
+	suites []*junitSuite
+	byRepo map[string]*junitSuite
+}
+
+func newJUnitReport() *junitReport {
+	return &junitReport{byRepo: make(map[string]*junitSuite)}
+}
+
+func (j *junitReport) suite(repo string) *junitSuite {
+	s, ok := j.byRepo[repo]
+	if !ok {
+		s = &junitSuite{Name: repo}
+		j.byRepo[repo] = s
+		j.suites = append(j.suites, s)
+	}
+	return s
+}
+
+func (j *junitReport) add(v any) {
+	switch t := v.(type) {
+	case Event:
+		switch t.Type {
+		case "repo.started":
+			j.suite(t.Repo).setTeams(t.Teams)
+		case "repo.finished":
+			j.suite(t.Repo).Time = junitSeconds(t.DurationMS)
+		}
+	case rules.Result:
+		s := j.suite(t.Repo)
+		s.setTeams(t.Teams)
+		tc := junitTestCase{Name: t.RuleID, ClassName: t.Repo}
+		switch t.Status {
+		case rules.StatusFail:
+			tc.Failure = &junitProblem{Message: t.Message, Type: string(t.Status), Body: junitEvidence(t)}
+			s.Failures++
+		case rules.StatusError:
+			tc.Error = &junitProblem{Message: t.Message, Type: string(t.Status), Body: junitEvidence(t)}
+			s.Errors++
+		case rules.StatusSkipped:
+			tc.Skipped = &junitSkipped{Message: t.Message}
+			s.Skipped++
+		}
+		s.Tests++
+		s.Cases = append(s.Cases, tc)
+	}
+}
+
+func (j *junitReport) encode(w io.Writer) error {
+	doc := junitTestSuites{Name: "repomedic"}
+	var total float64
+	for _, s := range j.suites {
+		doc.Suites = append(doc.Suites, *s)
+		doc.Tests += s.Tests
+		doc.Failures += s.Failures
+		doc.Errors += s.Errors
+		doc.Skipped += s.Skipped
+		total += s.Time
+	}
+	doc.Time = total
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     float64      `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       float64         `xml:"time,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// setTeams records the repo's responsible teams as a "teams" suite property.
+func (s *junitSuite) setTeams(teams []string) {
+	if len(teams) == 0 || len(s.Properties) > 0 {
+		return
+	}
+	s.Properties = []junitProperty{{Name: "teams", Value: strings.Join(teams, ", ")}}
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// junitSeconds converts milliseconds to the fractional seconds JUnit expects.
+func junitSeconds(ms int64) float64 {
+	return float64(ms) / 1000
+}
+
+// junitEvidence renders the message followed by sorted "key: value" evidence
+// lines as the failure/error body.
+func junitEvidence(r rules.Result) string {
+	var b strings.Builder
+	b.WriteString(r.Message)
+	keys := make([]string, 0, len(r.Evidence))
+	for k := range r.Evidence {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "\n%s: %s", k, r.Evidence[k])
+	}
+	return b.String()
+}
+
+// JUnitSink writes results as a JUnit XML file on Close. The file is written to
+// a temporary sibling and renamed into place, so readers never see a partial
+// report.
+type JUnitSink struct {
+	path   string
+	mu     sync.Mutex
+	report *junitReport
+}
+
+func NewJUnitSink(path string) (*JUnitSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("output path required")
+	}
+	dir := filepath.Dir(path)
+	if dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	return &JUnitSink{path: path, report: newJUnitReport()}, nil
+}
+
+func (s *JUnitSink) Write(v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.report.add(v)
+	return nil
+}
+
+func (s *JUnitSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeFileAtomic(s.path, s.report.encode)
+}
+
+// writeFileAtomic writes path via a temporary file in the same directory and
+// renames it into place once write succeeds.
+func writeFileAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	// CreateTemp uses 0600; match the permissions os.Create would give.
+	_ = tmp.Chmod(0644)
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"strings"
+	"testing"
+)
+
+func TestJUnitSink_MapsStatusesPerRepo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	s, err := NewJUnitSink(path)
+	if err != nil {
+		t.Fatalf("NewJUnitSink: %v", err)
+	}
+
+	writes := []any{
+		Event{Type: "repo.started", Repo: "org/a"},
+		rules.Result{Repo: "org/a", RuleID: "pass-rule", Status: rules.StatusPass},
+		rules.Result{Repo: "org/a", RuleID: "fail-rule", Status: rules.StatusFail, Message: "missing, badly", Evidence: map[string]string{"z": "2", "a": "<1>"}},
+		Event{Type: "repo.finished", Repo: "org/a", DurationMS: 250},
+		Event{Type: "repo.started", Repo: "org/b"},
+		rules.Result{Repo: "org/b", RuleID: "err-rule", Status: rules.StatusError, Message: "boom"},
+		rules.Result{Repo: "org/b", RuleID: "skip-rule", Status: rules.StatusSkipped, Message: "no access"},
+		Event{Type: "repo.finished", Repo: "org/b", DurationMS: 1000},
+	}
+	for _, w := range writes {
+		if err := s.Write(w); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no file before Close, stat err=%v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal(b, &doc); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, b)
+	}
+	if doc.Tests != 4 || doc.Failures != 1 || doc.Errors != 1 || doc.Skipped != 1 || doc.Time != 1.25 {
+		t.Fatalf("unexpected totals: %+v", doc)
+	}
+	if len(doc.Suites) != 2 || doc.Suites[0].Name != "org/a" || doc.Suites[0].Time != 0.25 {
+		t.Fatalf("unexpected suites: %+v", doc.Suites)
+	}
+
+	fail := doc.Suites[0].Cases[1]
+	if fail.Failure == nil || fail.Failure.Message != "missing, badly" {
+		t.Fatalf("expected failure with message, got %+v", fail)
+	}
+	if want := "missing, badly\na: <1>\nz: 2"; fail.Failure.Body != want {
+		t.Fatalf("failure body = %q, want %q", fail.Failure.Body, want)
+	}
+	if doc.Suites[1].Cases[0].Error == nil || doc.Suites[1].Cases[1].Skipped == nil {
+		t.Fatalf("expected error and skipped cases, got %+v", doc.Suites[1].Cases)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Fatalf("temporary file left behind: %s", e.Name())
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OpenMetricsSink writes the scan as OpenMetrics metrics on Close, for the
+// node_exporter textfile collector or any scraper of static files. Like
+// JUnitSink it renames a temporary file into place, so a collector never
+// reads a half-written file.
+//
+// Every metric describes the latest scan; re-running the scan replaces the
+// file. The per-dependency API request count is a counter (its samples carry
+// the _total suffix OpenMetrics requires); everything else is a gauge.
+type OpenMetricsSink struct {
+	path string
+	mu   sync.Mutex
+	now  func() time.Time
+
+	ruleResults  map[ruleResultLabels]int
+	repoFailures map[string]int
+
+	started  time.Time
+	ended    time.Time
+	exitCode int
+	haveExit bool
+	stats    *RunStats
+}
+
+type ruleResultLabels struct {
+	rule, status, org string
+}
+
+func NewOpenMetricsSink(path string) (*OpenMetricsSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("output path required")
+	}
+	dir := filepath.Dir(path)
+	if dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	return &OpenMetricsSink{
+		path:         path,
+		now:          time.Now,
+		ruleResults:  make(map[ruleResultLabels]int),
+		repoFailures: make(map[string]int),
+	}, nil
+}
+
+func (s *OpenMetricsSink) Write(v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch t := v.(type) {
+	case rules.Result:
+		org, _, _ := strings.Cut(t.Repo, "/")
+		s.ruleResults[ruleResultLabels{rule: t.RuleID, status: string(t.Status), org: org}]++
+		if t.Repo != "" {
+			if t.Status == rules.StatusFail {
+				s.repoFailures[t.Repo]++
+			} else if _, ok := s.repoFailures[t.Repo]; !ok {
+				s.repoFailures[t.Repo] = 0
+			}
+		}
+	case Event:
+		switch t.Type {
+		case "run.started":
+			s.started = s.now()
+		case "repo.started":
+			// Repos without failures still get a 0 series, so alerts resolve.
+			if _, ok := s.repoFailures[t.Repo]; !ok && t.Repo != "" {
+				s.repoFailures[t.Repo] = 0
+			}
+		case "run.finished", EventRunInterrupted:
+			s.ended = s.now()
+			s.exitCode = t.ExitCode
+			s.haveExit = true
+			if t.Stats != nil {
+				s.stats = t.Stats
+			}
+		}
+	}
+	return nil
+}
+
+func (s *OpenMetricsSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeFileAtomic(s.path, s.encode)
+}
+
+func (s *OpenMetricsSink) encode(w io.Writer) error {
+	var b strings.Builder
+
+	family := func(name, typ, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+	gauge := func(name, help string) { family(name, "gauge", help) }
+
+	gauge("repomedic_rule_results", "Rule results in the latest scan by rule, status and org.")
+	keys := make([]ruleResultLabels, 0, len(s.ruleResults))
+	for k := range s.ruleResults {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, c := keys[i], keys[j]
+		if a.rule != c.rule {
+			return a.rule < c.rule
+		}
+		if a.status != c.status {
+			return a.status < c.status
+		}
+		return a.org < c.org
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&b, "repomedic_rule_results{rule=%s,status=%s,org=%s} %d\n",
+			metricLabel(k.rule), metricLabel(k.status), metricLabel(k.org), s.ruleResults[k])
+	}
+
+	gauge("repomedic_repo_failures", "FAIL results per repository in the latest scan.")
+	for _, repo := range sortedKeys(s.repoFailures) {
+		fmt.Fprintf(&b, "repomedic_repo_failures{repo=%s} %d\n", metricLabel(repo), s.repoFailures[repo])
+	}
+
+	if !s.started.IsZero() && !s.ended.IsZero() {
+		gauge("repomedic_scan_duration_seconds", "Seconds the latest scan spent scanning repositories (discovery excluded).")
+		fmt.Fprintf(&b, "repomedic_scan_duration_seconds %s\n", metricFloat(s.ended.Sub(s.started).Seconds()))
+	}
+	if s.haveExit {
+		gauge("repomedic_scan_exit_code", "Exit code of the latest scan (0 clean, 1 failures, 2 errors, 3 fatal, 4 interrupted).")
+		fmt.Fprintf(&b, "repomedic_scan_exit_code %d\n", s.exitCode)
+		gauge("repomedic_last_run_timestamp_seconds", "Unix time the latest scan ended.")
+		fmt.Fprintf(&b, "repomedic_last_run_timestamp_seconds %s\n", metricFloat(float64(s.ended.UnixMilli())/1000))
+	}
+
+	if s.stats != nil && s.stats.API != nil {
+		api := s.stats.API
+		family("repomedic_api_requests", "counter", "GitHub API requests made by the latest scan per dependency.")
+		for _, dep := range sortedKeys(api.Requests) {
+			fmt.Fprintf(&b, "repomedic_api_requests_total{dependency=%s} %d\n", metricLabel(dep), api.Requests[dep])
+		}
+		gauge("repomedic_rate_limit_remaining", "GitHub core API rate limit remaining when the latest scan ended, summed across tokens.")
+		fmt.Fprintf(&b, "repomedic_rate_limit_remaining %d\n", api.RateLimitRemaining)
+	}
+
+	b.WriteString("# EOF\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// metricLabel quotes a label value, escaping backslashes, quotes and newlines.
+func metricLabel(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `"`, `\"`)
+	v = strings.ReplaceAll(v, "\n", `\n`)
+	return `"` + v + `"`
+}
+
+func metricFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
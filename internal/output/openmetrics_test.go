@@ -0,0 +1,98 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"testing"
+	"time"
+)
+
+func TestOpenMetricsSink_WritesGauges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics", "repomedic.prom")
+	s, err := NewOpenMetricsSink(path)
+	if err != nil {
+		t.Fatalf("NewOpenMetricsSink failed: %v", err)
+	}
+	start := time.Unix(1760000000, 0)
+	clock := start
+	s.now = func() time.Time { return clock }
+
+	_ = s.Write(Event{Type: "run.started", Repos: 3})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/a"})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "codeowners-exists", Status: rules.StatusFail})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/b"})
+	_ = s.Write(rules.Result{Repo: "acme/b", RuleID: "readme-root-exists", Status: rules.StatusPass})
+	_ = s.Write(Event{Type: "repo.started", Repo: `other/"q"`})
+	_ = s.Write(rules.Result{Repo: `other/"q"`, RuleID: "readme-root-exists", Status: rules.StatusFail})
+	clock = start.Add(90500 * time.Millisecond)
+	_ = s.Write(Event{Type: "run.finished", ExitCode: 1, Stats: &RunStats{API: &APIStats{
+		Requests:           map[string]int{"repo.metadata": 3, "repo.readme": 2},
+		RateLimitRemaining: 4990,
+	}}})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	want := `# HELP repomedic_rule_results Rule results in the latest scan by rule, status and org.
+# TYPE repomedic_rule_results gauge
+repomedic_rule_results{rule="codeowners-exists",status="FAIL",org="acme"} 1
+repomedic_rule_results{rule="readme-root-exists",status="FAIL",org="acme"} 1
+repomedic_rule_results{rule="readme-root-exists",status="FAIL",org="other"} 1
+repomedic_rule_results{rule="readme-root-exists",status="PASS",org="acme"} 1
+# HELP repomedic_repo_failures FAIL results per repository in the latest scan.
+# TYPE repomedic_repo_failures gauge
+repomedic_repo_failures{repo="acme/a"} 2
+repomedic_repo_failures{repo="acme/b"} 0
+repomedic_repo_failures{repo="other/\"q\""} 1
+# HELP repomedic_scan_duration_seconds Seconds the latest scan spent scanning repositories (discovery excluded).
+# TYPE repomedic_scan_duration_seconds gauge
+repomedic_scan_duration_seconds 90.5
+# HELP repomedic_scan_exit_code Exit code of the latest scan (0 clean, 1 failures, 2 errors, 3 fatal, 4 interrupted).
+# TYPE repomedic_scan_exit_code gauge
+repomedic_scan_exit_code 1
+# HELP repomedic_last_run_timestamp_seconds Unix time the latest scan ended.
+# TYPE repomedic_last_run_timestamp_seconds gauge
+repomedic_last_run_timestamp_seconds 1760000090.5
+# HELP repomedic_api_requests GitHub API requests made by the latest scan per dependency.
+# TYPE repomedic_api_requests counter
+repomedic_api_requests_total{dependency="repo.metadata"} 3
+repomedic_api_requests_total{dependency="repo.readme"} 2
+# HELP repomedic_rate_limit_remaining GitHub core API rate limit remaining when the latest scan ended, summed across tokens.
+# TYPE repomedic_rate_limit_remaining gauge
+repomedic_rate_limit_remaining 4990
+# EOF
+`
+	if string(b) != want {
+		t.Fatalf("unexpected metrics:\n%s\nwant:\n%s", b, want)
+	}
+}
+
+func TestOpenMetricsSink_OmitsRunGaugesWithoutRunEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repomedic.prom")
+	s, err := NewOpenMetricsSink(path)
+	if err != nil {
+		t.Fatalf("NewOpenMetricsSink failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	want := `# HELP repomedic_rule_results Rule results in the latest scan by rule, status and org.
+# TYPE repomedic_rule_results gauge
+# HELP repomedic_repo_failures FAIL results per repository in the latest scan.
+# TYPE repomedic_repo_failures gauge
+# EOF
+`
+	if string(b) != want {
+		t.Fatalf("unexpected metrics:\n%s", b)
+	}
+}
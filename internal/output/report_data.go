@@ -0,0 +1,402 @@
+package output
+
+import (
+	"repomedic/internal/rules"
+	"sort"
+	"time"
+)
+
+// ReportData is the data model report templates are executed against. The
+// built-in Markdown report is rendered from it with the default template, and
+// a --report-template is handed exactly the same value.
+//
+// Slices are in a stable order: Repos by name, Categories by risk (highest
+// first), Results in the order the scan produced them.
+type ReportData struct {
+	Run RunInfo
+
+	// GroupBy is the --report-group-by property, or "" when results are not grouped.
+	GroupBy string
+	// Groups breaks results down by the values of GroupBy; nil when not grouped.
+	Groups []ReportGroup
+
+	// HasTeams is true when repos were targeted by team and carry Teams.
+	HasTeams bool
+
+	Brief ReportBrief
+	// Priorities are the immediate remediation priorities, most urgent first.
+	Priorities []string
+
+	// Categories holds the control categories with at least one failing repo.
+	Categories []ReportCategory
+	// TopRiskAreas is Categories without repo hygiene, unless hygiene is the
+	// only failing category.
+	TopRiskAreas []ReportCategory
+
+	Audit ReportAudit
+
+	Repos   []*ReportRepo
+	Results []ReportResult
+	// Waivers lists the failures an allow-list policy turned into passes.
+	Waivers []ReportResult
+	// Baseline splits findings into new and baselined (see Result.Baselined);
+	// nil when the scan ran without --baseline.
+	Baseline *BaselineSummary
+	// SinceLastRun compares findings with the previous recorded run of the
+	// same target; nil unless --history-dir holds one.
+	SinceLastRun *RunDelta
+	// Rules lists the IDs of the rules that produced results, sorted.
+	Rules []string
+
+	Totals ReportTotals
+}
+
+// RunDelta is the change in findings since the previous recorded run.
+type RunDelta struct {
+	PreviousRunAt time.Time
+	*ScanDiff
+}
+
+// RunInfo describes the scan that produced the report.
+type RunInfo struct {
+	// PlannedRepos is the number of repos the run set out to scan; 0 if unknown.
+	PlannedRepos int
+	// EvaluatedRepos is the number of repos scanned before an interruption.
+	EvaluatedRepos int
+	Interrupted    bool
+	// InterruptReason is why the run stopped early ("interrupted" if unknown).
+	InterruptReason string
+	// ExitCode is the run's exit code; HasExitCode is false if the run did
+	// not report one.
+	ExitCode    int
+	HasExitCode bool
+}
+
+// ReportTotals counts results by status across all repos.
+type ReportTotals struct {
+	Repos   int
+	Pass    int
+	Fail    int
+	Error   int
+	Skipped int
+}
+
+// ReportRepo is one scanned repository.
+type ReportRepo struct {
+	Name       string
+	Teams      []string
+	Properties map[string][]string
+
+	Pass    int
+	Fail    int
+	Error   int
+	Skipped int
+
+	// RiskScore weighs failures and errors by how dangerous they are; repos
+	// are ranked by it in "Top Riskiest Repos".
+	RiskScore int
+	// KeyRisks names up to three of the repo's most important failures.
+	KeyRisks []string
+	// FirstFix is the single change that most reduces the repo's risk.
+	FirstFix string
+
+	Results []ReportResult
+}
+
+// ReportResult is a rule result annotated with how the report classifies it.
+type ReportResult struct {
+	rules.Result
+
+	Category string
+	// CategoryKey is a short, stable name for Category (see categoryKeys).
+	CategoryKey string
+	Severity    string
+	// Priority orders findings within a repo; lower is more urgent.
+	Priority int
+
+	Waived bool
+	// WaiverReason is the allow-list policy that waived the failure.
+	WaiverReason string
+}
+
+// ReportCategory summarizes the failures in one control category.
+type ReportCategory struct {
+	Name        string
+	Key         string
+	Description string
+	// Impact is a short phrase describing what the failures allow.
+	Impact   string
+	Severity string
+
+	ReposWithFail int
+	// FailingRepos are the repos with a failure in the category, sorted.
+	FailingRepos []string
+	// Representative lists up to three of the most frequently failing rules.
+	Representative []string
+	FailsByRule    map[string]int
+}
+
+// ReportAudit summarizes how completely repos could be audited.
+type ReportAudit struct {
+	FullyAudited     int
+	PartiallyAudited int
+	Blocked          int
+	Blockers         []ReportBlocker
+}
+
+// ReportBlocker is a reason repos could not be audited.
+type ReportBlocker struct {
+	Reason    string
+	RepoCount int
+	// ExampleRepos lists up to five of the blocked repos, sorted.
+	ExampleRepos  []string
+	ImpactedRules []string
+	// ImpactedAreas are the categories of ImpactedRules, sorted.
+	ImpactedAreas []string
+}
+
+// ReportGroup counts results for one value of the GroupBy property.
+type ReportGroup struct {
+	Value        string
+	Repos        int
+	FailingRepos int
+	Fail         int
+	Error        int
+}
+
+// categoryKeys gives templates short names to match categories on.
+var categoryKeys = map[string]string{
+	CategoryDefaultBranchProtections: "default-branch",
+	CategoryBranchDeletion:           "branch-deletion",
+	CategoryRulesetEnforcement:       "rulesets",
+	CategoryExposure:                 "exposure",
+	CategoryRepoHygiene:              "hygiene",
+	CategoryNoBranchProtection:       "no-branch-protection",
+	CategoryOther:                    "other",
+}
+
+var categoryImpact = map[string]string{
+	CategoryDefaultBranchProtections: "can bypass CI/CD & review",
+	CategoryBranchDeletion:           "can compromise audit history",
+	CategoryRulesetEnforcement:       "protections not active",
+	CategoryExposure:                 "can expose IP/secrets",
+	CategoryRepoHygiene:              "delays incident response",
+	CategoryNoBranchProtection:       "direct push to the default branch",
+}
+
+func newReportResult(r rules.Result) ReportResult {
+	cat := getCategory(r.RuleID)
+	waiver := r.Waiver()
+	return ReportResult{
+		Result:       r,
+		Category:     cat,
+		CategoryKey:  categoryKeys[cat],
+		Severity:     getSeverity(r.RuleID),
+		Priority:     getPriority(r.RuleID),
+		Waived:       waiver != "",
+		WaiverReason: waiver,
+	}
+}
+
+// reportData builds the template data model from what the sink collected.
+func (s *reportState) reportData(groupBy string) *ReportData {
+	repos := s.sortedRepos()
+	perRepo := collectRepoStats(repos, s.results)
+	audit := computeAuditStats(perRepo)
+
+	d := &ReportData{
+		Run: RunInfo{
+			PlannedRepos:   s.plannedRepos,
+			EvaluatedRepos: s.evaluatedRepos,
+			Interrupted:    s.interrupted,
+			ExitCode:       s.exitCode,
+			HasExitCode:    s.haveExitCode,
+		},
+		GroupBy:      groupBy,
+		HasTeams:     len(s.repoTeams) > 0,
+		Baseline:     s.baseline,
+		SinceLastRun: s.sinceLastRun(),
+		Brief:        computeRiskBrief(s.results, perRepo, audit),
+	}
+	if s.interrupted {
+		d.Run.InterruptReason, _ = partialCoverage(s.interruptReason, s.evaluatedRepos, s.plannedRepos)
+	}
+
+	uniqueRules := make(map[string]struct{})
+	for _, r := range s.results {
+		rr := newReportResult(r)
+		d.Results = append(d.Results, rr)
+		if rr.Waived {
+			d.Waivers = append(d.Waivers, rr)
+		}
+		if r.RuleID != "" {
+			uniqueRules[r.RuleID] = struct{}{}
+		}
+	}
+	d.Rules = sortedKeys(uniqueRules)
+
+	for _, repo := range repos {
+		rs := perRepo[repo]
+		rr := &ReportRepo{
+			Name:       repo,
+			Teams:      s.repoTeams[repo],
+			Properties: s.repoProps[repo],
+			Pass:       rs.Pass,
+			Fail:       rs.Fail,
+			Error:      rs.Error,
+			Skipped:    rs.Skipped,
+			RiskScore:  computeRiskScore(rs),
+			KeyRisks:   rs.KeyRisks(),
+			FirstFix:   firstFix(rs),
+		}
+		for _, r := range rs.Results {
+			rr.Results = append(rr.Results, newReportResult(r))
+		}
+		d.Repos = append(d.Repos, rr)
+
+		d.Totals.Repos++
+		d.Totals.Pass += rs.Pass
+		d.Totals.Fail += rs.Fail
+		d.Totals.Error += rs.Error
+		d.Totals.Skipped += rs.Skipped
+	}
+
+	catStats := computeCategoryStats(s.results)
+	for _, cs := range catStats {
+		c := ReportCategory{
+			Name:           cs.Name,
+			Key:            categoryKeys[cs.Name],
+			Description:    CategoryRiskDescription[cs.Name],
+			Impact:         "impact unknown",
+			Severity:       getCategorySeverity(cs.Name),
+			ReposWithFail:  cs.ReposWithFail,
+			Representative: cs.Representative,
+			FailsByRule:    cs.FailsByRule,
+		}
+		if impact, ok := categoryImpact[cs.Name]; ok {
+			c.Impact = impact
+		}
+		for _, repo := range repos {
+			for _, r := range perRepo[repo].Results {
+				if r.Status == rules.StatusFail && getCategory(r.RuleID) == cs.Name {
+					c.FailingRepos = append(c.FailingRepos, repo)
+					break
+				}
+			}
+		}
+		d.Categories = append(d.Categories, c)
+		// Repo hygiene is only a top risk area when nothing else failed.
+		if cs.Name != CategoryRepoHygiene || len(catStats) == 1 {
+			d.TopRiskAreas = append(d.TopRiskAreas, c)
+		}
+	}
+
+	d.Audit = ReportAudit{
+		FullyAudited:     audit.FullyAudited,
+		PartiallyAudited: audit.PartiallyAudited,
+		Blocked:          audit.Blocked,
+	}
+	for _, bi := range audit.Blockers {
+		areas := make(map[string]struct{})
+		for _, ruleID := range bi.ImpactedRules {
+			areas[getCategory(ruleID)] = struct{}{}
+		}
+		d.Audit.Blockers = append(d.Audit.Blockers, ReportBlocker{
+			Reason:        bi.Reason,
+			RepoCount:     bi.RepoCount,
+			ExampleRepos:  bi.ExampleRepos,
+			ImpactedRules: bi.ImpactedRules,
+			ImpactedAreas: sortedKeys(areas),
+		})
+	}
+
+	if d.Brief.UnsafeDefaultBranch > 0 {
+		d.Priorities = append(d.Priorities, "lock down default branches")
+	}
+	if d.Brief.PublicFail > 0 {
+		d.Priorities = append(d.Priorities, "restrict public access")
+	}
+	if audit.Blocked > 0 {
+		d.Priorities = append(d.Priorities, "resolve audit blockers")
+	}
+
+	if groupBy != "" {
+		d.Groups = reportGroups(groupBy, repos, perRepo, s.repoProps)
+	}
+	return d
+}
+
+// firstFix picks the remediation that should be applied to a repo first.
+func firstFix(rs *repoStats) string {
+	hasFail := func(ruleID string) bool {
+		for _, r := range rs.Results {
+			if r.RuleID == ruleID && r.Status == rules.StatusFail {
+				return true
+			}
+		}
+		return false
+	}
+
+	hasCategoryFail := func(cat string) bool {
+		for _, r := range rs.Results {
+			if r.Status == rules.StatusFail && getCategory(r.RuleID) == cat {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case hasCategoryFail(CategoryDefaultBranchProtections) || hasFail("default-branch-protected") || hasFail("default-branch-required-status-checks"):
+		return "Enforce default-branch gates (protection + checks + PR)"
+	case hasFail("repo-visibility-public"):
+		return "Confirm intentional public; otherwise restrict or allow-list"
+	case hasFail("branch-protect-enforce-admins") || hasFail("protected-branches-block-deletion"):
+		return "Ensure protections apply to administrators / block deletion"
+	case hasFail("secret-scanning-disabled"):
+		return "Enable secret scanning (if available)"
+	}
+	return "Assign ownership + documentation baseline (CODEOWNERS/README/description)"
+}
+
+// unsetPropertyValue labels repos that have no value for the grouping property.
+const unsetPropertyValue = "(unset)"
+
+// reportGroups counts results per value of a custom property. A repo with a
+// multi-select value is counted under each of its values; repos without a
+// value are grouped under unsetPropertyValue, which sorts last.
+func reportGroups(property string, repos []string, perRepo map[string]*repoStats, repoProps map[string]map[string][]string) []ReportGroup {
+	groups := make(map[string]*ReportGroup)
+	for _, repo := range repos {
+		values := repoProps[repo][property]
+		if len(values) == 0 {
+			values = []string{unsetPropertyValue}
+		}
+		rs := perRepo[repo]
+		for _, v := range values {
+			g := groups[v]
+			if g == nil {
+				g = &ReportGroup{Value: v}
+				groups[v] = g
+			}
+			g.Repos++
+			g.Fail += rs.Fail
+			g.Error += rs.Error
+			if rs.Fail > 0 {
+				g.FailingRepos++
+			}
+		}
+	}
+
+	out := make([]ReportGroup, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if (out[i].Value == unsetPropertyValue) != (out[j].Value == unsetPropertyValue) {
+			return out[j].Value == unsetPropertyValue
+		}
+		return out[i].Value < out[j].Value
+	})
+	return out
+}
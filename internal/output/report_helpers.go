@@ -63,6 +63,34 @@ var CategoryRiskDescription = map[string]string{
 	CategoryNoBranchProtection:       "Unprotected repositories allow anyone with write access to push directly to the default branch.",
 }
 
+// Severities rank a rule's failure by the risk of its category.
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+	SeverityLow    = "low"
+)
+
+var categorySeverity = map[string]string{
+	CategoryExposure:                 SeverityHigh,
+	CategoryDefaultBranchProtections: SeverityHigh,
+	CategoryNoBranchProtection:       SeverityHigh,
+	CategoryBranchDeletion:           SeverityMedium,
+	CategoryRulesetEnforcement:       SeverityMedium,
+	CategoryRepoHygiene:              SeverityLow,
+}
+
+// getSeverity returns the severity of a rule; uncategorized rules are low.
+func getSeverity(ruleID string) string {
+	return getCategorySeverity(getCategory(ruleID))
+}
+
+func getCategorySeverity(category string) string {
+	if sev, ok := categorySeverity[category]; ok {
+		return sev
+	}
+	return SeverityLow
+}
+
 func getCategory(ruleID string) string {
 	if cat, ok := ruleCategories[ruleID]; ok {
 		return cat
@@ -156,6 +184,124 @@ func (r *repoStats) KeyRisks() []string {
 	return risks
 }
 
+// collectRepoStats tallies results per repo. Every repo in repos gets an entry,
+// even if it produced no results.
+func collectRepoStats(repos []string, results []rules.Result) map[string]*repoStats {
+	perRepo := make(map[string]*repoStats, len(repos))
+	for _, repo := range repos {
+		perRepo[repo] = &repoStats{Repo: repo}
+	}
+	for _, r := range results {
+		if r.Repo == "" {
+			continue
+		}
+		rs, ok := perRepo[r.Repo]
+		if !ok {
+			rs = &repoStats{Repo: r.Repo}
+			perRepo[r.Repo] = rs
+		}
+		rs.Results = append(rs.Results, r)
+		switch r.Status {
+		case rules.StatusPass:
+			rs.Pass++
+		case rules.StatusFail:
+			rs.Fail++
+		case rules.StatusSkipped:
+			rs.Skipped++
+		case rules.StatusError:
+			rs.Error++
+		}
+	}
+	return perRepo
+}
+
+// ReportBrief is the executive summary shared by the Markdown and HTML reports.
+// Found, Why and Todo are plain-text bullet lines.
+type ReportBrief struct {
+	PublicFail          int
+	UnsafeDefaultBranch int
+	Blocked403          int
+
+	Found []string
+	Why   []string
+	Todo  []string
+}
+
+// HasRisks reports whether any of the headline risks were found.
+func (b ReportBrief) HasRisks() bool {
+	return b.PublicFail > 0 || b.UnsafeDefaultBranch > 0 || b.Blocked403 > 0
+}
+
+func computeRiskBrief(results []rules.Result, perRepo map[string]*repoStats, audit *auditStats) ReportBrief {
+	var b ReportBrief
+	hasFails := false
+	// 1. Public and not allow-listed
+	for _, r := range results {
+		if r.Status != rules.StatusFail {
+			continue
+		}
+		hasFails = true
+		if r.RuleID == "repo-visibility-public" {
+			b.PublicFail++
+		}
+	}
+
+	// 2. Default branch not safe
+	for _, rs := range perRepo {
+		for _, r := range rs.Results {
+			if r.Status == rules.StatusFail {
+				if getCategory(r.RuleID) == CategoryDefaultBranchProtections ||
+					r.RuleID == "default-branch-protected" ||
+					r.RuleID == "default-branch-required-status-checks" {
+					b.UnsafeDefaultBranch++
+					break
+				}
+			}
+		}
+	}
+
+	// 3. Blocked due to 403
+	for _, bInfo := range audit.Blockers {
+		if strings.Contains(bInfo.Reason, "403 Forbidden") {
+			b.Blocked403 += bInfo.RepoCount
+		}
+	}
+
+	if b.PublicFail > 0 {
+		b.Found = append(b.Found, fmt.Sprintf("%d repos are publicly visible and not allow-listed.", b.PublicFail))
+	}
+	if b.UnsafeDefaultBranch > 0 {
+		b.Found = append(b.Found, fmt.Sprintf("%d repos allow merges to the default branch without required status checks/protection.", b.UnsafeDefaultBranch))
+	}
+	if b.Blocked403 > 0 {
+		b.Found = append(b.Found, fmt.Sprintf("%d repos could not be audited for protections due to plan/permissions (403).", b.Blocked403))
+	}
+
+	if b.UnsafeDefaultBranch > 0 {
+		b.Why = append(b.Why, "Unprotected default branches allow direct pushes that can bypass CI/CD and peer review.")
+		b.Todo = append(b.Todo, "Lock down default branches on high-risk repositories to prevent direct pushes and enforce review.")
+	}
+	if b.PublicFail > 0 {
+		b.Why = append(b.Why, "Public repositories without strict controls increase the risk of accidental secret exposure.")
+		b.Todo = append(b.Todo, "Restrict public repository access or implement strict allow-listing to prevent data exposure.")
+	}
+	if b.Blocked403 > 0 {
+		b.Why = append(b.Why, "Blind spots in audit coverage prevent accurate risk assessment and can hide drift.")
+		b.Todo = append(b.Todo, "Audit permissions and resolve blockers to ensure complete visibility into repository protections.")
+	}
+
+	if !b.HasRisks() {
+		b.Found = []string{"No critical high-level risks found."}
+		b.Why = []string{"Continuous monitoring ensures security posture remains strong."}
+		if hasFails {
+			b.Todo = []string{"Review and remediate critical findings starting with the most exposed repositories."}
+		} else {
+			b.Todo = []string{"No immediate actions required."}
+		}
+	}
+	return b
+}
+
 type categoryStats struct {
 	Name           string
 	ReposWithFail  int
@@ -0,0 +1,365 @@
+package output
+
+import (
+	"cmp"
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+// defaultReportTemplate is the built-in Markdown report. It is the reference
+// example for --report-template.
+//
+//go:embed templates/report.md.tmpl
+var defaultReportTemplate string
+
+// reportTemplate is a parsed text/template or html/template.
+type reportTemplate interface {
+	Execute(w io.Writer, data any) error
+}
+
+// parseReportTemplate parses src with the report template functions. HTML
+// templates get html/template's contextual escaping; anything else is text.
+func parseReportTemplate(name, src string) (reportTemplate, error) {
+	if isHTMLTemplateName(name) {
+		return htmltemplate.New(name).Funcs(htmltemplate.FuncMap(reportTemplateFuncs)).Parse(src)
+	}
+	return texttemplate.New(name).Funcs(texttemplate.FuncMap(reportTemplateFuncs)).Parse(src)
+}
+
+// loadReportTemplate reads and parses a user-supplied report template.
+func loadReportTemplate(path string) (reportTemplate, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report template: %w", err)
+	}
+	t, err := parseReportTemplate(filepath.Base(path), string(src))
+	if err != nil {
+		return nil, fmt.Errorf("invalid report template: %w", err)
+	}
+	return t, nil
+}
+
+// isHTMLTemplateName reports whether a template file renders HTML, judged by
+// its extension once a template suffix is removed ("report.html.tmpl").
+func isHTMLTemplateName(name string) bool {
+	name = strings.ToLower(name)
+	for _, suffix := range []string{".tmpl", ".gotmpl", ".tpl"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	ext := filepath.Ext(name)
+	return ext == ".html" || ext == ".htm"
+}
+
+// reportTemplateFuncs are available to every report template. The list helpers
+// take the list last so they can be used in pipelines:
+//
+//	{{range .Results | where "Status" "FAIL" | minSeverity "high" | sortBy "Repo,RuleID"}}
+var reportTemplateFuncs = map[string]any{
+	"where":       tmplWhere,
+	"whereNot":    tmplWhereNot,
+	"whereIn":     tmplWhereIn,
+	"whereNotIn":  tmplWhereNotIn,
+	"minSeverity": tmplMinSeverity,
+	"sortBy":      tmplSortBy,
+	"groupBy":     tmplGroupBy,
+	"pluck":       tmplPluck,
+	"first":       tmplFirst,
+	"list":        func(items ...any) []any { return items },
+	"sortStrings": tmplSortStrings,
+	"join":        func(sep string, items []string) string { return strings.Join(items, sep) },
+	"repoList":    func(max int, repos []string) string { return formatRepoList(repos, max) },
+	"repoSample":  tmplRepoSample,
+	"lower":       strings.ToLower,
+	"upper":       strings.ToUpper,
+	"add":         func(a, b int) int { return a + b },
+}
+
+// TemplateGroup is one group produced by the groupBy template function.
+type TemplateGroup struct {
+	Key   string
+	Items any
+}
+
+func tmplWhere(field string, value any, list any) (any, error) {
+	want := fmt.Sprint(value)
+	return filterList(list, field, func(v string) bool { return v == want })
+}
+
+func tmplWhereNot(field string, value any, list any) (any, error) {
+	want := fmt.Sprint(value)
+	return filterList(list, field, func(v string) bool { return v != want })
+}
+
+func tmplWhereIn(field string, values []any, list any) (any, error) {
+	set := stringSet(values)
+	return filterList(list, field, func(v string) bool { _, ok := set[v]; return ok })
+}
+
+func tmplWhereNotIn(field string, values []any, list any) (any, error) {
+	set := stringSet(values)
+	return filterList(list, field, func(v string) bool { _, ok := set[v]; return !ok })
+}
+
+var severityRank = map[string]int{SeverityLow: 1, SeverityMedium: 2, SeverityHigh: 3}
+
+// tmplMinSeverity keeps items whose Severity is at least level.
+func tmplMinSeverity(level string, list any) (any, error) {
+	min, ok := severityRank[strings.ToLower(level)]
+	if !ok {
+		return nil, fmt.Errorf("minSeverity: unknown severity %q (want high, medium or low)", level)
+	}
+	return filterList(list, "Severity", func(v string) bool { return severityRank[v] >= min })
+}
+
+// tmplSortBy sorts a copy of list by a comma-separated list of fields; a
+// leading "-" sorts that field in descending order. The sort is stable.
+func tmplSortBy(spec string, list any) (any, error) {
+	lv, err := listValue(list)
+	if err != nil {
+		return nil, err
+	}
+	type key struct {
+		field string
+		desc  bool
+	}
+	var keys []key
+	for _, f := range strings.Split(spec, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		k := key{field: f}
+		if strings.HasPrefix(f, "-") {
+			k = key{field: f[1:], desc: true}
+		}
+		keys = append(keys, k)
+	}
+
+	fields := make([][]reflect.Value, lv.Len())
+	for i := range fields {
+		for _, k := range keys {
+			fv, err := fieldValue(lv.Index(i), k.field)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = append(fields[i], fv)
+		}
+	}
+	idx := make([]int, lv.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		for n, k := range keys {
+			c := compareValues(fields[idx[a]][n], fields[idx[b]][n])
+			if c == 0 {
+				continue
+			}
+			if k.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	sorted := reflect.MakeSlice(lv.Type(), lv.Len(), lv.Len())
+	for i, j := range idx {
+		sorted.Index(i).Set(lv.Index(j))
+	}
+	return sorted.Interface(), nil
+}
+
+// tmplGroupBy groups list by the value of field, in key order. Items keep
+// their order within a group.
+func tmplGroupBy(field string, list any) ([]TemplateGroup, error) {
+	lv, err := listValue(list)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[string]reflect.Value)
+	for i := 0; i < lv.Len(); i++ {
+		fv, err := fieldValue(lv.Index(i), field)
+		if err != nil {
+			return nil, err
+		}
+		k := fmt.Sprint(fv.Interface())
+		g, ok := items[k]
+		if !ok {
+			g = reflect.MakeSlice(lv.Type(), 0, 1)
+		}
+		items[k] = reflect.Append(g, lv.Index(i))
+	}
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]TemplateGroup, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, TemplateGroup{Key: k, Items: items[k].Interface()})
+	}
+	return out, nil
+}
+
+// tmplPluck returns the value of field for each item in list.
+func tmplPluck(field string, list any) ([]string, error) {
+	lv, err := listValue(list)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, lv.Len())
+	for i := 0; i < lv.Len(); i++ {
+		fv, err := fieldValue(lv.Index(i), field)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, fmt.Sprint(fv.Interface()))
+	}
+	return out, nil
+}
+
+// tmplFirst returns at most the first n items of list.
+func tmplFirst(n int, list any) (any, error) {
+	lv, err := listValue(list)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		n = 0
+	}
+	if lv.Len() > n {
+		lv = lv.Slice(0, n)
+	}
+	return lv.Interface(), nil
+}
+
+func tmplSortStrings(items []string) []string {
+	out := append([]string(nil), items...)
+	sort.Strings(out)
+	return out
+}
+
+// tmplRepoSample formats up to max repos as "(a, b, +N more)"; empty for none.
+func tmplRepoSample(max int, repos []string) string {
+	if len(repos) == 0 {
+		return ""
+	}
+	if len(repos) <= max {
+		return fmt.Sprintf("(%s)", strings.Join(repos, ", "))
+	}
+	return fmt.Sprintf("(%s, +%d more)", strings.Join(repos[:max], ", "), len(repos)-max)
+}
+
+func stringSet(values []any) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		set[fmt.Sprint(v)] = struct{}{}
+	}
+	return set
+}
+
+func listValue(list any) (reflect.Value, error) {
+	lv := reflect.ValueOf(list)
+	if !lv.IsValid() {
+		return reflect.ValueOf([]any{}), nil
+	}
+	if lv.Kind() != reflect.Slice && lv.Kind() != reflect.Array {
+		return reflect.Value{}, fmt.Errorf("expected a list, got %T", list)
+	}
+	if lv.Kind() == reflect.Array {
+		s := reflect.MakeSlice(reflect.SliceOf(lv.Type().Elem()), lv.Len(), lv.Len())
+		reflect.Copy(s, lv)
+		lv = s
+	}
+	return lv, nil
+}
+
+// filterList returns the items of list whose field, formatted with fmt.Sprint,
+// satisfies keep. The result has the same element type as list.
+func filterList(list any, field string, keep func(string) bool) (any, error) {
+	lv, err := listValue(list)
+	if err != nil {
+		return nil, err
+	}
+	out := reflect.MakeSlice(lv.Type(), 0, lv.Len())
+	for i := 0; i < lv.Len(); i++ {
+		fv, err := fieldValue(lv.Index(i), field)
+		if err != nil {
+			return nil, err
+		}
+		if keep(fmt.Sprint(fv.Interface())) {
+			out = reflect.Append(out, lv.Index(i))
+		}
+	}
+	return out.Interface(), nil
+}
+
+// fieldValue looks up an exported struct field (including promoted fields) or
+// a string map key on item, following pointers and interfaces.
+func fieldValue(item reflect.Value, field string) (reflect.Value, error) {
+	for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+		if item.IsNil() {
+			return reflect.Value{}, fmt.Errorf("field %s: nil item", field)
+		}
+		item = item.Elem()
+	}
+	switch item.Kind() {
+	case reflect.Struct:
+		sf, ok := item.Type().FieldByName(field)
+		if !ok || !sf.IsExported() {
+			return reflect.Value{}, fmt.Errorf("%s has no field %s", item.Type(), field)
+		}
+		return item.FieldByIndex(sf.Index), nil
+	case reflect.Map:
+		if item.Type().Key().Kind() != reflect.String {
+			break
+		}
+		v := item.MapIndex(reflect.ValueOf(field).Convert(item.Type().Key()))
+		if !v.IsValid() {
+			return reflect.Zero(item.Type().Elem()), nil
+		}
+		return v, nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot read field %s of %s", field, item.Type())
+}
+
+// compareValues orders numbers numerically, booleans false first and anything
+// else by its formatted string.
+func compareValues(a, b reflect.Value) int {
+	for a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return cmp.Compare(a.Int(), b.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return cmp.Compare(a.Uint(), b.Uint())
+		case reflect.Float32, reflect.Float64:
+			return cmp.Compare(a.Float(), b.Float())
+		case reflect.Bool:
+			return cmp.Compare(boolInt(a.Bool()), boolInt(b.Bool()))
+		case reflect.String:
+			return strings.Compare(a.String(), b.String())
+		}
+	}
+	return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,120 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"strings"
+	"testing"
+)
+
+func writeTemplateReport(t *testing.T, templateName, src string, groupBy string) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	tmplPath := filepath.Join(tmpDir, templateName)
+	if err := os.WriteFile(tmplPath, []byte(src), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	reportPath := filepath.Join(tmpDir, "report.out")
+
+	s, err := NewTemplateReportSink(reportPath, tmplPath)
+	if err != nil {
+		t.Fatalf("NewTemplateReportSink failed: %v", err)
+	}
+	s.SetGroupBy(groupBy)
+
+	_ = s.Write(Event{Type: "run.started", Repos: 2})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/a", Properties: map[string][]string{"tier": {"critical"}}})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/b"})
+	_ = s.Write(rules.Result{Repo: "acme/b", RuleID: "readme-root-exists", Status: rules.StatusFail, Message: "<no README>"})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "secret-scanning-disabled", Status: rules.StatusFail})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "default-branch-protected", Status: rules.StatusFail})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "repo-visibility-public", Status: rules.StatusPass,
+		Metadata: map[string]any{rules.MetadataWaiver: "allow.repos"}})
+	_ = s.Write(Event{Type: "run.finished", ExitCode: 2})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	b, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	return string(b)
+}
+
+func TestTemplateReport_DataModelAndHelpers(t *testing.T) {
+	src := `exit={{.Run.ExitCode}} planned={{.Run.PlannedRepos}} fail={{.Totals.Fail}}
+{{range .Results | where "Status" "FAIL" | minSeverity "high" | sortBy "Priority"}}high {{.Repo}} {{.RuleID}}
+{{end}}{{range .Repos | sortBy "-Fail,Name"}}repo {{.Name}} {{.Fail}}
+{{end}}{{range groupBy "Repo" .Results}}group {{.Key}} {{len .Items}}
+{{end}}{{range .Waivers}}waived {{.Repo}} {{.RuleID}} by {{.WaiverReason}}
+{{end}}{{range .Groups}}tier {{.Value}} {{.Repos}}
+{{end}}{{range .Categories}}category {{.Key}} {{.Severity}} {{join "," .FailingRepos}}
+{{end}}`
+	out := writeTemplateReport(t, "summary.txt.tmpl", src, "tier")
+
+	want := `exit=2 planned=2 fail=3
+high acme/a default-branch-protected
+high acme/a secret-scanning-disabled
+repo acme/a 2
+repo acme/b 1
+group acme/a 3
+group acme/b 1
+waived acme/a repo-visibility-public by allow.repos
+tier critical 1
+tier (unset) 1
+category exposure high acme/a
+category default-branch high acme/a
+category hygiene low acme/b
+`
+	if out != want {
+		t.Fatalf("unexpected output:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestTemplateReport_TextTemplateDoesNotEscape(t *testing.T) {
+	out := writeTemplateReport(t, "summary.md", `{{range .Results | where "RuleID" "readme-root-exists"}}{{.Message}}{{end}}`, "")
+	if out != "<no README>" {
+		t.Fatalf("expected raw message, got %q", out)
+	}
+}
+
+func TestTemplateReport_HTMLTemplateEscapes(t *testing.T) {
+	out := writeTemplateReport(t, "summary.html.tmpl", `<p>{{range .Results | where "RuleID" "readme-root-exists"}}{{.Message}}{{end}}</p>`, "")
+	if out != "<p>&lt;no README&gt;</p>" {
+		t.Fatalf("expected escaped message, got %q", out)
+	}
+}
+
+func TestNewTemplateReportSink_InvalidTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmplPath := filepath.Join(tmpDir, "bad.tmpl")
+	if err := os.WriteFile(tmplPath, []byte("{{range .Repos}}"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	reportPath := filepath.Join(tmpDir, "report.md")
+
+	if _, err := NewTemplateReportSink(reportPath, tmplPath); err == nil || !strings.Contains(err.Error(), "invalid report template") {
+		t.Fatalf("expected invalid template error, got %v", err)
+	}
+	if _, err := os.Stat(reportPath); !os.IsNotExist(err) {
+		t.Fatalf("expected no report file for an invalid template, stat err = %v", err)
+	}
+}
+
+func TestTemplateReport_ExecutionErrorIsReported(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmplPath := filepath.Join(tmpDir, "bad.tmpl")
+	if err := os.WriteFile(tmplPath, []byte(`{{.Results | sortBy "Nope"}}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	s, err := NewTemplateReportSink(filepath.Join(tmpDir, "report.md"), tmplPath)
+	if err != nil {
+		t.Fatalf("NewTemplateReportSink failed: %v", err)
+	}
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "r", Status: rules.StatusPass})
+	if err := s.Close(); err == nil || !strings.Contains(err.Error(), "has no field Nope") {
+		t.Fatalf("expected missing field error, got %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"repomedic/internal/rules"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMarkdownReportContract(t *testing.T) {
@@ -145,3 +146,194 @@ func TestNormalizeErrorReason(t *testing.T) {
 		}
 	}
 }
+
+func TestReportSink_InterruptedRunHasPartialBanner(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.md")
+	s, err := NewReportSink(reportPath)
+	if err != nil {
+		t.Fatalf("NewReportSink failed: %v", err)
+	}
+
+	_ = s.Write(Event{Type: "run.started", Repos: 3, Rules: 1})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/a"})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "default-branch-protected", Status: rules.StatusFail})
+	_ = s.Write(Event{Type: "repo.finished", Repo: "acme/a"})
+	_ = s.Write(Event{Type: EventRunInterrupted, Repos: 3, Evaluated: 1, Reason: "interrupt signal received", ExitCode: 4})
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	b, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	out := string(b)
+
+	banner := strings.Index(out, "**Partial report:**")
+	brief := strings.Index(out, "### 🚨 Executive Risk Brief")
+	if banner == -1 {
+		t.Fatalf("expected partial-report banner, got:\n%s", out)
+	}
+	if brief != -1 && banner > brief {
+		t.Fatalf("expected banner before the executive brief")
+	}
+	if !strings.Contains(out, "interrupt signal received") || !strings.Contains(out, "1 of 3 planned repositories") {
+		t.Fatalf("expected reason and coverage in banner, got:\n%s", out)
+	}
+}
+
+func TestReportSink_CompletedRunHasNoPartialBanner(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.md")
+	s, err := NewReportSink(reportPath)
+	if err != nil {
+		t.Fatalf("NewReportSink failed: %v", err)
+	}
+	_ = s.Write(Event{Type: "run.started", Repos: 1, Rules: 1})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "default-branch-protected", Status: rules.StatusPass})
+	_ = s.Write(Event{Type: "run.finished", ExitCode: 0})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	b, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if strings.Contains(string(b), "Partial report") {
+		t.Fatalf("expected no partial-report banner for a completed run")
+	}
+}
+
+func TestReportSink_GroupByProperty(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.md")
+	s, err := NewReportSink(reportPath)
+	if err != nil {
+		t.Fatalf("NewReportSink failed: %v", err)
+	}
+	s.SetGroupBy("tier")
+
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/a", Properties: map[string][]string{"tier": {"critical"}}})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/b", Properties: map[string][]string{"tier": {"critical", "pci"}}})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/c"})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "default-branch-protected", Status: rules.StatusFail})
+	_ = s.Write(rules.Result{Repo: "acme/b", RuleID: "default-branch-protected", Status: rules.StatusPass})
+	_ = s.Write(rules.Result{Repo: "acme/c", RuleID: "default-branch-protected", Status: rules.StatusError})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	b, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	out := string(b)
+	for _, want := range []string{
+		"## Results by tier",
+		"| tier | Repos | Repos Failing | FAIL | ERROR |",
+		"| critical | 2 | 1 | 1 | 0 |",
+		"| pci | 1 | 0 | 0 | 0 |",
+		"| (unset) | 1 | 0 | 0 | 1 |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Index(out, "| pci |") > strings.Index(out, "| (unset) |") {
+		t.Errorf("expected (unset) to sort last")
+	}
+}
+
+func TestReportSink_PerRepoStatusListsTeams(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.md")
+	s, err := NewReportSink(reportPath)
+	if err != nil {
+		t.Fatalf("NewReportSink failed: %v", err)
+	}
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/api", Teams: []string{"acme/platform", "acme/platform-infra"}})
+	_ = s.Write(rules.Result{Repo: "acme/api", RuleID: "default-branch-protected", Status: rules.StatusFail})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	b, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	out := string(b)
+	if !strings.Contains(out, "| Repo | Teams | FAIL | ERROR | Key Risks |") {
+		t.Fatalf("expected Teams column in per-repo status")
+	}
+	if !strings.Contains(out, "| acme/api | acme/platform, acme/platform-infra | 1 | 0 |") {
+		t.Fatalf("expected team list for acme/api, got:\n%s", out)
+	}
+}
+
+func TestReportSink_BaselineBannerAndMarker(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.md")
+	s, err := NewReportSink(reportPath)
+	if err != nil {
+		t.Fatalf("NewReportSink failed: %v", err)
+	}
+
+	_ = s.Write(Event{Type: "run.started", Repos: 1, Rules: 2})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/a"})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail, Baselined: true})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "default-branch-protected", Status: rules.StatusFail})
+	_ = s.Write(Event{Type: "repo.finished", Repo: "acme/a"})
+	_ = s.Write(Event{Type: "run.finished", ExitCode: 1, Baseline: &BaselineSummary{New: 1, Baselined: 1}})
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	b, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	out := string(b)
+
+	if !strings.Contains(out, "> **Baseline:** 1 new findings, 1 baselined.") {
+		t.Fatalf("expected baseline banner, got:\n%s", out)
+	}
+	if !strings.Contains(out, "readme-root-exists") || !strings.Contains(out, "_(baselined)_") {
+		t.Fatalf("expected baselined marker, got:\n%s", out)
+	}
+}
+
+func TestReportSink_SinceLastRun(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.md")
+	s, err := NewReportSink(reportPath)
+	if err != nil {
+		t.Fatalf("NewReportSink failed: %v", err)
+	}
+	s.SetPreviousRun(&HistoryRun{
+		At:    time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+		Repos: []string{"acme/a"},
+		Results: []HistoryResult{
+			{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusFail},
+			{Repo: "acme/a", RuleID: "default-branch-protected", Status: rules.StatusPass},
+		},
+	})
+
+	_ = s.Write(Event{Type: "run.started", Repos: 1, Rules: 2})
+	_ = s.Write(Event{Type: "repo.started", Repo: "acme/a"})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "readme-root-exists", Status: rules.StatusPass})
+	_ = s.Write(rules.Result{Repo: "acme/a", RuleID: "default-branch-protected", Status: rules.StatusFail})
+	_ = s.Write(Event{Type: "run.finished", ExitCode: 1})
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	b, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	out := string(b)
+
+	for _, want := range []string{
+		"## Since Last Run",
+		"Compared with the scan of 2026-10-01 09:30 UTC: 1 new findings, 1 resolved, 0 changed status, 0 still failing.",
+		"**New findings**\n- acme/a **default-branch-protected**: PASS → FAIL",
+		"**Resolved**\n- acme/a **readme-root-exists**: FAIL → PASS",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in report, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"repomedic/internal/rules"
+	"sync"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	// sarifFingerprintKey versions the fingerprint so its inputs can change
+	// without colliding with earlier logs.
+	sarifFingerprintKey = "repomedic/v1"
+)
+
+// SARIFSink writes FAIL and ERROR results as a SARIF 2.1.0 log when closed.
+// The log is written to a temporary file and renamed into place, so an
+// upload never picks up an empty or truncated log.
+type SARIFSink struct {
+	path     string
+	mu       sync.Mutex
+	rules    []rules.Rule
+	results  []rules.Result
+	exitCode int
+	finished bool
+}
+
+// NewSARIFSink creates a SARIF sink at path. ruleList becomes the tool's
+// rule descriptors, so results can reference them by index.
+func NewSARIFSink(path string, ruleList []rules.Rule) (*SARIFSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("output path required")
+	}
+	if dir := filepath.Dir(path); dir != "." && dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+	return &SARIFSink{path: path, rules: ruleList}, nil
+}
+
+func (s *SARIFSink) Write(v any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch t := v.(type) {
+	case rules.Result:
+		if t.Status == rules.StatusFail || t.Status == rules.StatusError {
+			s.results = append(s.results, t)
+		}
+	case Event:
+		if t.Type == "run.finished" || t.Type == EventRunInterrupted {
+			s.exitCode = t.ExitCode
+			s.finished = true
+		}
+	}
+	return nil
+}
+
+func (s *SARIFSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return writeFileAtomic(s.path, func(w io.Writer) error {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(buildSARIFLog(s.rules, s.results, s.exitCode, s.finished))
+	})
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations,omitempty"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string                     `json:"name"`
+	Rules []sarifReportingDescriptor `json:"rules"`
+}
+
+type sarifReportingDescriptor struct {
+	ID               string          `json:"id"`
+	Name             string          `json:"name,omitempty"`
+	ShortDescription *sarifMessage   `json:"shortDescription,omitempty"`
+	FullDescription  *sarifMessage   `json:"fullDescription,omitempty"`
+	Properties       *sarifRuleProps `json:"properties,omitempty"`
+}
+
+type sarifRuleProps struct {
+	Options []sarifRuleOption `json:"options,omitempty"`
+}
+
+type sarifRuleOption struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful bool `json:"executionSuccessful"`
+	ExitCode            int  `json:"exitCode"`
+}
+
+type sarifResult struct {
+	RuleID       string            `json:"ruleId"`
+	RuleIndex    int               `json:"ruleIndex"`
+	Kind         string            `json:"kind"`
+	Level        string            `json:"level"`
+	Message      sarifMessage      `json:"message"`
+	Locations    []sarifLocation   `json:"locations"`
+	Fingerprints map[string]string `json:"fingerprints"`
+	Properties   map[string]any    `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func buildSARIFLog(ruleList []rules.Rule, results []rules.Result, exitCode int, finished bool) sarifLog {
+	driver := sarifDriver{Name: "RepoMedic", Rules: []sarifReportingDescriptor{}}
+	index := make(map[string]int, len(ruleList))
+	for _, r := range ruleList {
+		index[r.ID()] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifDescriptor(r))
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	if finished {
+		// Exit codes 0-2 mean the scan ran; 3 and 4 are fatal and interrupted runs.
+		run.Invocations = []sarifInvocation{{ExecutionSuccessful: exitCode < 3, ExitCode: exitCode}}
+	}
+
+	for _, res := range results {
+		idx, ok := index[res.RuleID]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			index[res.RuleID] = idx
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifReportingDescriptor{ID: res.RuleID})
+		}
+		run.Results = append(run.Results, sarifResultFrom(res, idx))
+	}
+
+	return sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+}
+
+func sarifDescriptor(r rules.Rule) sarifReportingDescriptor {
+	d := sarifReportingDescriptor{
+		ID:               r.ID(),
+		Name:             r.Title(),
+		ShortDescription: &sarifMessage{Text: r.Title()},
+		FullDescription:  &sarifMessage{Text: r.Description()},
+	}
+	if cr, ok := r.(rules.ConfigurableRule); ok {
+		var opts []sarifRuleOption
+		for _, o := range cr.Options() {
+			opts = append(opts, sarifRuleOption{Name: o.Name, Description: o.Description, Default: o.Default})
+		}
+		if len(opts) > 0 {
+			d.Properties = &sarifRuleProps{Options: opts}
+		}
+	}
+	return d
+}
+
+func sarifResultFrom(res rules.Result, ruleIndex int) sarifResult {
+	out := sarifResult{
+		RuleID:       res.RuleID,
+		RuleIndex:    ruleIndex,
+		Kind:         "fail",
+		Level:        "error",
+		Message:      sarifMessage{Text: res.Message},
+		Fingerprints: map[string]string{sarifFingerprintKey: sarifFingerprint(res)},
+		Properties:   map[string]any{"status": string(res.Status)},
+	}
+	if res.Status == rules.StatusError {
+		// The rule could not be evaluated, so report it below real findings.
+		// SARIF requires kind "fail" for any level other than "none".
+		out.Level = "warning"
+	}
+	if out.Message.Text == "" {
+		out.Message.Text = string(res.Status)
+	}
+	if res.WrongID != "" {
+		out.Properties["wrong_id"] = res.WrongID
+	}
+	if len(res.Teams) > 0 {
+		out.Properties["teams"] = res.Teams
+	}
+
+	loc := sarifLocation{LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: res.Repo, Kind: "module"}}}
+	if res.Artifact != "" {
+		loc.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: res.Artifact}}
+	}
+	out.Locations = []sarifLocation{loc}
+	return out
+}
+
+// sarifFingerprint hashes the finding's identity, so the same rule failing for
+// the same repository (and file) keeps its fingerprint even if the message
+// changes.
+func sarifFingerprint(res rules.Result) string {
+	sum := sha256.Sum256([]byte(findingIdentity(res)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,112 @@
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"repomedic/internal/data"
+	"repomedic/internal/rules"
+	"testing"
+
+	"github.com/google/go-github/v81/github"
+)
+
+type sarifTestRule struct{ id string }
+
+func (r sarifTestRule) ID() string          { return r.id }
+func (r sarifTestRule) Title() string       { return "Title of " + r.id }
+func (r sarifTestRule) Description() string { return "Description of " + r.id }
+func (r sarifTestRule) Dependencies(context.Context, *github.Repository) ([]data.DependencyKey, error) {
+	return nil, nil
+}
+func (r sarifTestRule) Evaluate(context.Context, *github.Repository, data.DataContext) (rules.Result, error) {
+	return rules.Result{}, nil
+}
+func (r sarifTestRule) Options() []rules.Option {
+	return []rules.Option{{Name: "location", Description: "where", Default: "either"}}
+}
+func (r sarifTestRule) Configure(map[string]string) error { return nil }
+
+func readSARIF(t *testing.T, path string) sarifLog {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(b, &log); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v\n%s", err, b)
+	}
+	return log
+}
+
+func TestSARIFSink_WritesRulesAndFindings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out", "results.sarif")
+	s, err := NewSARIFSink(path, []rules.Rule{sarifTestRule{id: "codeowners-exists"}, sarifTestRule{id: "readme-root-exists"}})
+	if err != nil {
+		t.Fatalf("NewSARIFSink: %v", err)
+	}
+
+	_ = s.Write(rules.Result{RuleID: "codeowners-exists", Repo: "acme/a", Status: rules.StatusFail, Message: "missing", Artifact: "CODEOWNERS"})
+	_ = s.Write(rules.Result{RuleID: "readme-root-exists", Repo: "acme/b", Status: rules.StatusPass})
+	_ = s.Write(rules.Result{RuleID: "readme-root-exists", Repo: "acme/c", Status: rules.StatusError, Message: "boom"})
+	_ = s.Write(Event{Type: "run.finished", ExitCode: 1})
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no file before Close, stat err=%v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	log := readSARIF(t, path)
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected log header: version=%q runs=%d", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if got := len(run.Tool.Driver.Rules); got != 2 {
+		t.Fatalf("expected 2 rule descriptors, got %d", got)
+	}
+	d := run.Tool.Driver.Rules[0]
+	if d.ShortDescription.Text != "Title of codeowners-exists" || d.FullDescription.Text != "Description of codeowners-exists" {
+		t.Fatalf("unexpected descriptor: %+v", d)
+	}
+	if d.Properties == nil || len(d.Properties.Options) != 1 || d.Properties.Options[0].Default != "either" {
+		t.Fatalf("expected rule options in descriptor properties, got %+v", d.Properties)
+	}
+
+	if len(run.Results) != 2 {
+		t.Fatalf("expected PASS to be omitted, got %d results", len(run.Results))
+	}
+	fail := run.Results[0]
+	if fail.Level != "error" || fail.Kind != "fail" || fail.RuleIndex != 0 {
+		t.Fatalf("unexpected FAIL result: %+v", fail)
+	}
+	loc := fail.Locations[0]
+	if loc.LogicalLocations[0].FullyQualifiedName != "acme/a" {
+		t.Fatalf("expected logical location acme/a, got %+v", loc.LogicalLocations)
+	}
+	if loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation.URI != "CODEOWNERS" {
+		t.Fatalf("expected artifact CODEOWNERS, got %+v", loc.PhysicalLocation)
+	}
+	errRes := run.Results[1]
+	if errRes.Level != "warning" || errRes.Kind != "fail" || errRes.RuleIndex != 1 || errRes.Locations[0].PhysicalLocation != nil {
+		t.Fatalf("unexpected ERROR result: %+v", errRes)
+	}
+	if len(run.Invocations) != 1 || !run.Invocations[0].ExecutionSuccessful {
+		t.Fatalf("expected a successful invocation, got %+v", run.Invocations)
+	}
+}
+
+func TestSARIFFingerprint_StableAcrossMessagesAndCase(t *testing.T) {
+	a := rules.Result{RuleID: "r", Repo: "Acme/Repo", Message: "one", Artifact: "CODEOWNERS"}
+	b := rules.Result{RuleID: "r", Repo: "acme/repo", Message: "two", Artifact: "CODEOWNERS"}
+	if sarifFingerprint(a) != sarifFingerprint(b) {
+		t.Fatalf("expected fingerprint to ignore message and repo case")
+	}
+	c := b
+	c.Artifact = ".github/CODEOWNERS"
+	if sarifFingerprint(b) == sarifFingerprint(c) {
+		t.Fatalf("expected fingerprint to depend on artifact")
+	}
+}
@@ -0,0 +1,424 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"repomedic/internal/rules"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// TrendOptions limits what ComputeTrends reports.
+type TrendOptions struct {
+	// Runs is how many of the most recent runs the pass-rate series cover;
+	// 0 means all.
+	Runs int
+	// Oldest is how many of the oldest open findings to list.
+	Oldest int
+}
+
+// Trends summarizes recorded scans (see --history-dir).
+type Trends struct {
+	// Runs are the runs the pass-rate series cover, oldest first.
+	Runs []TrendRun `json:"runs"`
+	// Rules, Categories and Orgs hold one pass rate per entry of Runs.
+	// Categories are named by their short key, e.g. "default-branch".
+	Rules      []TrendSeries `json:"rules"`
+	Categories []TrendSeries `json:"categories"`
+	Orgs       []TrendSeries `json:"orgs"`
+	// TimeToFix covers every loaded run, not just Runs.
+	TimeToFix []TimeToFix `json:"time_to_fix"`
+	// OldestOpen lists findings still open in the latest run, longest open first.
+	OldestOpen []OpenFinding `json:"oldest_open"`
+}
+
+// TrendRun is one run in a trend series.
+type TrendRun struct {
+	At       time.Time `json:"at"`
+	Target   string    `json:"target"`
+	Repos    int       `json:"repos"`
+	PassRate *float64  `json:"pass_rate"`
+}
+
+// TrendSeries is a pass rate over time. PassRates holds nil for runs without
+// PASS, FAIL or ERROR results for the series; SKIPPED results are ignored.
+type TrendSeries struct {
+	Name      string     `json:"name"`
+	PassRates []*float64 `json:"pass_rates"`
+}
+
+// TimeToFix summarizes how long a rule's findings stayed open before a later
+// run no longer reported them.
+type TimeToFix struct {
+	RuleID        string `json:"rule_id"`
+	Resolved      int    `json:"resolved"`
+	MedianSeconds int64  `json:"median_seconds"`
+	MaxSeconds    int64  `json:"max_seconds"`
+}
+
+// OpenFinding is a finding open in the latest run.
+type OpenFinding struct {
+	Repo      string       `json:"repo"`
+	RuleID    string       `json:"rule_id"`
+	Artifact  string       `json:"artifact,omitempty"`
+	Status    rules.Status `json:"status"`
+	FirstSeen time.Time    `json:"first_seen"`
+	// AgeSeconds is the time from FirstSeen to the latest run.
+	AgeSeconds int64 `json:"age_seconds"`
+}
+
+// passCounter accumulates a pass rate.
+type passCounter struct{ pass, total int }
+
+func (c *passCounter) add(status rules.Status) {
+	switch status {
+	case rules.StatusPass:
+		c.pass++
+		c.total++
+	case rules.StatusFail, rules.StatusError:
+		c.total++
+	}
+}
+
+func (c *passCounter) rate() *float64 {
+	if c == nil || c.total == 0 {
+		return nil
+	}
+	r := float64(c.pass) / float64(c.total)
+	return &r
+}
+
+// ComputeTrends summarizes runs, which must be sorted oldest first.
+func ComputeTrends(runs []*HistoryRun, opts TrendOptions) *Trends {
+	t := &Trends{
+		Runs:       []TrendRun{},
+		Rules:      []TrendSeries{},
+		Categories: []TrendSeries{},
+		Orgs:       []TrendSeries{},
+		TimeToFix:  []TimeToFix{},
+		OldestOpen: []OpenFinding{},
+	}
+
+	window := runs
+	if opts.Runs > 0 && len(window) > opts.Runs {
+		window = window[len(window)-opts.Runs:]
+	}
+	byRule := make(map[string][]*passCounter)
+	byCategory := make(map[string][]*passCounter)
+	byOrg := make(map[string][]*passCounter)
+	count := func(m map[string][]*passCounter, key string, i int, status rules.Status) {
+		s, ok := m[key]
+		if !ok {
+			s = make([]*passCounter, len(window))
+			m[key] = s
+		}
+		if s[i] == nil {
+			s[i] = &passCounter{}
+		}
+		s[i].add(status)
+	}
+	for i, run := range window {
+		var all passCounter
+		for _, r := range run.Results {
+			all.add(r.Status)
+			count(byRule, r.RuleID, i, r.Status)
+			count(byCategory, categoryKeys[getCategory(r.RuleID)], i, r.Status)
+			org, _, _ := strings.Cut(r.Repo, "/")
+			count(byOrg, org, i, r.Status)
+		}
+		t.Runs = append(t.Runs, TrendRun{At: run.At, Target: run.Target, Repos: len(run.Repos), PassRate: all.rate()})
+	}
+	t.Rules = trendSeries(byRule)
+	t.Categories = trendSeries(byCategory)
+	t.Orgs = trendSeries(byOrg)
+
+	t.TimeToFix, t.OldestOpen = trackFindings(runs, opts.Oldest)
+	return t
+}
+
+func trendSeries(m map[string][]*passCounter) []TrendSeries {
+	out := make([]TrendSeries, 0, len(m))
+	for _, name := range sortedKeys(m) {
+		s := TrendSeries{Name: name}
+		for _, c := range m[name] {
+			s.PassRates = append(s.PassRates, c.rate())
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// trackFindings follows findings across runs. A finding opens the first time
+// it is reported as FAIL or ERROR and is fixed at the first later run that
+// scanned its repo with its rule without reporting it.
+func trackFindings(runs []*HistoryRun, oldest int) ([]TimeToFix, []OpenFinding) {
+	type openFinding struct {
+		HistoryResult
+		firstSeen time.Time
+	}
+	open := make(map[string]*openFinding)
+	fixes := make(map[string][]time.Duration)
+
+	for _, run := range runs {
+		scanned := lowerSet(run.Repos)
+		evaluated := make(map[string]struct{}, len(run.Rules))
+		for _, id := range run.Rules {
+			evaluated[id] = struct{}{}
+		}
+		current := make(map[string]HistoryResult)
+		for _, r := range run.Results {
+			if isFinding(r.result()) {
+				current[findingIdentity(r.result())] = r
+			}
+		}
+
+		for id, f := range open {
+			if _, still := current[id]; still {
+				continue
+			}
+			_, repoScanned := scanned[strings.ToLower(f.Repo)]
+			_, ruleEvaluated := evaluated[f.RuleID]
+			if !repoScanned || !ruleEvaluated {
+				continue
+			}
+			fixes[f.RuleID] = append(fixes[f.RuleID], run.At.Sub(f.firstSeen))
+			delete(open, id)
+		}
+		for id, r := range current {
+			if f, ok := open[id]; ok {
+				f.HistoryResult = r
+				continue
+			}
+			open[id] = &openFinding{HistoryResult: r, firstSeen: run.At}
+		}
+	}
+
+	ttf := make([]TimeToFix, 0, len(fixes))
+	for _, ruleID := range sortedKeys(fixes) {
+		d := fixes[ruleID]
+		sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+		median := d[len(d)/2]
+		if len(d)%2 == 0 {
+			median = (d[len(d)/2-1] + d[len(d)/2]) / 2
+		}
+		ttf = append(ttf, TimeToFix{
+			RuleID:        ruleID,
+			Resolved:      len(d),
+			MedianSeconds: int64(median / time.Second),
+			MaxSeconds:    int64(d[len(d)-1] / time.Second),
+		})
+	}
+
+	openList := make([]OpenFinding, 0, len(open))
+	if len(runs) > 0 {
+		latest := runs[len(runs)-1].At
+		for _, f := range open {
+			openList = append(openList, OpenFinding{
+				Repo:       f.Repo,
+				RuleID:     f.RuleID,
+				Artifact:   f.Artifact,
+				Status:     f.Status,
+				FirstSeen:  f.firstSeen,
+				AgeSeconds: int64(latest.Sub(f.firstSeen) / time.Second),
+			})
+		}
+	}
+	sort.Slice(openList, func(i, j int) bool {
+		a, b := openList[i], openList[j]
+		if !a.FirstSeen.Equal(b.FirstSeen) {
+			return a.FirstSeen.Before(b.FirstSeen)
+		}
+		if a.Repo != b.Repo {
+			return a.Repo < b.Repo
+		}
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		return a.Artifact < b.Artifact
+	})
+	if oldest >= 0 && len(openList) > oldest {
+		openList = openList[:oldest]
+	}
+	return ttf, openList
+}
+
+// WriteTrends renders t as text, json or markdown.
+func WriteTrends(w io.Writer, t *Trends, format string) error {
+	switch format {
+	case "text":
+		return writeTrendsText(w, t)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(t)
+	case "markdown":
+		return writeTrendsMarkdown(w, t)
+	default:
+		return fmt.Errorf("unsupported trends format: %s", format)
+	}
+}
+
+// trendSections pairs each pass-rate series with its heading, in display order.
+func (t *Trends) trendSections() []struct {
+	title  string
+	series []TrendSeries
+} {
+	return []struct {
+		title  string
+		series []TrendSeries
+	}{
+		{"Pass rate by rule", t.Rules},
+		{"Pass rate by category", t.Categories},
+		{"Pass rate by org", t.Orgs},
+	}
+}
+
+func formatPassRate(r *float64) string {
+	if r == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", *r*100)
+}
+
+// formatAge renders a duration in the largest sensible unit.
+func formatAge(seconds int64) string {
+	d := time.Duration(seconds) * time.Second
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+}
+
+func formatRunTime(at time.Time) string {
+	return at.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+func openFindingTarget(f OpenFinding) string {
+	if f.Artifact != "" {
+		return f.Repo + " " + f.RuleID + " (" + f.Artifact + ")"
+	}
+	return f.Repo + " " + f.RuleID
+}
+
+func writeTrendsText(w io.Writer, t *Trends) error {
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Runs (%d):\n", len(t.Runs))
+	for i, run := range t.Runs {
+		fmt.Fprintf(tw, "  #%d\t%s\t%s\t%d repos\tpass rate %s\n", i+1, formatRunTime(run.At), run.Target, run.Repos, formatPassRate(run.PassRate))
+	}
+	for _, s := range t.trendSections() {
+		fmt.Fprintf(tw, "\n%s:\n", s.title)
+		if len(s.series) == 0 {
+			fmt.Fprintln(tw, "  none")
+			continue
+		}
+		fmt.Fprint(tw, "  ")
+		for i := range t.Runs {
+			fmt.Fprintf(tw, "\t#%d", i+1)
+		}
+		fmt.Fprintln(tw)
+		for _, series := range s.series {
+			fmt.Fprintf(tw, "  %s", series.Name)
+			for _, r := range series.PassRates {
+				fmt.Fprintf(tw, "\t%s", formatPassRate(r))
+			}
+			fmt.Fprintln(tw)
+		}
+	}
+
+	fmt.Fprintln(tw, "\nTime to fix (resolved findings):")
+	if len(t.TimeToFix) == 0 {
+		fmt.Fprintln(tw, "  none")
+	} else {
+		fmt.Fprintln(tw, "  RULE\tRESOLVED\tMEDIAN\tMAX")
+		for _, f := range t.TimeToFix {
+			fmt.Fprintf(tw, "  %s\t%d\t%s\t%s\n", f.RuleID, f.Resolved, formatAge(f.MedianSeconds), formatAge(f.MaxSeconds))
+		}
+	}
+
+	fmt.Fprintln(tw, "\nOldest open findings:")
+	if len(t.OldestOpen) == 0 {
+		fmt.Fprintln(tw, "  none")
+	}
+	for _, f := range t.OldestOpen {
+		fmt.Fprintf(tw, "  %s\t[%s]\topen since %s (%s)\n", openFindingTarget(f), f.Status, formatRunTime(f.FirstSeen), formatAge(f.AgeSeconds))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeTrendsMarkdown(w io.Writer, t *Trends) error {
+	var b strings.Builder
+	b.WriteString("# RepoMedic Trends\n\n")
+	b.WriteString("## Runs\n\n")
+	b.WriteString("| Run | Time | Target | Repos | Pass Rate |\n")
+	b.WriteString("| --- | --- | --- | ---: | ---: |\n")
+	for i, run := range t.Runs {
+		fmt.Fprintf(&b, "| #%d | %s | %s | %d | %s |\n", i+1, formatRunTime(run.At), markdownCell(run.Target), run.Repos, formatPassRate(run.PassRate))
+	}
+	b.WriteString("\n")
+
+	for _, s := range t.trendSections() {
+		fmt.Fprintf(&b, "## %s\n\n", s.title)
+		if len(s.series) == 0 {
+			b.WriteString("- None\n\n")
+			continue
+		}
+		b.WriteString("| Name |")
+		sep := "| --- |"
+		for i := range t.Runs {
+			fmt.Fprintf(&b, " #%d |", i+1)
+			sep += " ---: |"
+		}
+		b.WriteString("\n" + sep + "\n")
+		for _, series := range s.series {
+			fmt.Fprintf(&b, "| %s |", markdownCell(series.Name))
+			for _, r := range series.PassRates {
+				fmt.Fprintf(&b, " %s |", formatPassRate(r))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Time to Fix\n\n")
+	if len(t.TimeToFix) == 0 {
+		b.WriteString("- None\n\n")
+	} else {
+		b.WriteString("| Rule | Resolved | Median | Max |\n")
+		b.WriteString("| --- | ---: | ---: | ---: |\n")
+		for _, f := range t.TimeToFix {
+			fmt.Fprintf(&b, "| %s | %d | %s | %s |\n", f.RuleID, f.Resolved, formatAge(f.MedianSeconds), formatAge(f.MaxSeconds))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Oldest Open Findings\n\n")
+	if len(t.OldestOpen) == 0 {
+		b.WriteString("- None\n")
+	} else {
+		b.WriteString("| Repo | Rule | Status | Open Since | Age |\n")
+		b.WriteString("| --- | --- | --- | --- | ---: |\n")
+		for _, f := range t.OldestOpen {
+			rule := f.RuleID
+			if f.Artifact != "" {
+				rule += " (" + f.Artifact + ")"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n", f.Repo, markdownCell(rule), f.Status, formatRunTime(f.FirstSeen), formatAge(f.AgeSeconds))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,132 @@
+package output
+
+import (
+	"bytes"
+	"repomedic/internal/rules"
+	"strings"
+	"testing"
+	"time"
+)
+
+func trendFixture() []*HistoryRun {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	const hygiene, branch = "readme-root-exists", "default-branch-protected"
+	return []*HistoryRun{
+		{At: day(1), Target: "repos", Repos: []string{"acme/a", "beta/b"}, Rules: []string{branch, hygiene}, Results: []HistoryResult{
+			{Repo: "acme/a", RuleID: hygiene, Status: rules.StatusFail},
+			{Repo: "acme/a", RuleID: branch, Status: rules.StatusPass},
+			{Repo: "beta/b", RuleID: hygiene, Status: rules.StatusFail},
+			{Repo: "beta/b", RuleID: branch, Status: rules.StatusError},
+		}},
+		{At: day(3), Target: "repos", Repos: []string{"acme/a", "beta/b"}, Rules: []string{branch, hygiene}, Results: []HistoryResult{
+			{Repo: "acme/a", RuleID: hygiene, Status: rules.StatusPass},
+			{Repo: "acme/a", RuleID: branch, Status: rules.StatusFail},
+			{Repo: "beta/b", RuleID: hygiene, Status: rules.StatusFail},
+			{Repo: "beta/b", RuleID: branch, Status: rules.StatusSkipped},
+		}},
+		// acme/a is not scanned, so its open finding stays open.
+		{At: day(6), Target: "repos", Repos: []string{"beta/b"}, Rules: []string{hygiene}, Results: []HistoryResult{
+			{Repo: "beta/b", RuleID: hygiene, Status: rules.StatusFail},
+		}},
+	}
+}
+
+func passRates(s TrendSeries) string {
+	out := make([]string, 0, len(s.PassRates))
+	for _, r := range s.PassRates {
+		out = append(out, formatPassRate(r))
+	}
+	return s.Name + " " + strings.Join(out, " ")
+}
+
+func TestComputeTrends(t *testing.T) {
+	tr := ComputeTrends(trendFixture(), TrendOptions{Oldest: 10})
+
+	if len(tr.Runs) != 3 || formatPassRate(tr.Runs[0].PassRate) != "25%" || tr.Runs[2].Repos != 1 {
+		t.Fatalf("unexpected runs: %+v", tr.Runs)
+	}
+
+	var got []string
+	for _, s := range tr.Rules {
+		got = append(got, passRates(s))
+	}
+	for _, s := range tr.Orgs {
+		got = append(got, passRates(s))
+	}
+	want := []string{
+		"default-branch-protected 50% 0% -",
+		"readme-root-exists 0% 50% 0%",
+		"acme 50% 50% -",
+		"beta 0% 0% 0%",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected pass rates:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if len(tr.Categories) != 2 || passRates(tr.Categories[1]) != "hygiene 0% 50% 0%" {
+		t.Fatalf("unexpected categories: %+v", tr.Categories)
+	}
+
+	twoDays := int64(48 * time.Hour / time.Second)
+	wantFix := []TimeToFix{
+		{RuleID: "default-branch-protected", Resolved: 1, MedianSeconds: twoDays, MaxSeconds: twoDays},
+		{RuleID: "readme-root-exists", Resolved: 1, MedianSeconds: twoDays, MaxSeconds: twoDays},
+	}
+	if len(tr.TimeToFix) != 2 || tr.TimeToFix[0] != wantFix[0] || tr.TimeToFix[1] != wantFix[1] {
+		t.Fatalf("unexpected time to fix: %+v", tr.TimeToFix)
+	}
+
+	if len(tr.OldestOpen) != 2 {
+		t.Fatalf("expected 2 open findings, got %+v", tr.OldestOpen)
+	}
+	first, second := tr.OldestOpen[0], tr.OldestOpen[1]
+	if first.Repo != "beta/b" || first.RuleID != "readme-root-exists" || formatAge(first.AgeSeconds) != "5d" {
+		t.Fatalf("unexpected oldest open finding: %+v", first)
+	}
+	if second.Repo != "acme/a" || second.RuleID != "default-branch-protected" || formatAge(second.AgeSeconds) != "3d" {
+		t.Fatalf("unexpected second open finding: %+v", second)
+	}
+}
+
+func TestComputeTrends_Limits(t *testing.T) {
+	tr := ComputeTrends(trendFixture(), TrendOptions{Runs: 2, Oldest: 1})
+	if len(tr.Runs) != 2 || !tr.Runs[0].At.Equal(trendFixture()[1].At) {
+		t.Fatalf("expected the last 2 runs, got %+v", tr.Runs)
+	}
+	for _, s := range tr.Rules {
+		if len(s.PassRates) != 2 {
+			t.Fatalf("expected 2 pass rates for %s, got %d", s.Name, len(s.PassRates))
+		}
+	}
+	// Time to fix still covers every run.
+	if len(tr.TimeToFix) != 2 || len(tr.OldestOpen) != 1 {
+		t.Fatalf("unexpected limits: %d fixes, %d open", len(tr.TimeToFix), len(tr.OldestOpen))
+	}
+}
+
+func TestWriteTrends_Formats(t *testing.T) {
+	tr := ComputeTrends(trendFixture(), TrendOptions{Oldest: 10})
+
+	var text bytes.Buffer
+	if err := WriteTrends(&text, tr, "text"); err != nil {
+		t.Fatalf("WriteTrends text failed: %v", err)
+	}
+	// Columns are aligned with padding; compare with whitespace collapsed.
+	flat := strings.Join(strings.Fields(text.String()), " ")
+	for _, want := range []string{"Runs (3):", "Pass rate by rule:", "readme-root-exists 0% 50% 0%", "Time to fix (resolved findings):", "beta/b readme-root-exists [FAIL] open since 2026-10-01 00:00 UTC (5d)"} {
+		if !strings.Contains(flat, want) {
+			t.Fatalf("expected %q in text output:\n%s", want, text.String())
+		}
+	}
+
+	var md bytes.Buffer
+	if err := WriteTrends(&md, tr, "markdown"); err != nil {
+		t.Fatalf("WriteTrends markdown failed: %v", err)
+	}
+	if !strings.Contains(md.String(), "| readme-root-exists | 0% | 50% | 0% |") || !strings.Contains(md.String(), "## Oldest Open Findings") {
+		t.Fatalf("unexpected markdown output:\n%s", md.String())
+	}
+
+	if err := WriteTrends(&bytes.Buffer{}, tr, "xml"); err == nil {
+		t.Fatalf("expected an error for an unsupported format")
+	}
+}
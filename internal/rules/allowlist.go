@@ -3,17 +3,21 @@ package rules
 import (
 	"fmt"
 	"path"
+	"repomedic/internal/data/models"
 	"strings"
 
 	"github.com/google/go-github/v81/github"
 )
 
 // AllowList handles common allow-listing logic for rules.
-// It supports allowing by repository name (exact match), glob pattern, and topics.
+// It supports allowing by repository name (exact match), glob pattern, topics,
+// and org custom property values.
 type AllowList struct {
 	Repos    map[string]bool
 	Patterns []string
 	Topics   []string
+	// Properties maps a custom property name to the values that allow a repository.
+	Properties map[string][]string
 }
 
 // Options returns the standard configuration options for allow-listing.
@@ -31,14 +35,19 @@ func (a *AllowList) Options() []Option {
 			Name:        "allow.topics",
 			Description: "Comma-separated list of topics. A repository with any of these topics is allowed.",
 		},
+		{
+			Name:        "allow.properties",
+			Description: "Comma-separated list of custom property NAME=VALUE pairs (e.g. tier=sandbox). A repository matching any pair is allowed.",
+		},
 	}
 }
 
 // Configure parses the configuration options to populate the AllowList.
-func (a *AllowList) Configure(opts map[string]string) {
+func (a *AllowList) Configure(opts map[string]string) error {
 	a.Repos = make(map[string]bool)
 	a.Patterns = nil
 	a.Topics = nil
+	a.Properties = nil
 
 	if val, ok := opts["allow.repos"]; ok && val != "" {
 		for _, s := range strings.Split(val, ",") {
@@ -67,11 +76,31 @@ func (a *AllowList) Configure(opts map[string]string) {
 			}
 		}
 	}
+
+	if val, ok := opts["allow.properties"]; ok && val != "" {
+		for _, s := range strings.Split(val, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+			name, value, ok := strings.Cut(s, "=")
+			name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+			if !ok || name == "" {
+				return fmt.Errorf("invalid allow.properties entry %q: expected NAME=VALUE", s)
+			}
+			if a.Properties == nil {
+				a.Properties = make(map[string][]string)
+			}
+			a.Properties[name] = append(a.Properties[name], value)
+		}
+	}
+	return nil
 }
 
 // IsAllowed checks if the repository is allowed by any of the configured rules.
+// props are the repository's custom property values (nil when unknown).
 // It returns true and a reason string if allowed, otherwise false and empty string.
-func (a *AllowList) IsAllowed(repo *github.Repository) (bool, string) {
+func (a *AllowList) IsAllowed(repo *github.Repository, props models.CustomProperties) (bool, string) {
 	if repo == nil {
 		return false, ""
 	}
@@ -102,15 +131,33 @@ func (a *AllowList) IsAllowed(repo *github.Repository) (bool, string) {
 		}
 	}
 
+	// Check allow.properties
+	for name, values := range a.Properties {
+		if props.Has(name, values) {
+			return true, "allow.properties"
+		}
+	}
+
 	return false, ""
 }
 
+// MetadataWaiver is the result metadata key naming the allow-list policy that
+// turned a failure into a pass.
+const MetadataWaiver = "waiver"
+
 // CheckResult evaluates the result and applies the allowlist logic.
-// If the result is a failure and the repository is allowed, it converts the result to a pass.
-func (a *AllowList) CheckResult(repo *github.Repository, result Result) Result {
+// If the result is a failure and the repository is allowed, it converts the result to a pass
+// and records the policy under MetadataWaiver.
+func (a *AllowList) CheckResult(repo *github.Repository, props models.CustomProperties, result Result) Result {
 	if result.Status == StatusFail {
-		if allowed, reason := a.IsAllowed(repo); allowed {
-			return PassResultWithMessage(repo, result.RuleID, fmt.Sprintf("Allowed failure: %s (Allowed by policy: %s)", result.Message, reason))
+		if allowed, reason := a.IsAllowed(repo, props); allowed {
+			metadata := make(map[string]any, len(result.Metadata)+1)
+			for k, v := range result.Metadata {
+				metadata[k] = v
+			}
+			metadata[MetadataWaiver] = reason
+			res := PassResultWithMetadata(repo, result.RuleID, fmt.Sprintf("Allowed failure: %s (Allowed by policy: %s)", result.Message, reason), metadata)
+			return res.WithArtifact(result.Artifact)
 		}
 	}
 	return result
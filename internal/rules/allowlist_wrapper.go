@@ -3,6 +3,8 @@ package rules
 import (
 	"context"
 	"repomedic/internal/data"
+	"repomedic/internal/data/models"
+	"slices"
 
 	"github.com/google/go-github/v81/github"
 )
@@ -28,9 +30,14 @@ func (w *AllowListWrapper) Description() string {
 	return w.Rule.Description()
 }
 
-// Dependencies returns the inner rule's Dependencies.
+// Dependencies returns the inner rule's Dependencies, plus the repository's
+// custom properties when allow.properties is configured.
 func (w *AllowListWrapper) Dependencies(ctx context.Context, repo *github.Repository) ([]data.DependencyKey, error) {
-	return w.Rule.Dependencies(ctx, repo)
+	deps, err := w.Rule.Dependencies(ctx, repo)
+	if err != nil || len(w.allowList.Properties) == 0 || slices.Contains(deps, data.DepRepoCustomProperties) {
+		return deps, err
+	}
+	return append(slices.Clone(deps), data.DepRepoCustomProperties), nil
 }
 
 // Evaluate calls the inner rule's Evaluate and then applies the allowlist logic.
@@ -39,7 +46,11 @@ func (w *AllowListWrapper) Evaluate(ctx context.Context, repo *github.Repository
 	if err != nil {
 		return result, err
 	}
-	return w.allowList.CheckResult(repo, result), nil
+	var props models.CustomProperties
+	if len(w.allowList.Properties) > 0 {
+		props, _ = data.Get(dc, data.RepoCustomProperties)
+	}
+	return w.allowList.CheckResult(repo, props, result), nil
 }
 
 // Options returns the combined options of the allowlist and the inner rule (if configurable).
@@ -53,7 +64,9 @@ func (w *AllowListWrapper) Options() []Option {
 
 // Configure configures the allowlist and the inner rule (if configurable).
 func (w *AllowListWrapper) Configure(opts map[string]string) error {
-	w.allowList.Configure(opts)
+	if err := w.allowList.Configure(opts); err != nil {
+		return err
+	}
 	if cr, ok := w.Rule.(ConfigurableRule); ok {
 		return cr.Configure(opts)
 	}
@@ -3,6 +3,8 @@ package rules
 import (
 	"context"
 	"repomedic/internal/data"
+	"repomedic/internal/data/models"
+	"slices"
 	"testing"
 
 	"github.com/google/go-github/v81/github"
@@ -54,6 +56,7 @@ func TestAllowListWrapper_Evaluate(t *testing.T) {
 		ruleFail       bool
 		allowConfig    map[string]string
 		expectedStatus Status
+		expectedWaiver string
 	}{
 		{
 			name:           "Pass - Rule passes, no allowlist",
@@ -72,6 +75,7 @@ func TestAllowListWrapper_Evaluate(t *testing.T) {
 			ruleFail:       true,
 			allowConfig:    map[string]string{"allow.repos": "org/repo"},
 			expectedStatus: StatusPass,
+			expectedWaiver: "allow.repos",
 		},
 		{
 			name:           "Fail - Rule fails, not allowed by repo",
@@ -99,6 +103,9 @@ func TestAllowListWrapper_Evaluate(t *testing.T) {
 			if result.Status != tt.expectedStatus {
 				t.Errorf("expected status %v, got %v", tt.expectedStatus, result.Status)
 			}
+			if got := result.Waiver(); got != tt.expectedWaiver {
+				t.Errorf("expected waiver %q, got %q", tt.expectedWaiver, got)
+			}
 		})
 	}
 }
@@ -109,9 +116,9 @@ func TestAllowListWrapper_Options(t *testing.T) {
 	wrapper := &AllowListWrapper{Rule: inner}
 	opts := wrapper.Options()
 
-	// Should have allowlist options (3)
-	if len(opts) != 3 {
-		t.Errorf("expected 3 options, got %d", len(opts))
+	// Should have allowlist options (4)
+	if len(opts) != 4 {
+		t.Errorf("expected 4 options, got %d", len(opts))
 	}
 
 	// Test with configurable inner rule
@@ -119,9 +126,9 @@ func TestAllowListWrapper_Options(t *testing.T) {
 	wrapperConf := &AllowListWrapper{Rule: innerConf}
 	optsConf := wrapperConf.Options()
 
-	// Should have allowlist options (3) + inner options (1)
-	if len(optsConf) != 4 {
-		t.Errorf("expected 4 options, got %d", len(optsConf))
+	// Should have allowlist options (4) + inner options (1)
+	if len(optsConf) != 5 {
+		t.Errorf("expected 5 options, got %d", len(optsConf))
 	}
 }
 
@@ -149,3 +156,48 @@ func TestAllowListWrapper_Configure(t *testing.T) {
 		t.Error("inner rule not configured correctly")
 	}
 }
+
+func TestAllowListWrapper_AllowProperties(t *testing.T) {
+	repo := &github.Repository{FullName: github.Ptr("org/repo")}
+	wrapper := &AllowListWrapper{Rule: &MockRule{id: "mock-rule", fail: true}}
+	if err := wrapper.Configure(map[string]string{"allow.properties": "tier=sandbox, lifecycle=archived"}); err != nil {
+		t.Fatalf("Configure error: %v", err)
+	}
+
+	deps, err := wrapper.Dependencies(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("Dependencies error: %v", err)
+	}
+	if !slices.Contains(deps, data.DepRepoCustomProperties) {
+		t.Fatalf("expected %s in dependencies, got %v", data.DepRepoCustomProperties, deps)
+	}
+
+	tests := []struct {
+		name  string
+		props models.CustomProperties
+		want  Status
+	}{
+		{name: "matching property", props: models.CustomProperties{"tier": {"sandbox"}}, want: StatusPass},
+		{name: "other value", props: models.CustomProperties{"tier": {"critical"}}, want: StatusFail},
+		{name: "no properties", props: models.CustomProperties{}, want: StatusFail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dc := data.NewMapDataContext(map[data.DependencyKey]any{data.DepRepoCustomProperties: tt.props})
+			result, err := wrapper.Evaluate(context.Background(), repo, dc)
+			if err != nil {
+				t.Fatalf("Evaluate error: %v", err)
+			}
+			if result.Status != tt.want {
+				t.Errorf("expected status %v, got %v (%s)", tt.want, result.Status, result.Message)
+			}
+		})
+	}
+}
+
+func TestAllowListWrapper_AllowPropertiesInvalid(t *testing.T) {
+	wrapper := &AllowListWrapper{Rule: &MockRule{id: "mock-rule"}}
+	if err := wrapper.Configure(map[string]string{"allow.properties": "tier"}); err == nil {
+		t.Fatal("expected error for entry without '='")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"repomedic/internal/data"
-	"repomedic/internal/data/models"
 	"repomedic/internal/rules"
 	"strings"
 
@@ -32,14 +31,12 @@ func (r *BranchProtectEnforceAdmins) Dependencies(ctx context.Context, repo *git
 }
 
 func (r *BranchProtectEnforceAdmins) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
-	val, ok := dc.Get(data.DepRepoClassicBranchProtections)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), fmt.Sprintf("missing dependency: %s", data.DepRepoClassicBranchProtections)), nil
+	protections, err := data.Get(dc, data.RepoClassicBranchProtections)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
-
-	protections, ok := val.(*models.ClassicBranchProtections)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), fmt.Sprintf("unexpected type for %s: %T", data.DepRepoClassicBranchProtections, val)), nil
+	if protections == nil {
+		return rules.ErrorResult(repo, r.ID(), "Dependency is nil"), nil
 	}
 
 	if len(protections.Protections) == 0 {
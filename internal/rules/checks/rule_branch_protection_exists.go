@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"repomedic/internal/data"
-	"repomedic/internal/data/models"
 	"repomedic/internal/rules"
 
 	"github.com/google/go-github/v81/github"
@@ -36,21 +35,16 @@ func (r *BranchProtectionExistsRule) Dependencies(ctx context.Context, repo *git
 }
 
 func (r *BranchProtectionExistsRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
-	val, ok := dc.Get(data.DepRepoProtectedBranchesDeletionStatus)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
+	status, err := data.Get(dc, data.RepoProtectedBranchesDeletionStatus)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
 
 	// nil means no protected branches data available
-	if val == nil {
+	if status == nil {
 		return rules.FailResult(repo, r.ID(), "Repository has no branch protection rules or rulesets configured"), nil
 	}
 
-	status, ok := val.(*models.ProtectedBranchesDeletionStatus)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Invalid dependency type"), nil
-	}
-
 	if len(status.Branches) > 0 {
 		return rules.PassResult(repo, r.ID()), nil
 	}
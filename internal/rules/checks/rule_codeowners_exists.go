@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"repomedic/internal/data"
-	"repomedic/internal/data/models"
 	"repomedic/internal/rules"
 	"strings"
 
@@ -75,20 +74,16 @@ func (r *CodeownersExistsRule) Dependencies(ctx context.Context, repo *github.Re
 }
 
 func (r *CodeownersExistsRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchCodeowners)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
+	presence, err := data.Get(dc, data.RepoDefaultBranchCodeowners)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
-	if val == nil {
+	if presence == nil {
 		return rules.ErrorResult(repo, r.ID(), "Dependency is nil"), nil
 	}
 
-	presence, ok := val.(*models.CodeownersPresence)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Invalid dependency type"), nil
-	}
-
 	var result rules.Result
+	artifact := "CODEOWNERS"
 	switch r.location {
 	case "root":
 		if presence.Root {
@@ -97,6 +92,7 @@ func (r *CodeownersExistsRule) Evaluate(ctx context.Context, repo *github.Reposi
 			result = rules.FailResult(repo, r.ID(), "CODEOWNERS not found at repository root")
 		}
 	case "github":
+		artifact = ".github/CODEOWNERS"
 		if presence.GitHub {
 			result = rules.PassResultWithMessage(repo, r.ID(), "CODEOWNERS present in .github directory")
 		} else {
@@ -107,9 +103,11 @@ func (r *CodeownersExistsRule) Evaluate(ctx context.Context, repo *github.Reposi
 			loc := "repository root"
 			if presence.GitHub {
 				loc = ".github directory"
+				artifact = ".github/CODEOWNERS"
 			}
 			if presence.Root && presence.GitHub {
 				loc = "repository root and .github directory"
+				artifact = "CODEOWNERS"
 			}
 			result = rules.PassResultWithMessage(repo, r.ID(), "CODEOWNERS present in "+loc)
 		} else {
@@ -120,6 +118,7 @@ func (r *CodeownersExistsRule) Evaluate(ctx context.Context, repo *github.Reposi
 		return rules.ErrorResult(repo, r.ID(), "Invalid configuration"), nil
 	}
 
+	result.Artifact = artifact
 	return result, nil
 }
 
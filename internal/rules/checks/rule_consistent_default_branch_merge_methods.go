@@ -115,14 +115,9 @@ func (r *ConsistentDefaultBranchMergeMethodsRule) Dependencies(ctx context.Conte
 
 func (r *ConsistentDefaultBranchMergeMethodsRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
 	// Get the repo's effective merge methods
-	effectiveVal, ok := dc.Get(data.DepRepoEffectiveMergeMethods)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Dependency missing: repo effective merge methods"), nil
-	}
-
-	effectiveMask, ok := effectiveVal.(models.MergeMethodMask)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Invalid dependency type: repo effective merge methods"), nil
+	effectiveMask, err := data.Get(dc, data.RepoEffectiveMergeMethods)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
 
 	// Determine the baseline
@@ -137,20 +132,15 @@ func (r *ConsistentDefaultBranchMergeMethodsRule) Evaluate(ctx context.Context,
 		}
 	} else {
 		// Get the org-level baseline
-		baselineVal, ok := dc.Get(data.DepMergeBaseline)
-		if !ok {
-			return rules.ErrorResult(repo, r.ID(), "Dependency missing: merge baseline"), nil
+		baseline, err = data.Get(dc, data.MergeBaseline)
+		if err != nil {
+			return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 		}
 
-		if baselineVal == nil {
+		if baseline == nil {
 			// nil means no baseline could be determined at all
 			return rules.SkippedResult(repo, r.ID(), "No merge baseline available"), nil
 		}
-
-		baseline, ok = baselineVal.(*models.MergeBaseline)
-		if !ok {
-			return rules.ErrorResult(repo, r.ID(), "Invalid dependency type: merge baseline"), nil
-		}
 	}
 
 	// Handle baseline states
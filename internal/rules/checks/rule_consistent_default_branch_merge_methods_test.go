@@ -163,7 +163,7 @@ func TestConsistentDefaultBranchMergeMethodsRule_Evaluate(t *testing.T) {
 				},
 			},
 			expectedStatus: rules.StatusError,
-			wantMsgContain: "Dependency missing: repo effective merge methods",
+			wantMsgContain: "Dependency missing: repo.effective_merge_methods",
 		},
 		{
 			name:      "ERROR when merge baseline dependency missing",
@@ -172,7 +172,7 @@ func TestConsistentDefaultBranchMergeMethodsRule_Evaluate(t *testing.T) {
 				data.DepRepoEffectiveMergeMethods: models.MergeMethodSquash,
 			},
 			expectedStatus: rules.StatusError,
-			wantMsgContain: "Dependency missing: merge baseline",
+			wantMsgContain: "Dependency missing: org.merge_baseline_selected",
 		},
 		{
 			name:      "ERROR when effective merge methods has wrong type",
@@ -186,7 +186,7 @@ func TestConsistentDefaultBranchMergeMethodsRule_Evaluate(t *testing.T) {
 				},
 			},
 			expectedStatus: rules.StatusError,
-			wantMsgContain: "Invalid dependency type: repo effective merge methods",
+			wantMsgContain: "Invalid dependency type: repo.effective_merge_methods",
 		},
 		{
 			name:      "ERROR when merge baseline has wrong type",
@@ -196,7 +196,7 @@ func TestConsistentDefaultBranchMergeMethodsRule_Evaluate(t *testing.T) {
 				data.DepMergeBaseline:             "not a baseline",
 			},
 			expectedStatus: rules.StatusError,
-			wantMsgContain: "Invalid dependency type: merge baseline",
+			wantMsgContain: "Invalid dependency type: org.merge_baseline_selected",
 		},
 	}
 
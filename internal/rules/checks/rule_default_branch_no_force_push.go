@@ -38,13 +38,9 @@ func (r *DefaultBranchNoForcePushRule) Dependencies(ctx context.Context, repo *g
 func (r *DefaultBranchNoForcePushRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
 	defaultBranch := repo.GetDefaultBranch()
 	if defaultBranch == "" {
-		val, ok := dc.Get(data.DepRepoMetadata)
-		if !ok {
-			return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
-		}
-		meta, ok := val.(*github.Repository)
-		if !ok {
-			return rules.ErrorResult(repo, r.ID(), "Invalid dependency type"), nil
+		meta, err := data.Get(dc, data.RepoMetadata)
+		if err != nil {
+			return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 		}
 		defaultBranch = meta.GetDefaultBranch()
 	}
@@ -72,21 +68,16 @@ func (r *DefaultBranchNoForcePushRule) Evaluate(ctx context.Context, repo *githu
 // classicProtectionBlocksForcePush checks if classic branch protection blocks force pushes.
 // Returns true if protection exists and AllowForcePushes is either nil or explicitly disabled.
 func classicProtectionBlocksForcePush(dc data.DataContext) (bool, string) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchClassicProtection)
-	if !ok {
-		return false, "Dependency missing"
+	protection, err := data.Get(dc, data.RepoDefaultBranchClassicProtection)
+	if err != nil {
+		return false, rules.DependencyErrorMessage(err)
 	}
 
-	if val == nil {
+	if protection == nil {
 		// No classic protection configured
 		return false, ""
 	}
 
-	protection, ok := val.(*github.Protection)
-	if !ok {
-		return false, "Invalid dependency type"
-	}
-
 	// Classic branch protection blocks force pushes by default.
 	// Force pushes are only allowed if AllowForcePushes is explicitly set and Enabled is true.
 	if protection.AllowForcePushes != nil && protection.AllowForcePushes.Enabled {
@@ -100,21 +91,16 @@ func classicProtectionBlocksForcePush(dc data.DataContext) (bool, string) {
 // effectiveRulesBlockForcePush checks if any effective ruleset rule blocks force pushes.
 // The "non_fast_forward" rule type blocks force pushes.
 func effectiveRulesBlockForcePush(dc data.DataContext) (bool, string) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchEffectiveRules)
-	if !ok {
-		return false, "Dependency missing"
+	branchRules, err := data.Get(dc, data.RepoDefaultBranchEffectiveRules)
+	if err != nil {
+		return false, rules.DependencyErrorMessage(err)
 	}
-	if val == nil {
+	if branchRules == nil {
 		return false, ""
 	}
 
-	rules, ok := val.(*github.BranchRules)
-	if !ok {
-		return false, "Invalid dependency type"
-	}
-
 	// In v81, NonFastForward is a slice; if non-empty, force push is blocked
-	if len(rules.NonFastForward) > 0 {
+	if len(branchRules.NonFastForward) > 0 {
 		return true, ""
 	}
 	return false, ""
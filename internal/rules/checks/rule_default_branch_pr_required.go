@@ -35,13 +35,9 @@ func (r *DefaultBranchPRRequiredRule) Dependencies(ctx context.Context, repo *gi
 func (r *DefaultBranchPRRequiredRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
 	defaultBranch := repo.GetDefaultBranch()
 	if defaultBranch == "" {
-		val, ok := dc.Get(data.DepRepoMetadata)
-		if !ok {
-			return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
-		}
-		meta, ok := val.(*github.Repository)
-		if !ok {
-			return rules.ErrorResult(repo, r.ID(), "Invalid dependency type"), nil
+		meta, err := data.Get(dc, data.RepoMetadata)
+		if err != nil {
+			return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 		}
 		defaultBranch = meta.GetDefaultBranch()
 	}
@@ -67,39 +63,28 @@ func (r *DefaultBranchPRRequiredRule) Evaluate(ctx context.Context, repo *github
 }
 
 func classicProtectionRequiresPR(dc data.DataContext) (bool, string) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchClassicProtection)
-	if !ok {
-		return false, "Dependency missing"
+	protection, err := data.Get(dc, data.RepoDefaultBranchClassicProtection)
+	if err != nil {
+		return false, rules.DependencyErrorMessage(err)
 	}
-
-	if val == nil {
+	if protection == nil {
 		return false, ""
 	}
 
-	protection, ok := val.(*github.Protection)
-	if !ok {
-		return false, "Invalid dependency type"
-	}
-
 	return protection.RequiredPullRequestReviews != nil, ""
 }
 
 func effectiveRulesRequirePR(dc data.DataContext) (bool, string) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchEffectiveRules)
-	if !ok {
-		return false, "Dependency missing"
+	branchRules, err := data.Get(dc, data.RepoDefaultBranchEffectiveRules)
+	if err != nil {
+		return false, rules.DependencyErrorMessage(err)
 	}
-	if val == nil {
+	if branchRules == nil {
 		return false, ""
 	}
 
-	rules, ok := val.(*github.BranchRules)
-	if !ok {
-		return false, "Invalid dependency type"
-	}
-
 	// In v81, PullRequest is a slice; if non-empty, PRs are required
-	if len(rules.PullRequest) > 0 {
+	if len(branchRules.PullRequest) > 0 {
 		return true, ""
 	}
 	return false, ""
@@ -170,19 +170,14 @@ func (r *DefaultBranchPRReviewSettingsRule) Evaluate(ctx context.Context, repo *
 }
 
 func classicPRReviewSettingsOK(dc data.DataContext, cfg *DefaultBranchPRReviewSettingsRule) (ok bool, detail string, errMsg string) {
-	val, exists := dc.Get(data.DepRepoDefaultBranchClassicProtection)
-	if !exists {
-		return false, "unknown", "Dependency missing"
+	protection, err := data.Get(dc, data.RepoDefaultBranchClassicProtection)
+	if err != nil {
+		return false, "unknown", rules.DependencyErrorMessage(err)
 	}
-	if val == nil {
+	if protection == nil {
 		return false, "branch not protected (nil)", ""
 	}
 
-	protection, okType := val.(*github.Protection)
-	if !okType {
-		return false, "unknown", "Invalid dependency type"
-	}
-
 	rr := protection.RequiredPullRequestReviews
 	if rr == nil {
 		return false, "required_pull_request_reviews not configured", ""
@@ -228,19 +223,14 @@ func classicPRReviewSettingsOK(dc data.DataContext, cfg *DefaultBranchPRReviewSe
 }
 
 func effectivePRReviewSettingsOK(dc data.DataContext, cfg *DefaultBranchPRReviewSettingsRule) (ok bool, detail string, errMsg string) {
-	val, exists := dc.Get(data.DepRepoDefaultBranchEffectiveRules)
-	if !exists {
-		return false, "unknown", "Dependency missing"
+	branchRules, err := data.Get(dc, data.RepoDefaultBranchEffectiveRules)
+	if err != nil {
+		return false, "unknown", rules.DependencyErrorMessage(err)
 	}
-	if val == nil {
+	if branchRules == nil {
 		return false, "no effective rules (nil)", ""
 	}
 
-	branchRules, okType := val.(*github.BranchRules)
-	if !okType {
-		return false, "unknown", "Invalid dependency type"
-	}
-
 	// In v81, PullRequest is a slice of *PullRequestBranchRule
 	if len(branchRules.PullRequest) == 0 {
 		return false, "no pull_request rule found", ""
@@ -36,13 +36,9 @@ func (r *DefaultBranchProtectedRule) Dependencies(ctx context.Context, repo *git
 func (r *DefaultBranchProtectedRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
 	defaultBranch := repo.GetDefaultBranch()
 	if defaultBranch == "" {
-		val, ok := dc.Get(data.DepRepoMetadata)
-		if !ok {
-			return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
-		}
-		meta, ok := val.(*github.Repository)
-		if !ok {
-			return rules.ErrorResult(repo, r.ID(), "Invalid dependency type"), nil
+		meta, err := data.Get(dc, data.RepoMetadata)
+		if err != nil {
+			return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 		}
 		defaultBranch = meta.GetDefaultBranch()
 	}
@@ -68,33 +64,24 @@ func (r *DefaultBranchProtectedRule) Evaluate(ctx context.Context, repo *github.
 }
 
 func classicProtectionExists(dc data.DataContext) (bool, string) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchClassicProtection)
-	if !ok {
-		return false, "Dependency missing"
-	}
-
-	if val == nil {
-		return false, ""
+	protection, err := data.Get(dc, data.RepoDefaultBranchClassicProtection)
+	if err != nil {
+		return false, rules.DependencyErrorMessage(err)
 	}
 
-	// If val is not nil, classic protection exists
-	return true, ""
+	// If protection is not nil, classic protection exists
+	return protection != nil, ""
 }
 
 func effectiveRulesExist(dc data.DataContext) (bool, string) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchEffectiveRules)
-	if !ok {
-		return false, "Dependency missing"
+	branchRules, err := data.Get(dc, data.RepoDefaultBranchEffectiveRules)
+	if err != nil {
+		return false, rules.DependencyErrorMessage(err)
 	}
-	if val == nil {
+	if branchRules == nil {
 		return false, ""
 	}
 
-	branchRules, ok := val.(*github.BranchRules)
-	if !ok {
-		return false, "Invalid dependency type"
-	}
-
 	// In v81, BranchRules has typed fields for each rule type.
 	// Any non-empty slice indicates rules exist.
 	if len(branchRules.PullRequest) > 0 ||
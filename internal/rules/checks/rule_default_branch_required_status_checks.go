@@ -88,18 +88,13 @@ func (r *DefaultBranchRequiredStatusChecks) Configure(opts map[string]string) er
 }
 
 func (r *DefaultBranchRequiredStatusChecks) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchEffectiveRules)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), fmt.Sprintf("missing dependency: %s", data.DepRepoDefaultBranchEffectiveRules)), nil
-	}
-
-	branchRules, ok := val.(*github.BranchRules)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), fmt.Sprintf("unexpected type for %s: %T", data.DepRepoDefaultBranchEffectiveRules, val)), nil
+	branchRules, err := data.Get(dc, data.RepoDefaultBranchEffectiveRules)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
 
 	// In v81, RequiredStatusChecks is a slice of *RequiredStatusChecksBranchRule
-	if len(branchRules.RequiredStatusChecks) == 0 {
+	if branchRules == nil || len(branchRules.RequiredStatusChecks) == 0 {
 		return rules.FailResult(repo, r.ID(), "Default branch does not require status checks"), nil
 	}
 
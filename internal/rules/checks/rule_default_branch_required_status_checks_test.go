@@ -47,7 +47,7 @@ func TestDefaultBranchRequiredStatusChecks_Evaluate(t *testing.T) {
 			name:           "missing dependency",
 			data:           map[data.DependencyKey]any{},
 			expectedStatus: rules.StatusError,
-			expectedMsg:    "missing dependency",
+			expectedMsg:    "Dependency missing",
 		},
 		{
 			name: "wrong type",
@@ -55,7 +55,7 @@ func TestDefaultBranchRequiredStatusChecks_Evaluate(t *testing.T) {
 				data.DepRepoDefaultBranchEffectiveRules: "wrong",
 			},
 			expectedStatus: rules.StatusError,
-			expectedMsg:    "unexpected type",
+			expectedMsg:    "Invalid dependency type",
 		},
 		{
 			name: "no rules",
@@ -27,14 +27,12 @@ func (r *DescriptionExistsRule) Dependencies(ctx context.Context, repo *github.R
 }
 
 func (r *DescriptionExistsRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
-	val, ok := dc.Get(data.DepRepoMetadata)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
+	meta, err := data.Get(dc, data.RepoMetadata)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
-
-	meta, ok := val.(*github.Repository)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Unexpected type for metadata"), nil
+	if meta == nil {
+		return rules.ErrorResult(repo, r.ID(), "Dependency is nil"), nil
 	}
 
 	if meta.GetDescription() == "" {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"repomedic/internal/data"
-	"repomedic/internal/data/models"
 	"repomedic/internal/rules"
 	"sort"
 	"strings"
@@ -36,19 +35,14 @@ func (r *ProtectedBranchesBlockDeletionRule) Dependencies(ctx context.Context, r
 }
 
 func (r *ProtectedBranchesBlockDeletionRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
-	val, ok := dc.Get(data.DepRepoProtectedBranchesDeletionStatus)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
+	report, err := data.Get(dc, data.RepoProtectedBranchesDeletionStatus)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
-	if val == nil {
+	if report == nil {
 		return rules.ErrorResult(repo, r.ID(), "Dependency is nil"), nil
 	}
 
-	report, ok := val.(*models.ProtectedBranchesDeletionStatus)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), fmt.Sprintf("Invalid dependency type: %T", val)), nil
-	}
-
 	if report.Truncated {
 		return rules.ErrorResult(repo, r.ID(), fmt.Sprintf("Protected scope scan truncated at limit=%d; cannot safely determine deletion policy for all protected scopes", report.Limit)), nil
 	}
@@ -3,7 +3,6 @@ package checks
 import (
 	"context"
 	"repomedic/internal/data"
-	"repomedic/internal/data/models"
 	"repomedic/internal/rules"
 	"strings"
 
@@ -29,35 +28,30 @@ func (r *ReadmeRootExistsRule) Dependencies(ctx context.Context, repo *github.Re
 }
 
 func (r *ReadmeRootExistsRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
-	val, ok := dc.Get(data.DepRepoDefaultBranchReadme)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
+	presence, err := data.Get(dc, data.RepoDefaultBranchReadme)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
-	if val == nil {
+	if presence == nil {
 		return rules.ErrorResult(repo, r.ID(), "Dependency is nil"), nil
 	}
 
-	presence, ok := val.(*models.ReadmePresence)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Invalid dependency type"), nil
-	}
-
 	if presence.Found {
 		// Enforce README.md at repo root, but accept casing variants.
 		p := strings.TrimSpace(presence.Path)
 		if p != "" && strings.Contains(p, "/") {
-			return rules.FailResult(repo, r.ID(), "README found but not at repository root (found "+p+")"), nil
+			return rules.FailResult(repo, r.ID(), "README found but not at repository root (found "+p+")").WithArtifact(p), nil
 		}
 		if p == "" {
-			return rules.FailResult(repo, r.ID(), "README found but path is unknown"), nil
+			return rules.FailResult(repo, r.ID(), "README found but path is unknown").WithArtifact("README.md"), nil
 		}
 		if !strings.EqualFold(p, "README.md") {
-			return rules.FailResult(repo, r.ID(), "README found but filename is not README.md (found "+p+")"), nil
+			return rules.FailResult(repo, r.ID(), "README found but filename is not README.md (found "+p+")").WithArtifact(p), nil
 		}
-		return rules.PassResultWithMessage(repo, r.ID(), "README present at repository root ("+p+")"), nil
+		return rules.PassResultWithMessage(repo, r.ID(), "README present at repository root ("+p+")").WithArtifact(p), nil
 	}
 
-	return rules.FailResult(repo, r.ID(), "README not found (expected README.md at repository root)"), nil
+	return rules.FailResult(repo, r.ID(), "README not found (expected README.md at repository root)").WithArtifact("README.md"), nil
 }
 
 func init() {
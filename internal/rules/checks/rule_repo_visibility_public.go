@@ -37,10 +37,8 @@ func (r *RepoVisibilityPublicRule) Dependencies(ctx context.Context, repo *githu
 func (r *RepoVisibilityPublicRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
 	// Use metadata from context if available, otherwise fallback to repo argument
 	var targetRepo *github.Repository
-	if val, ok := dc.Get(data.DepRepoMetadata); ok && val != nil {
-		if meta, ok := val.(*github.Repository); ok {
-			targetRepo = meta
-		}
+	if meta, err := data.Get(dc, data.RepoMetadata); err == nil && meta != nil {
+		targetRepo = meta
 	}
 
 	if targetRepo == nil {
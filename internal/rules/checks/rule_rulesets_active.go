@@ -35,21 +35,16 @@ func (r *RulesetsActiveRule) Dependencies(ctx context.Context, repo *github.Repo
 }
 
 func (r *RulesetsActiveRule) Evaluate(ctx context.Context, repo *github.Repository, dc data.DataContext) (rules.Result, error) {
-	val, ok := dc.Get(data.DepRepoAllRulesets)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Dependency missing"), nil
+	rulesets, err := data.Get(dc, data.RepoAllRulesets)
+	if err != nil {
+		return rules.ErrorResult(repo, r.ID(), rules.DependencyErrorMessage(err)), nil
 	}
 
 	// nil means no rulesets configured (known absence) - this passes since there are no inactive rulesets
-	if val == nil {
+	if rulesets == nil {
 		return rules.PassResultWithMessage(repo, r.ID(), "No rulesets configured"), nil
 	}
 
-	rulesets, ok := val.([]*github.RepositoryRuleset)
-	if !ok {
-		return rules.ErrorResult(repo, r.ID(), "Invalid dependency type"), nil
-	}
-
 	// No rulesets - pass
 	if len(rulesets) == 0 {
 		return rules.PassResultWithMessage(repo, r.ID(), "No rulesets configured"), nil
@@ -19,4 +19,13 @@ type Result struct {
 	// Metadata contains structured data supporting the result (e.g. lists, counts).
 	Metadata map[string]any `json:"metadata,omitempty"`
 	WrongID  string         `json:"wrong_id,omitempty"`
+	// Artifact is the repository file the result concerns (e.g. "CODEOWNERS"),
+	// when the rule is about a file. For missing files it is the expected path.
+	Artifact string `json:"artifact,omitempty"`
+	// Teams lists the teams (ORG/TEAM-SLUG) responsible for the repo when it
+	// was targeted with --team.
+	Teams []string `json:"teams,omitempty"`
+	// Baselined marks a FAIL that is already recorded in the --baseline
+	// file; it is reported but does not affect the exit code.
+	Baselined bool `json:"baselined,omitempty"`
 }
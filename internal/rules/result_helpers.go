@@ -1,6 +1,11 @@
 package rules
 
-import "github.com/google/go-github/v81/github"
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v81/github"
+)
 
 func RepoFullName(repo *github.Repository) string {
 	if repo == nil {
@@ -37,6 +42,14 @@ func ErrorResult(repo *github.Repository, ruleID string, message string) Result
 	return NewResult(repo, ruleID, StatusError, message)
 }
 
+// DependencyErrorMessage turns an error from data.Get into a result message,
+// capitalized like the other rule messages ("Dependency missing: repo.metadata").
+func DependencyErrorMessage(err error) string {
+	msg := err.Error()
+	r, size := utf8.DecodeRuneInString(msg)
+	return string(unicode.ToUpper(r)) + msg[size:]
+}
+
 func SkippedResult(repo *github.Repository, ruleID string, message string) Result {
 	return NewResult(repo, ruleID, StatusSkipped, message)
 }
@@ -52,3 +65,16 @@ func FailResultWithMetadata(repo *github.Repository, ruleID string, message stri
 	res.Metadata = metadata
 	return res
 }
+
+// WithArtifact returns r with Artifact set to path.
+func (r Result) WithArtifact(path string) Result {
+	r.Artifact = path
+	return r
+}
+
+// Waiver returns the allow-list policy that waived r's failure, or "" if r was
+// not waived.
+func (r Result) Waiver() string {
+	w, _ := r.Metadata[MetadataWaiver].(string)
+	return w
+}